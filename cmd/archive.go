@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+// Tags recording where archived artifacts went
+const (
+	tagArchiveLocation   = "archive.location"
+	tagArchiveDigest     = "archive.sha256"
+	tagArchiveFileCount  = "archive.file_count"
+	tagArchiveArchivedAt = "archive.archived_at"
+	tagArchiveDeleted    = "archive.originals_deleted"
+)
+
+var runArchiveArtifactsCmd = &cobra.Command{
+	Use:   "archive-artifacts",
+	Short: "Move a run's artifacts to cold storage",
+	Long: `Download the artifacts of a run into a gzip-compressed tar archive
+<dest>/<run-id>.tar.gz and record the archive location, SHA-256 digest and file
+count as archive.* tags of the run. The destination is a directory, such as a
+mounted archive bucket. With --delete-originals, the archived artifacts are
+deleted from the run's artifact store after the archive has been written; the
+deletion is confirmed like that of "run delete" unless --yes or --force is given.`,
+	RunE: runArchiveArtifacts,
+}
+
+func init() {
+	runCmd.AddCommand(runArchiveArtifactsCmd)
+
+	// Run archive-artifacts command flags
+	runArchiveArtifactsCmd.Flags().String("run-id", "", "Run ID whose artifacts are archived (required)")
+	runArchiveArtifactsCmd.Flags().String("dest", "", "Archive directory (required)")
+	runArchiveArtifactsCmd.Flags().String("path", "", "Archive only this artifact directory (default: all artifacts)")
+	runArchiveArtifactsCmd.Flags().Bool("delete-originals", false, "Delete the archived artifacts from the run's artifact store")
+	addBandwidthFlag(runArchiveArtifactsCmd)
+	runArchiveArtifactsCmd.MarkFlagRequired("run-id")
+	runArchiveArtifactsCmd.MarkFlagRequired("dest")
+	addConfirmFlags(runArchiveArtifactsCmd)
+
+	registerExamples(runArchiveArtifactsCmd,
+		example{Command: "mlflow-cli run archive-artifacts --run-id <run-id> --dest /mnt/glacier/mlflow --delete-originals --yes"},
+	)
+}
+
+func runArchiveArtifacts(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	if err := applyBandwidthLimit(cmd, cfg); err != nil {
+		return err
+	}
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	dest, _ := cmd.Flags().GetString("dest")
+	artifactPath, _ := cmd.Flags().GetString("path")
+	deleteOriginals, _ := cmd.Flags().GetBool("delete-originals")
+
+	dest = strings.TrimPrefix(dest, "file://")
+	if strings.Contains(dest, "://") {
+		return fmt.Errorf("unsupported archive location: %s (expected a directory)", dest)
+	}
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	archivePath, err := filepath.Abs(filepath.Join(dest, runID+".tar.gz"))
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(archivePath); err == nil {
+		return fmt.Errorf("archive already exists: %s", archivePath)
+	}
+
+	ctx := context.Background()
+	if deleteOriginals {
+		originals, err := client.ListArtifactsRecursive(ctx, runID, strings.Trim(artifactPath, "/"))
+		if err != nil {
+			return err
+		}
+		action := fmt.Sprintf("delete %s of run %s after archiving", plural(len(originals), "artifact"), runID)
+		if err := confirm(cmd, action, len(originals)); err != nil {
+			return err
+		}
+	}
+
+	// Write the archive under a temporary name so that an interrupted run never
+	// leaves a partial archive behind
+	tmp, err := os.CreateTemp(dest, "."+runID+".tar.gz.*")
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	files, err := client.WriteArtifactsArchive(ctx, runID, artifactPath, io.MultiWriter(tmp, hash))
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to archive artifacts: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), archivePath); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Archived %d artifacts to %s\n", len(files), archivePath)
+
+	tags := map[string]string{
+		tagArchiveLocation:   "file://" + filepath.ToSlash(archivePath),
+		tagArchiveDigest:     hex.EncodeToString(hash.Sum(nil)),
+		tagArchiveFileCount:  strconv.Itoa(len(files)),
+		tagArchiveArchivedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := client.SetTagsFromMap(ctx, runID, tags); err != nil {
+		return fmt.Errorf("failed to record archive location: %w", err)
+	}
+
+	if deleteOriginals {
+		for i, file := range files {
+			if err := client.DeleteArtifact(ctx, runID, file.Path); err != nil {
+				return fmt.Errorf("failed to delete %s (%d/%d originals deleted): %w", file.Path, i, len(files), err)
+			}
+		}
+		if err := client.SetTag(ctx, runID, tagArchiveDeleted, "true"); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Successfully archived %d artifacts of run %s to %s\n", len(files), runID, archivePath)
+	if deleteOriginals {
+		fmt.Printf("  Deleted %d original artifacts\n", len(files))
+	}
+	return nil
+}
@@ -4,43 +4,237 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	"github.com/imishinist/mlflow-cli/internal/bundle"
 	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/glob"
 	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/units"
 )
 
 var logArtifactCmd = &cobra.Command{
 	Use:   "artifact",
 	Short: "Log artifact to MLflow run",
-	Long: `Log a file as an artifact to an MLflow run.
-The file will be uploaded with its original filename unless --artifact-path is specified.`,
-	Example: `  # Upload a file with its original name
-  mlflow-cli log artifact --run-id <run-id> --file model.pkl
-  
-  # Upload a file with a custom artifact path
-  mlflow-cli log artifact --run-id <run-id> --file model.pkl --artifact-path models/final_model.pkl
-  
-  # Upload multiple files
-  mlflow-cli log artifact --run-id <run-id> --file model.pkl --file config.yaml`,
+	Long: `Log files as artifacts to an MLflow run.
+A file will be uploaded with its original filename unless --artifact-path is specified.
+With --dir, every file below the directory is uploaded with its path relative to
+the directory, under --artifact-path if specified. Glob patterns in --file are
+uploaded the same way, relative to the directory before the first wildcard.
+--exclude patterns are matched against these relative paths; a pattern without
+a slash matches the file name in any directory.
+
+With --archive tar.gz or zip, each --dir is bundled client-side into a single
+artifact <dir-name>.tar.gz (or .zip) under --artifact-path, which is much faster
+than uploading many small files. "artifact download --extract" unpacks it.
+
+Existing artifacts are overwritten after confirmation, like deletions: in a
+terminal the command asks, otherwise --yes is needed. An explicit --overwrite
+confirms it. --skip-existing and --fail-if-exists leave existing artifacts
+alone.
+
+--artifact-path may be a Go text/template filled with the run's metadata:
+  .RunID .RunName .ExperimentID .Status .Tags .Params .Metrics
+Template functions: lower, upper, replace, quote, default`,
 	RunE: logArtifact,
 }
 
+var artifactCmd = &cobra.Command{
+	Use:   "artifact",
+	Short: "Artifact management commands",
+	Long:  `Commands for managing artifacts already logged to MLflow runs.`,
+}
+
+var artifactCopyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copy artifacts from one run to another",
+	Long: `Copy an artifact file, or all files below an artifact directory, from one run
+to another. Content is streamed from the source run's artifact store to the
+destination's; only files whose size is unknown until read are spooled to a
+temporary file first, as uploads need it. Artifacts in local artifact stores
+are copied directly on disk.`,
+	RunE: artifactCopy,
+}
+
 func init() {
 	logCmd.AddCommand(logArtifactCmd)
+	rootCmd.AddCommand(artifactCmd)
+	artifactCmd.AddCommand(artifactCopyCmd)
+
+	// Artifact copy command flags
+	artifactCopyCmd.Flags().String("from-run", "", "Source run ID (required)")
+	artifactCopyCmd.Flags().String("from-path", "", "Source artifact file or directory (default: all artifacts)")
+	artifactCopyCmd.Flags().String("to-run", "", "Destination run ID (required)")
+	artifactCopyCmd.Flags().String("to-path", "", "Destination artifact path (default: same as --from-path)")
+	addBandwidthFlag(artifactCopyCmd)
+	artifactCopyCmd.MarkFlagRequired("from-run")
+	artifactCopyCmd.MarkFlagRequired("to-run")
 
 	// Artifact command flags
 	logArtifactCmd.Flags().String("run-id", "", "Run ID to upload artifacts to (required)")
-	logArtifactCmd.Flags().StringSlice("file", []string{}, "File path to upload (can be specified multiple times)")
-	logArtifactCmd.Flags().String("artifact-path", "", "Custom artifact path (only valid when uploading a single file)")
+	logArtifactCmd.Flags().StringSlice("file", []string{}, "File path or glob pattern such as 'outputs/**/*.png' to upload (can be specified multiple times)")
+	logArtifactCmd.Flags().StringSlice("dir", []string{}, "Directory to upload recursively, preserving relative paths (can be specified multiple times)")
+	logArtifactCmd.Flags().StringSlice("exclude", []string{}, "Glob pattern of files to leave out of --dir and glob uploads (can be specified multiple times)")
+	logArtifactCmd.Flags().String("artifact-path", "", "Custom artifact path of a single file, or the artifact directory to upload --dir and glob matches into")
+	logArtifactCmd.Flags().Int("parallelism", 1, "Number of files uploaded concurrently")
+	logArtifactCmd.Flags().Bool("overwrite", false, "Overwrite existing artifacts without asking for confirmation")
+	logArtifactCmd.Flags().Bool("skip-existing", false, "Skip files whose artifact path already exists")
+	logArtifactCmd.Flags().Bool("fail-if-exists", false, "Fail if an artifact path already exists")
+	logArtifactCmd.Flags().Bool("resume", false, "Continue an interrupted upload from its journal, skipping completed files and parts")
+	logArtifactCmd.Flags().String("journal", "", "Upload journal file (default: per run in the user cache directory)")
+	logArtifactCmd.Flags().String("archive", "", "Bundle each --dir into a single archive artifact (tar.gz/zip)")
+	units.SizeFlag(logArtifactCmd.Flags(), "part-size", mlflow.DefaultPartSize, "Part size of multipart uploads of large files (min 5MiB)")
+	units.SizeFlag(logArtifactCmd.Flags(), "max-artifact-size", 0, "Fail before uploading anything if a file is larger than this, e.g. 5GB; 0 for no limit")
+	addBandwidthFlag(logArtifactCmd)
 	logArtifactCmd.MarkFlagRequired("run-id")
-	logArtifactCmd.MarkFlagRequired("file")
+	logArtifactCmd.MarkFlagsOneRequired("file", "dir")
+	logArtifactCmd.MarkFlagsMutuallyExclusive("overwrite", "skip-existing", "fail-if-exists")
+	logArtifactCmd.MarkFlagsMutuallyExclusive("archive", "file")
+	logArtifactCmd.MarkFlagsMutuallyExclusive("archive", "resume")
+	addConfirmFlags(logArtifactCmd)
+
+	registerExamples(logArtifactCmd,
+		example{
+			Description: "Upload a file with its original name",
+			Command:     "mlflow-cli log artifact --run-id <run-id> --file model.pkl",
+		},
+		example{
+			Description: "Upload a file with a custom artifact path",
+			Command:     "mlflow-cli log artifact --run-id <run-id> --file model.pkl --artifact-path models/final_model.pkl",
+		},
+		example{
+			Description: "Upload multiple files",
+			Command:     "mlflow-cli log artifact --run-id <run-id> --file model.pkl --file config.yaml",
+		},
+		example{
+			Description: "Upload all plots, keeping the directory layout below outputs/",
+			Command:     "mlflow-cli log artifact --run-id <run-id> --file 'outputs/**/*.png' --exclude '**/debug/**'",
+		},
+		example{
+			Description: "Upload a directory tree below outputs/ (e.g. outputs/plots/loss.png)",
+			Command:     "mlflow-cli log artifact --run-id <run-id> --dir ./plots --artifact-path outputs",
+		},
+		example{
+			Description: "Upload thousands of small files concurrently",
+			Command:     "mlflow-cli log artifact --run-id <run-id> --dir ./samples --parallelism 16",
+		},
+		example{
+			Description: "Place the model by run metadata",
+			Command: `mlflow-cli log artifact --run-id <run-id> --file model.onnx \
+  --artifact-path 'models/{{.Tags.model_type}}/{{.RunName}}/model.onnx'`,
+		},
+		example{
+			Description: "Re-executed pipeline step: keep artifacts uploaded by a previous attempt",
+			Command:     "mlflow-cli log artifact --run-id <run-id> --file model.pkl --skip-existing",
+		},
+		example{
+			Description: "Continue an interrupted upload of a large checkpoint",
+			Command:     "mlflow-cli log artifact --run-id <run-id> --dir ./ckpt --resume",
+		},
+		example{
+			Description: "Upload 50k small files as a single artifact outputs/samples.tar.gz",
+			Command:     "mlflow-cli log artifact --run-id <run-id> --dir ./samples --artifact-path outputs --archive tar.gz",
+		},
+	)
+	registerExamples(artifactCopyCmd,
+		example{
+			Description: "Embed the baseline's model in a new run for later comparison",
+			Command: `mlflow-cli artifact copy --from-run <baseline-run-id> --from-path model/ \
+  --to-run <run-id> --to-path baseline_model/`,
+		},
+	)
+}
+
+// Policies for uploading to an artifact path that already exists
+const (
+	existsPolicyOverwrite = "overwrite"
+	existsPolicySkip      = "skip"
+	existsPolicyFail      = "fail"
+)
+
+// artifactIndex tells whether artifacts of a run exist, listing each artifact
+// directory once, so that checking the files of a large directory upload does
+// not take a request per file
+type artifactIndex struct {
+	client *mlflow.Client
+	runID  string
+	dirs   map[string]map[string]bool
+}
+
+func newArtifactIndex(client *mlflow.Client, runID string) *artifactIndex {
+	return &artifactIndex{client: client, runID: runID, dirs: make(map[string]map[string]bool)}
+}
+
+// Exists returns whether a file exists at an artifact path
+func (a *artifactIndex) Exists(ctx context.Context, artifactPath string) (bool, error) {
+	dir := path.Dir(artifactPath)
+	if dir == "." {
+		dir = ""
+	}
+	files, ok := a.dirs[dir]
+	if !ok {
+		artifacts, err := a.client.ListArtifacts(ctx, a.runID, dir)
+		if err != nil {
+			return false, err
+		}
+		files = make(map[string]bool, len(artifacts))
+		for _, artifact := range artifacts {
+			if !artifact.IsDir {
+				files[artifact.Path] = true
+			}
+		}
+		a.dirs[dir] = files
+	}
+	return files[artifactPath], nil
+}
+
+// addBandwidthFlag adds the --bwlimit flag to a command transferring artifacts
+func addBandwidthFlag(cmd *cobra.Command) {
+	units.RateFlag(cmd.Flags(), "bwlimit", 0, "Maximum bytes per second of all artifact uploads and downloads together, e.g. 10MiB/s; 0 for no limit")
+}
+
+// applyBandwidthLimit sets the bandwidth limit of a client config to --bwlimit
+func applyBandwidthLimit(cmd *cobra.Command, cfg *config.Config) error {
+	limit, err := units.GetRate(cmd.Flags(), "bwlimit")
+	if err != nil {
+		return err
+	}
+	cfg.BandwidthLimit = limit
+	return nil
+}
+
+// overwriteConfirmed reports whether existing artifacts or files may be
+// overwritten without asking: --overwrite was given explicitly, --force
+// confirms every change, or a dry run changes nothing
+func overwriteConfirmed(cmd *cobra.Command) bool {
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+	force, _ := cmd.Flags().GetBool("force")
+	return overwrite || force || viper.GetBool("dry_run")
+}
+
+// getExistsPolicy returns the exists policy selected by flags
+func getExistsPolicy(cmd *cobra.Command) string {
+	if skip, _ := cmd.Flags().GetBool("skip-existing"); skip {
+		return existsPolicySkip
+	}
+	if fail, _ := cmd.Flags().GetBool("fail-if-exists"); fail {
+		return existsPolicyFail
+	}
+	return existsPolicyOverwrite
 }
 
 func logArtifact(cmd *cobra.Command, args []string) error {
 	cfg := config.New()
+	if err := applyBandwidthLimit(cmd, cfg); err != nil {
+		return err
+	}
 	client, err := mlflow.NewClient(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create MLflow client: %w", err)
@@ -49,58 +243,428 @@ func logArtifact(cmd *cobra.Command, args []string) error {
 	// Parse flags
 	runID, _ := cmd.Flags().GetString("run-id")
 	files, _ := cmd.Flags().GetStringSlice("file")
+	dirs, _ := cmd.Flags().GetStringSlice("dir")
+	excludes, _ := cmd.Flags().GetStringSlice("exclude")
 	artifactPath, _ := cmd.Flags().GetString("artifact-path")
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+	resume, _ := cmd.Flags().GetBool("resume")
+	journalPath, _ := cmd.Flags().GetString("journal")
+	archiveFormat, _ := cmd.Flags().GetString("archive")
+	partSize, err := units.GetSize(cmd.Flags(), "part-size")
+	if err != nil {
+		return err
+	}
+	maxArtifactSize, err := units.GetSize(cmd.Flags(), "max-artifact-size")
+	if err != nil {
+		return err
+	}
 
 	// Validation
-	if len(files) == 0 {
-		return fmt.Errorf("at least one file must be specified")
+	if parallelism < 1 {
+		return fmt.Errorf("parallelism must be at least 1")
+	}
+	if partSize < minPartSize {
+		return fmt.Errorf("--part-size must be at least %s", units.FormatSize(minPartSize))
+	}
+	if len(files) == 0 && len(dirs) == 0 {
+		return fmt.Errorf("at least one file or directory must be specified")
+	}
+	if archiveFormat != "" {
+		if err := bundle.ValidateFormat(archiveFormat); err != nil {
+			return err
+		}
 	}
 
-	if len(files) > 1 && artifactPath != "" {
-		return fmt.Errorf("--artifact-path can only be used when uploading a single file")
+	// --artifact-path names a single file, or is the prefix of directories and globs
+	literalFiles := 0
+	for _, filePath := range files {
+		if !glob.HasMeta(filePath) {
+			literalFiles++
+		}
+	}
+	if artifactPath != "" && literalFiles > 0 && (len(files) > 1 || len(dirs) > 0) {
+		return fmt.Errorf("--artifact-path can only be used with a single file, or as the prefix of directories and glob patterns")
 	}
 
 	ctx := context.Background()
-	successCount := 0
 
-	for _, filePath := range files {
+	artifactPath, err = expandArtifactPath(ctx, client, runID, artifactPath)
+	if err != nil {
+		return err
+	}
+
+	var uploads []mlflow.ArtifactUpload
+	if archiveFormat != "" {
+		tempDir, err := os.MkdirTemp("", "mlflow-cli-archive-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tempDir)
+		uploads, err = bundleDirectories(dirs, excludes, artifactPath, archiveFormat, tempDir)
+		if err != nil {
+			return err
+		}
+	} else {
+		uploads, err = collectArtifactUploads(files, dirs, excludes, artifactPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	journal, err := openUploadJournal(cmd, runID, journalPath, partSize, resume)
+	if err != nil {
+		return err
+	}
+
+	// Existing artifacts are only looked up if they are skipped, fail the
+	// upload or need a confirmation
+	existsPolicy := getExistsPolicy(cmd)
+	checkExisting := existsPolicy != existsPolicyOverwrite || !overwriteConfirmed(cmd)
+	existing := newArtifactIndex(client, runID)
+	skippedCount := 0
+	resumedCount := 0
+	overwriteCount := 0
+
+	// Select the files to upload
+	var pending []mlflow.ArtifactUpload
+	for _, upload := range uploads {
+		filePath, targetPath := upload.FilePath, upload.ArtifactPath
+
 		// Check if file exists
-		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		info, err := os.Stat(filePath)
+		if os.IsNotExist(err) {
 			fmt.Fprintf(os.Stderr, "File not found: %s\n", filePath)
 			continue
 		}
+		if err == nil && maxArtifactSize > 0 && info.Size() > maxArtifactSize {
+			return fmt.Errorf("%s is %d bytes, larger than --max-artifact-size %s", filePath, info.Size(), units.FormatSize(maxArtifactSize))
+		}
 
-		// Determine artifact path
-		var targetPath string
-		if artifactPath != "" {
-			targetPath = artifactPath
-		} else {
-			targetPath = filepath.Base(filePath)
+		if resume && journal.Completed(upload) {
+			resumedCount++
+			continue
 		}
 
-		err := client.UploadArtifact(ctx, runID, filePath, targetPath)
+		if !checkExisting {
+			pending = append(pending, upload)
+			continue
+		}
+		exists, err := existing.Exists(ctx, targetPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to upload %s: %v\n", filePath, err)
+			return fmt.Errorf("failed to check existing artifact %s: %w", targetPath, err)
+		}
+		switch {
+		case exists && existsPolicy == existsPolicyFail:
+			return fmt.Errorf("artifact already exists: %s", targetPath)
+		case exists && existsPolicy == existsPolicySkip:
+			fmt.Fprintf(os.Stderr, "Skipping existing artifact: %s\n", targetPath)
+			skippedCount++
+			continue
+		case exists:
+			overwriteCount++
+		}
+
+		pending = append(pending, upload)
+	}
+
+	// Replacing existing artifacts is confirmed like deletions
+	if overwriteCount > 0 && !overwriteConfirmed(cmd) {
+		if err := confirm(cmd, fmt.Sprintf("overwrite %s of run %s", plural(overwriteCount, "existing artifact"), runID), overwriteCount); err != nil {
+			return err
+		}
+	}
+
+	progress := newProgressPrinter("Uploading artifacts")
+	errs, err := client.UploadArtifactsResumable(ctx, runID, pending, parallelism, journal, progress.Update)
+	progress.Done()
+	if err != nil {
+		return err
+	}
+	successCount := 0
+	for i, err := range errs {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to upload %s: %v\n", pending[i].FilePath, err)
 			continue
 		}
 		successCount++
 	}
 
-	if successCount == 0 {
+	// The journal is only needed until every file is uploaded; archives are
+	// bundled anew by every attempt and cannot be resumed
+	if successCount == len(pending) || archiveFormat != "" {
+		if err := journal.Remove(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove upload journal: %v\n", err)
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Progress is recorded in %s; run the same command with --resume to continue\n", journal.Path())
+	}
+
+	if successCount == 0 && skippedCount == 0 && resumedCount == 0 {
 		return fmt.Errorf("failed to upload any artifacts")
 	}
 
 	// Output success message
-	if len(files) == 1 {
-		fmt.Printf("Successfully uploaded artifact: %s\n", files[0])
-		if artifactPath != "" {
-			fmt.Printf("  Artifact path: %s\n", artifactPath)
-		} else {
-			fmt.Printf("  Artifact path: %s\n", filepath.Base(files[0]))
-		}
+	if resumedCount > 0 {
+		fmt.Printf("Successfully uploaded %d/%d artifacts (%d already uploaded before resuming)\n", successCount+resumedCount, len(uploads), resumedCount)
+	} else if skippedCount > 0 {
+		fmt.Printf("Successfully uploaded %d/%d artifacts (%d skipped as existing)\n", successCount, len(uploads), skippedCount)
+	} else if len(uploads) == 1 && len(dirs) == 0 {
+		fmt.Printf("Successfully uploaded artifact: %s\n", uploads[0].FilePath)
+		fmt.Printf("  Artifact path: %s\n", uploads[0].ArtifactPath)
 	} else {
-		fmt.Printf("Successfully uploaded %d/%d artifacts\n", successCount, len(files))
+		fmt.Printf("Successfully uploaded %d/%d artifacts\n", successCount, len(uploads))
+	}
+
+	return nil
+}
+
+// minPartSize is the smallest part size accepted by cloud object stores
+const minPartSize = 5 << 20
+
+// openUploadJournal returns the journal to record uploads to a run in. With
+// resume, the journal of an earlier attempt is continued; its part size
+// overrides partSize so that unfinished multipart uploads stay consistent.
+func openUploadJournal(cmd *cobra.Command, runID, journalPath string, partSize int64, resume bool) (*mlflow.UploadJournal, error) {
+	if journalPath == "" {
+		defaultPath, err := mlflow.DefaultUploadJournalPath(runID)
+		if err != nil {
+			return nil, err
+		}
+		journalPath = defaultPath
+	}
+
+	journal, ok, err := mlflow.LoadUploadJournal(journalPath, runID)
+	if err != nil {
+		return nil, err
+	}
+	if !resume {
+		if ok {
+			fmt.Fprintf(os.Stderr, "Warning: discarding the journal of an unfinished upload to run %s (use --resume to continue it)\n", runID)
+		}
+		return mlflow.NewUploadJournal(journalPath, runID, partSize), nil
+	}
+
+	if !ok {
+		fmt.Fprintf(os.Stderr, "No upload journal found at %s, uploading all files\n", journalPath)
+		return mlflow.NewUploadJournal(journalPath, runID, partSize), nil
+	}
+	if cmd.Flags().Changed("part-size") && journal.PartSize != partSize {
+		fmt.Fprintf(os.Stderr, "Warning: resuming with the part size of the journal (%s)\n", units.FormatSize(journal.PartSize))
+	}
+	fmt.Fprintf(os.Stderr, "Resuming upload from %s\n", journalPath)
+	return journal, nil
+}
+
+// collectArtifactUploads expands files, glob patterns, and directories into
+// uploads. Literal files are uploaded by their name or artifactPath; glob matches
+// and directory trees keep their relative paths under artifactPath. Uploads are
+// ordered by argument, and lexically within a pattern or directory.
+func collectArtifactUploads(files, dirs, excludes []string, artifactPath string) ([]mlflow.ArtifactUpload, error) {
+	for _, pattern := range excludes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid --exclude pattern %s: %w", pattern, err)
+		}
+	}
+
+	var uploads []mlflow.ArtifactUpload
+	seen := make(map[mlflow.ArtifactUpload]bool)
+	add := func(upload mlflow.ArtifactUpload, relPath string) error {
+		if relPath != "" {
+			excluded, err := excludedPath(excludes, relPath)
+			if err != nil || excluded {
+				return err
+			}
+		}
+		if !seen[upload] {
+			seen[upload] = true
+			uploads = append(uploads, upload)
+		}
+		return nil
+	}
+
+	for _, filePath := range files {
+		if !glob.HasMeta(filePath) {
+			targetPath := filepath.Base(filePath)
+			if artifactPath != "" {
+				targetPath = artifactPath
+			}
+			add(mlflow.ArtifactUpload{FilePath: filePath, ArtifactPath: targetPath}, "")
+			continue
+		}
+
+		matches, err := glob.Expand(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand pattern %s: %w", filePath, err)
+		}
+		if len(matches) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: no files match pattern %s\n", filePath)
+		}
+		for _, match := range matches {
+			upload := mlflow.ArtifactUpload{FilePath: match.Path, ArtifactPath: path.Join(artifactPath, match.RelPath)}
+			if err := add(upload, match.RelPath); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, dir := range dirs {
+		dirUploads, err := mlflow.DirectoryArtifacts(dir, artifactPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+		}
+		if len(dirUploads) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: directory %s has no files\n", dir)
+		}
+		for _, upload := range dirUploads {
+			rel, err := filepath.Rel(dir, upload.FilePath)
+			if err != nil {
+				return nil, err
+			}
+			if err := add(upload, filepath.ToSlash(rel)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return uploads, nil
+}
+
+// bundleDirectories bundles the files of each directory not matching excludes
+// into an archive in tempDir and returns the uploads of the archives, named
+// after their directory under artifactPath
+func bundleDirectories(dirs, excludes []string, artifactPath, format, tempDir string) ([]mlflow.ArtifactUpload, error) {
+	var uploads []mlflow.ArtifactUpload
+	for i, dir := range dirs {
+		dirUploads, err := collectArtifactUploads(nil, []string{dir}, excludes, "")
+		if err != nil {
+			return nil, err
+		}
+		if len(dirUploads) == 0 {
+			continue
+		}
+
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		name := bundle.Name(filepath.Base(absDir), format)
+		archivePath := filepath.Join(tempDir, strconv.Itoa(i), name)
+		if err := os.MkdirAll(filepath.Dir(archivePath), 0755); err != nil {
+			return nil, err
+		}
+
+		files := make([]bundle.File, 0, len(dirUploads))
+		for _, upload := range dirUploads {
+			files = append(files, bundle.File{Path: upload.FilePath, Name: upload.ArtifactPath})
+		}
+		if err := writeBundle(archivePath, format, files); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(os.Stderr, "Bundled %d files of %s into %s\n", len(files), dir, name)
+
+		uploads = append(uploads, mlflow.ArtifactUpload{
+			FilePath:     archivePath,
+			ArtifactPath: path.Join(artifactPath, name),
+		})
+	}
+	return uploads, nil
+}
+
+// writeBundle writes files to a new archive file
+func writeBundle(archivePath, format string, files []bundle.File) error {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	if err := bundle.Write(f, format, files); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// excludedPath reports whether a slash-separated relative path matches any of
+// the exclude patterns. Patterns without a slash match the file name.
+func excludedPath(excludes []string, relPath string) (bool, error) {
+	for _, pattern := range excludes {
+		name := relPath
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(relPath)
+		}
+		ok, err := glob.MatchPath(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid --exclude pattern %s: %w", pattern, err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// expandArtifactPath fills an artifact path template with the metadata of the
+// run. Paths without template actions are returned as is.
+func expandArtifactPath(ctx context.Context, client *mlflow.Client, runID, artifactPath string) (string, error) {
+	if !strings.Contains(artifactPath, "{{") {
+		return artifactPath, nil
+	}
+
+	tmpl, err := template.New("artifact-path").Funcs(renderFuncs).Option("missingkey=error").Parse(artifactPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse --artifact-path template: %w", err)
+	}
+
+	run, err := client.GetRun(ctx, runID)
+	if err != nil {
+		return "", err
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, run); err != nil {
+		return "", fmt.Errorf("failed to render --artifact-path template: %w", err)
+	}
+
+	expanded := strings.Trim(rendered.String(), "/")
+	if expanded == "" {
+		return "", fmt.Errorf("--artifact-path template %q rendered an empty path", artifactPath)
+	}
+	for _, segment := range strings.Split(expanded, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("--artifact-path template %q rendered an invalid path: %s", artifactPath, expanded)
+		}
+	}
+	return expanded, nil
+}
+
+func artifactCopy(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	if err := applyBandwidthLimit(cmd, cfg); err != nil {
+		return err
+	}
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	fromRun, _ := cmd.Flags().GetString("from-run")
+	fromPath, _ := cmd.Flags().GetString("from-path")
+	toRun, _ := cmd.Flags().GetString("to-run")
+	toPath, _ := cmd.Flags().GetString("to-path")
+
+	if !cmd.Flags().Changed("to-path") {
+		toPath = fromPath
+	}
+	if fromRun == toRun && strings.Trim(fromPath, "/") == strings.Trim(toPath, "/") {
+		return fmt.Errorf("source and destination are the same")
+	}
+
+	ctx := context.Background()
+	copied, err := client.CopyArtifacts(ctx, fromRun, fromPath, toRun, toPath)
+	if err != nil {
+		return fmt.Errorf("failed to copy artifacts (%d copied): %w", copied, err)
 	}
 
+	fmt.Printf("Successfully copied %d artifacts from run %s to run %s\n", copied, fromRun, toRun)
 	return nil
 }
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/bundle"
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+var artifactDownloadCmd = &cobra.Command{
+	Use:   "download",
+	Short: "Download artifacts of a run",
+	Long: `Download an artifact file, or all files below an artifact directory, of a run
+into a local directory. Files keep their artifact paths below --output-dir, so
+--path model/ is downloaded to <output-dir>/model/.
+
+mlflow-artifacts, DBFS (via credentials-for-read), S3, GCS, Azure, SFTP and
+local file artifact stores are supported. Each file is written to a temporary file and
+renamed into place, so an interrupted download never leaves a partial file
+behind. Existing local files are overwritten after confirmation, like
+deletions: in a terminal the command asks, otherwise --yes is needed. An
+explicit --overwrite confirms it.
+
+With --extract, downloaded .tar.gz, .tgz and .zip artifacts, e.g. those logged
+with "log artifact --archive", are extracted into a directory named like the
+archive without its extension, and the archive is removed.
+
+With --tar, the files are written as an uncompressed tar archive to a file, or
+to stdout with --tar -, instead of into --output-dir. Files are streamed into
+the archive, except those whose size the artifact store does not report: tar
+headers need the size up front, so these are spooled to a temporary file
+first. Files are named relative to --path, so the archive of a directory
+holding a Dockerfile is a docker build context.`,
+	RunE: artifactDownload,
+}
+
+func init() {
+	artifactCmd.AddCommand(artifactDownloadCmd)
+
+	// Artifact download command flags
+	artifactDownloadCmd.Flags().String("run-id", "", "Run ID to download artifacts from (required)")
+	artifactDownloadCmd.Flags().String("path", "", "Artifact file or directory to download (default: all artifacts)")
+	artifactDownloadCmd.Flags().String("output-dir", ".", "Local directory to download into")
+	artifactDownloadCmd.Flags().Bool("overwrite", false, "Overwrite existing local files without asking for confirmation")
+	artifactDownloadCmd.Flags().Bool("skip-existing", false, "Skip artifacts whose local file already exists")
+	artifactDownloadCmd.Flags().Bool("fail-if-exists", false, "Fail before downloading if any local file already exists")
+	artifactDownloadCmd.Flags().Bool("extract", false, "Extract downloaded tar.gz and zip archives into directories")
+	artifactDownloadCmd.Flags().String("tar", "", "Write the files as a tar archive to this file, or to stdout with -")
+	addBandwidthFlag(artifactDownloadCmd)
+	artifactDownloadCmd.MarkFlagRequired("run-id")
+	addConfirmFlags(artifactDownloadCmd)
+	artifactDownloadCmd.MarkFlagsMutuallyExclusive("overwrite", "skip-existing", "fail-if-exists")
+	for _, flag := range []string{"output-dir", "overwrite", "skip-existing", "fail-if-exists", "extract"} {
+		artifactDownloadCmd.MarkFlagsMutuallyExclusive("tar", flag)
+	}
+
+	registerExamples(artifactDownloadCmd,
+		example{
+			Description: "Download all artifacts of a run into the current directory",
+			Command:     "mlflow-cli artifact download --run-id <run-id>",
+		},
+		example{
+			Description: "Download the model directory, keeping files downloaded before",
+			Command:     "mlflow-cli artifact download --run-id <run-id> --path model --output-dir ./out --skip-existing",
+		},
+		example{
+			Description: "Download outputs/samples.tar.gz and extract it into ./outputs/samples/",
+			Command:     "mlflow-cli artifact download --run-id <run-id> --path outputs/samples.tar.gz --extract",
+		},
+		example{
+			Description: "Build an image from the model directory without writing it to disk",
+			Command:     "mlflow-cli artifact download --run-id <run-id> --path model/ --tar - | docker build -t model -",
+		},
+	)
+}
+
+func artifactDownload(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	if err := applyBandwidthLimit(cmd, cfg); err != nil {
+		return err
+	}
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	artifactPath, _ := cmd.Flags().GetString("path")
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	extract, _ := cmd.Flags().GetBool("extract")
+	tarPath, _ := cmd.Flags().GetString("tar")
+
+	artifactPath = strings.Trim(artifactPath, "/")
+	ctx := context.Background()
+	if tarPath != "" {
+		return downloadArtifactsTar(ctx, client, runID, artifactPath, tarPath)
+	}
+
+	files, err := client.ListArtifactsRecursive(ctx, runID, artifactPath)
+	if err != nil {
+		return err
+	}
+
+	// A path without children is a single file
+	artifactPaths := make([]string, 0, len(files))
+	for _, file := range files {
+		artifactPaths = append(artifactPaths, file.Path)
+	}
+	if len(artifactPaths) == 0 {
+		if artifactPath == "" {
+			return fmt.Errorf("run %s has no artifacts", runID)
+		}
+		artifactPaths = append(artifactPaths, artifactPath)
+	}
+
+	existsPolicy := getExistsPolicy(cmd)
+	skippedCount := 0
+	overwriteCount := 0
+
+	// Select the files to download before writing anything
+	type download struct{ artifactPath, dest string }
+	var pending []download
+	for _, p := range artifactPaths {
+		// Artifact paths come from the server and must stay below the output directory
+		if !filepath.IsLocal(filepath.FromSlash(p)) {
+			return fmt.Errorf("refusing to download artifact outside the output directory: %s", p)
+		}
+		dest := filepath.Join(outputDir, filepath.FromSlash(p))
+
+		if _, err := os.Stat(dest); err == nil {
+			switch existsPolicy {
+			case existsPolicyFail:
+				return fmt.Errorf("local file already exists: %s", dest)
+			case existsPolicySkip:
+				fmt.Fprintf(os.Stderr, "Skipping existing file: %s\n", dest)
+				skippedCount++
+				continue
+			default:
+				overwriteCount++
+			}
+		}
+
+		pending = append(pending, download{artifactPath: p, dest: dest})
+	}
+
+	// Replacing existing local files is confirmed like deletions
+	if overwriteCount > 0 && !overwriteConfirmed(cmd) {
+		if err := confirm(cmd, "overwrite "+plural(overwriteCount, "existing local file"), overwriteCount); err != nil {
+			return err
+		}
+	}
+
+	progress := newProgressPrinter("Downloading artifacts")
+	var archives []string
+	for i, d := range pending {
+		progress.Update(i, len(pending))
+		if err := client.DownloadArtifact(ctx, runID, d.artifactPath, d.dest); err != nil {
+			progress.Done()
+			return fmt.Errorf("failed to download %s (%d downloaded): %w", d.artifactPath, i, err)
+		}
+		if _, _, ok := bundle.Detect(d.dest); ok && extract {
+			archives = append(archives, d.dest)
+		}
+	}
+	progress.Update(len(pending), len(pending))
+	progress.Done()
+
+	for _, archivePath := range archives {
+		if err := extractArchive(archivePath); err != nil {
+			return err
+		}
+	}
+
+	if skippedCount > 0 {
+		fmt.Printf("Successfully downloaded %d/%d artifacts to %s (%d skipped as existing)\n", len(pending), len(artifactPaths), outputDir, skippedCount)
+	} else {
+		fmt.Printf("Successfully downloaded %d artifacts to %s\n", len(pending), outputDir)
+	}
+	return nil
+}
+
+// downloadArtifactsTar writes the artifacts below artifactPath as a tar
+// archive to tarPath, or to stdout if it is -. An archive file is written to a
+// temporary file and renamed into place like downloaded files.
+func downloadArtifactsTar(ctx context.Context, client *mlflow.Client, runID, artifactPath, tarPath string) error {
+	if tarPath == "-" {
+		if isTerminal(os.Stdout) {
+			return fmt.Errorf("refusing to write a tar archive to a terminal: redirect stdout or use --tar <file>")
+		}
+		count, err := client.WriteArtifactsTar(ctx, os.Stdout, runID, artifactPath, "", time.Time{})
+		if err != nil {
+			return fmt.Errorf("failed to stream artifacts (%d written): %w", count, err)
+		}
+		fmt.Fprintf(os.Stderr, "Successfully streamed %d artifacts\n", count)
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(tarPath), "."+filepath.Base(tarPath)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	count, err := client.WriteArtifactsTar(ctx, tmp, runID, artifactPath, "", time.Time{})
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to download artifacts (%d written): %w", count, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tarPath, err)
+	}
+	if err := os.Rename(tmp.Name(), tarPath); err != nil {
+		return err
+	}
+	fmt.Printf("Successfully downloaded %d artifacts to %s\n", count, tarPath)
+	return nil
+}
+
+// extractArchive extracts a downloaded archive into a directory named like the
+// archive without its extension and removes the archive
+func extractArchive(archivePath string) error {
+	format, destDir, _ := bundle.Detect(archivePath)
+	count, err := bundle.Extract(archivePath, format, destDir)
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", archivePath, err)
+	}
+	if err := os.Remove(archivePath); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Extracted %d files of %s into %s\n", count, filepath.Base(archivePath), destDir)
+	return nil
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/output"
+)
+
+var artifactListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the artifacts of a run",
+	Long: `List the files and directories directly below an artifact directory of a run,
+or every file below it with --recursive, with their sizes in bytes. All pages
+of the listing are fetched. --sort-by orders the listing by a column, e.g.
+size:desc to find the largest files.`,
+	RunE: artifactList,
+}
+
+// Columns of artifact list
+var artifactColumns = []string{"path", "type", "size"}
+
+func init() {
+	artifactCmd.AddCommand(artifactListCmd)
+
+	// Artifact list command flags
+	artifactListCmd.Flags().String("run-id", "", "Run ID (required)")
+	artifactListCmd.Flags().String("path", "", "Artifact directory to list (default: the artifact root)")
+	artifactListCmd.Flags().BoolP("recursive", "r", false, "List every file below the directory instead of its direct children")
+	artifactListCmd.Flags().StringP("output", "o", output.FormatTable, "Output format (csv/json/jsonl/table)")
+	addColumnsFlag(artifactListCmd, artifactColumns)
+	addSortByFlag(artifactListCmd)
+	artifactListCmd.MarkFlagRequired("run-id")
+
+	registerExamples(artifactListCmd,
+		example{Command: "mlflow-cli artifact list --run-id <run-id>", Runnable: true},
+		example{Command: "mlflow-cli artifact list --run-id <run-id> --path models/ --recursive --output json"},
+		example{Command: "mlflow-cli artifact list --run-id <run-id> --recursive --columns path,size --sort-by size:desc", Runnable: true},
+	)
+}
+
+func artifactList(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	artifactPath, _ := cmd.Flags().GetString("path")
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+	layout, err := getTableLayout(cmd, artifactColumns, fixedColumns(artifactColumns))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	artifactPath = strings.Trim(artifactPath, "/")
+	var artifacts []models.ArtifactInfo
+	if recursive {
+		artifacts, err = client.ListArtifactsRecursive(ctx, runID, artifactPath)
+	} else {
+		artifacts, err = client.ListArtifacts(ctx, runID, artifactPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	table := output.NewTable(artifactColumns...)
+	for _, artifact := range artifacts {
+		if artifact.IsDir {
+			// Directories have no size of their own
+			table.Append(artifact.Path, "dir", nil)
+			continue
+		}
+		table.Append(artifact.Path, "file", artifact.FileSize)
+	}
+	if len(artifacts) == 0 {
+		fmt.Fprintf(os.Stderr, "No artifacts found at %s\n", displayArtifactPath(artifactPath))
+	}
+
+	return output.Write(os.Stdout, format, layout.apply(table))
+}
+
+// displayArtifactPath names an artifact path in messages
+func displayArtifactPath(artifactPath string) string {
+	if artifactPath == "" {
+		return "the artifact root"
+	}
+	return artifactPath
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+var artifactSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Upload new and changed files of a directory",
+	Long: `Synchronize a local directory to an artifact directory of a run, like rsync.
+The run's artifacts below --artifact-path are listed and only files that are
+missing or differ in size are uploaded, so syncing checkpoints periodically does
+not upload unchanged files again.
+
+The artifact listing has no checksums: with --checksum, files of equal size are
+also compared by the SHA-256 of their content, which reads the artifacts back
+from the artifact store. Artifacts without a local file are kept.`,
+	RunE: artifactSync,
+}
+
+func init() {
+	artifactCmd.AddCommand(artifactSyncCmd)
+
+	// Artifact sync command flags
+	artifactSyncCmd.Flags().String("run-id", "", "Run ID to sync artifacts to (required)")
+	artifactSyncCmd.Flags().String("dir", "", "Local directory to sync (required)")
+	artifactSyncCmd.Flags().String("artifact-path", "", "Artifact directory to sync into (default: the root)")
+	artifactSyncCmd.Flags().StringSlice("exclude", []string{}, "Glob pattern of files to leave out (can be specified multiple times)")
+	artifactSyncCmd.Flags().Bool("checksum", false, "Compare files of equal size by SHA-256 of their content")
+	artifactSyncCmd.Flags().Int("parallelism", 4, "Number of files compared and uploaded concurrently")
+	addBandwidthFlag(artifactSyncCmd)
+	artifactSyncCmd.MarkFlagRequired("run-id")
+	artifactSyncCmd.MarkFlagRequired("dir")
+
+	registerExamples(artifactSyncCmd,
+		example{
+			Description: "Sync checkpoints after every epoch",
+			Command:     "mlflow-cli artifact sync --run-id <run-id> --dir ./checkpoints --artifact-path checkpoints",
+		},
+		example{
+			Description: "Also detect files rewritten with the same size",
+			Command:     "mlflow-cli artifact sync --run-id <run-id> --dir ./outputs --checksum --exclude '*.tmp'",
+		},
+	)
+}
+
+func artifactSync(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	if err := applyBandwidthLimit(cmd, cfg); err != nil {
+		return err
+	}
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	dir, _ := cmd.Flags().GetString("dir")
+	artifactPath, _ := cmd.Flags().GetString("artifact-path")
+	excludes, _ := cmd.Flags().GetStringSlice("exclude")
+	checksum, _ := cmd.Flags().GetBool("checksum")
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+
+	if parallelism < 1 {
+		return fmt.Errorf("--parallelism must be at least 1")
+	}
+
+	ctx := context.Background()
+	artifactPath, err = expandArtifactPath(ctx, client, runID, artifactPath)
+	if err != nil {
+		return err
+	}
+
+	uploads, err := collectArtifactUploads(nil, []string{dir}, excludes, artifactPath)
+	if err != nil {
+		return err
+	}
+
+	comparing := newProgressPrinter("Comparing artifacts")
+	changes, err := client.ChangedArtifacts(ctx, runID, artifactPath, uploads, checksum, parallelism, comparing.Update)
+	comparing.Done()
+	if err != nil {
+		return err
+	}
+
+	unchanged := len(uploads) - len(changes)
+	if len(changes) == 0 {
+		fmt.Printf("Already in sync: %d artifacts unchanged\n", unchanged)
+		return nil
+	}
+
+	newCount := 0
+	pending := make([]mlflow.ArtifactUpload, 0, len(changes))
+	for _, change := range changes {
+		if change.New {
+			newCount++
+		}
+		pending = append(pending, change.Upload)
+	}
+
+	progress := newProgressPrinter("Uploading artifacts")
+	errs, err := client.UploadArtifactsParallel(ctx, runID, pending, parallelism, progress.Update)
+	progress.Done()
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for i, err := range errs {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to upload %s: %v\n", pending[i].FilePath, err)
+			failed++
+		}
+	}
+
+	fmt.Printf("Uploaded %d/%d artifacts (%d new, %d changed), %d unchanged\n",
+		len(pending)-failed, len(pending), newCount, len(pending)-newCount, unchanged)
+	if failed > 0 {
+		return fmt.Errorf("failed to upload %d of %d artifacts", failed, len(pending))
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/output"
+	"github.com/imishinist/mlflow-cli/internal/units"
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Load-test a tracking server with synthetic runs",
+	Long: `Exercise the tracking server with synthetic load and report the latency
+percentiles and throughput of each operation, to size MLflow deployments
+before migrations.
+
+Each run is created, logs --metrics-per-run metric values in log-batch requests
+of up to 1000 values, uploads an artifact of --artifact-size, is read back,
+ended and deleted (kept with --keep). --parallelism runs are loaded at the same
+time, like concurrent training jobs.
+
+Latencies include the retries of failed requests; use --max-retries 0 to see
+the raw errors of an overloaded server. Failed requests skip the rest of their
+run, are counted in the report, and make the command exit with an error after
+it. Runs that failed part-way are ended as failed, and deleted unless --keep is
+given.
+
+Use a dedicated experiment: the runs are named bench-1, bench-2 and so on.`,
+	RunE: bench,
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+
+	// Bench command flags
+	addExperimentFlags(benchCmd)
+	benchCmd.Flags().Int("runs", 10, "Number of runs to create")
+	benchCmd.Flags().Int("metrics-per-run", 1000, "Number of metric values logged to each run")
+	units.SizeFlag(benchCmd.Flags(), "artifact-size", 0, "Size of the artifact uploaded to each run, e.g. 50MB; 0 uploads none")
+	benchCmd.Flags().Int("parallelism", 4, "Number of runs loaded concurrently")
+	benchCmd.Flags().Bool("keep", false, "Keep the runs instead of deleting them")
+	benchCmd.Flags().StringP("output", "o", output.FormatTable, "Output format (csv/json/jsonl/table)")
+
+	registerExamples(benchCmd,
+		example{Command: "mlflow-cli bench --runs 100 --metrics-per-run 10000 --artifact-size 50MB --experiment-name load-test"},
+		example{
+			Description: "A quick smoke load",
+			Command:     "mlflow-cli bench --runs 2 --metrics-per-run 100 --experiment-id <experiment-id>",
+			Runnable:    true,
+		},
+	)
+}
+
+func bench(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runs, _ := cmd.Flags().GetInt("runs")
+	metricsPerRun, _ := cmd.Flags().GetInt("metrics-per-run")
+	artifactSize, err := units.GetSize(cmd.Flags(), "artifact-size")
+	if err != nil {
+		return err
+	}
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+	keep, _ := cmd.Flags().GetBool("keep")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+	if runs < 1 {
+		return fmt.Errorf("--runs must be at least 1")
+	}
+	if metricsPerRun < 0 {
+		return fmt.Errorf("--metrics-per-run must not be negative")
+	}
+	if parallelism < 1 {
+		return fmt.Errorf("--parallelism must be at least 1")
+	}
+
+	ctx := context.Background()
+	experimentID, err := resolveExperimentID(ctx, cmd, client, cfg)
+	if err != nil {
+		return err
+	}
+
+	progress := newProgressPrinter("Running benchmark")
+	result, err := client.Bench(ctx, mlflow.BenchOptions{
+		ExperimentID:  experimentID,
+		Runs:          runs,
+		MetricsPerRun: metricsPerRun,
+		ArtifactSize:  artifactSize,
+		Parallelism:   parallelism,
+		Keep:          keep,
+	}, progress.Update)
+	progress.Done()
+	if err != nil {
+		return err
+	}
+
+	seconds := result.Elapsed.Seconds()
+	table := output.NewTable("operation", "requests", "errors", "p50_ms", "p90_ms", "p99_ms", "max_ms", "requests_per_sec")
+	requests := 0
+	for _, operation := range result.Operations {
+		requests += operation.Requests
+		table.Append(operation.Name, operation.Requests, operation.Errors,
+			milliseconds(operation.P50), milliseconds(operation.P90), milliseconds(operation.P99), milliseconds(operation.Max),
+			math.Round(float64(operation.Requests)/seconds*10)/10)
+	}
+	if err := output.Write(os.Stdout, format, table); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Completed %d of %d runs in %s: %d metric values (%.0f/s)", result.Runs, runs, result.Elapsed.Round(time.Millisecond), result.Metrics, float64(result.Metrics)/seconds)
+	if result.ArtifactBytes > 0 {
+		fmt.Fprintf(os.Stderr, ", %s of artifacts (%.1fMB/s)", units.FormatSize(result.ArtifactBytes), float64(result.ArtifactBytes)/float64(units.MB)/seconds)
+	}
+	fmt.Fprintln(os.Stderr)
+	if len(result.Abandoned) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %s failed part-way and could not be cleaned up: %s\n",
+			plural(len(result.Abandoned), "run"), strings.Join(result.Abandoned, ", "))
+	}
+
+	if result.Errors > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d requests failed, the first with: %v", result.Errors, requests, result.FirstError)
+	}
+	return nil
+}
+
+// milliseconds returns a latency in milliseconds with microsecond precision
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+var checkpointCmd = &cobra.Command{
+	Use:   "checkpoint",
+	Short: "Save and restore training checkpoints",
+	Long: `Store training checkpoints as run artifacts under checkpoints/<name>/ and
+restore them later, so that preemptible training jobs can resume from the
+tracking server alone. checkpoints/LATEST points at the most recent complete
+checkpoint.`,
+}
+
+var checkpointSaveCmd = &cobra.Command{
+	Use:   "save",
+	Short: "Upload a checkpoint directory",
+	Long: `Upload all files below a local directory as a checkpoint of a run. The latest
+pointer is only updated after every file was uploaded, so an interrupted save
+never becomes the checkpoint that is restored.`,
+	RunE: checkpointSave,
+}
+
+var checkpointRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Download a checkpoint directory",
+	Long: `Download the latest checkpoint of a run, or the one given by --name, into a
+local directory.`,
+	RunE: checkpointRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(checkpointCmd)
+	checkpointCmd.AddCommand(checkpointSaveCmd)
+	checkpointCmd.AddCommand(checkpointRestoreCmd)
+
+	// Checkpoint save command flags
+	checkpointSaveCmd.Flags().String("run-id", "", "Run ID to save the checkpoint to (required)")
+	checkpointSaveCmd.Flags().String("dir", "", "Local checkpoint directory (required)")
+	checkpointSaveCmd.Flags().String("name", "", "Checkpoint name (default: step-<step> or the current UTC time)")
+	checkpointSaveCmd.Flags().Int64("step", -1, "Training step the checkpoint was taken at")
+	checkpointSaveCmd.MarkFlagRequired("run-id")
+	checkpointSaveCmd.MarkFlagRequired("dir")
+	checkpointSaveCmd.MarkFlagsMutuallyExclusive("name", "step")
+
+	// Checkpoint restore command flags
+	checkpointRestoreCmd.Flags().String("run-id", "", "Run ID to restore the checkpoint from (required)")
+	checkpointRestoreCmd.Flags().String("dest", "", "Local directory to restore into (required)")
+	checkpointRestoreCmd.Flags().String("name", "", "Checkpoint name (default: latest)")
+	checkpointRestoreCmd.Flags().Bool("allow-missing", false, "Succeed without restoring anything if the run has no checkpoints")
+	checkpointRestoreCmd.MarkFlagRequired("run-id")
+	checkpointRestoreCmd.MarkFlagRequired("dest")
+
+	registerExamples(checkpointSaveCmd,
+		example{
+			Description: "Save a checkpoint named after the training step",
+			Command:     "mlflow-cli checkpoint save --run-id <run-id> --dir ./ckpt --step 1000",
+		},
+	)
+	registerExamples(checkpointRestoreCmd,
+		example{
+			Description: "Resume from the latest checkpoint if there is one",
+			Command:     "mlflow-cli checkpoint restore --run-id <run-id> --dest ./ckpt --allow-missing",
+		},
+	)
+}
+
+func checkpointSave(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	dir, _ := cmd.Flags().GetString("dir")
+	name, _ := cmd.Flags().GetString("name")
+	step, _ := cmd.Flags().GetInt64("step")
+
+	if name == "" {
+		if step >= 0 {
+			name = fmt.Sprintf("step-%d", step)
+		} else {
+			name = time.Now().UTC().Format("20060102T150405Z")
+		}
+	}
+
+	ctx := context.Background()
+	uploaded, err := client.SaveCheckpoint(ctx, runID, dir, name)
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint %s (%d files uploaded): %w", name, uploaded, err)
+	}
+
+	fmt.Printf("Successfully saved checkpoint %s (%d files)\n", name, uploaded)
+	return nil
+}
+
+func checkpointRestore(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	dest, _ := cmd.Flags().GetString("dest")
+	name, _ := cmd.Flags().GetString("name")
+	allowMissing, _ := cmd.Flags().GetBool("allow-missing")
+
+	ctx := context.Background()
+	if name == "" {
+		name, err = client.LatestCheckpoint(ctx, runID)
+		if err != nil {
+			return fmt.Errorf("failed to find latest checkpoint: %w", err)
+		}
+		if name == "" {
+			if allowMissing {
+				fmt.Fprintf(os.Stderr, "Run %s has no checkpoints, nothing to restore\n", runID)
+				return nil
+			}
+			return fmt.Errorf("run %s has no checkpoints", runID)
+		}
+	}
+
+	downloaded, err := client.RestoreCheckpoint(ctx, runID, name, dest)
+	if err != nil {
+		return fmt.Errorf("failed to restore checkpoint %s (%d files downloaded): %w", name, downloaded, err)
+	}
+
+	fmt.Printf("Successfully restored checkpoint %s (%d files) to %s\n", name, downloaded, dest)
+	return nil
+}
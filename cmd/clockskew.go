@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+// reportClockSkew tells on stderr if the local clock differs significantly
+// from the server's, as metrics stamped by a clock running ahead appear in the
+// future in the MLflow UI, and whether their timestamps were corrected
+func reportClockSkew() {
+	d, ok := mlflow.ClockSkew()
+	if !ok || !mlflow.IsClockSkewed(d) {
+		return
+	}
+
+	direction := "ahead of"
+	if d < 0 {
+		direction, d = "behind", -d
+	}
+
+	switch viper.GetString("clock_skew") {
+	case mlflow.ClockSkewCorrect:
+		if mlflow.ClockSkewCorrected() {
+			fmt.Fprintf(os.Stderr, "Corrected metric timestamps for the local clock running %s %s the server\n", d, direction)
+		}
+	case mlflow.ClockSkewIgnore:
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: the local clock is %s %s the server; metric timestamps taken from it are off by as much (use --clock-skew correct to shift them)\n", d, direction)
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/output"
+)
+
+// addColumnsFlag adds the --columns flag selecting the columns of a table
+func addColumnsFlag(cmd *cobra.Command, defaults []string) {
+	cmd.Flags().StringSlice("columns", []string{}, fmt.Sprintf("Comma-separated columns (default: %s)", strings.Join(defaults, ",")))
+}
+
+// addSortByFlag adds the --sort-by flag ordering the rows of a table
+func addSortByFlag(cmd *cobra.Command) {
+	cmd.Flags().String("sort-by", "", "Sort rows by a column; append :desc for descending order (e.g. start_time:desc)")
+}
+
+// tableLayout is the column selection and row order of a table output
+type tableLayout struct {
+	// columns are printed in order
+	columns []string
+	// sortColumn orders the rows if set; it need not be printed
+	sortColumn string
+	descending bool
+}
+
+// getTableLayout returns the layout selected with --columns and --sort-by.
+// Column names are checked with validate; without --columns, defaults are
+// printed.
+func getTableLayout(cmd *cobra.Command, defaults []string, validate func(column string) error) (tableLayout, error) {
+	layout := tableLayout{columns: defaults}
+	if cmd.Flags().Lookup("columns") != nil {
+		if columns, _ := cmd.Flags().GetStringSlice("columns"); len(columns) > 0 {
+			layout.columns = columns
+		}
+	}
+	for _, column := range layout.columns {
+		if err := validate(column); err != nil {
+			return tableLayout{}, err
+		}
+	}
+
+	sortBy, _ := cmd.Flags().GetString("sort-by")
+	if sortBy == "" {
+		return layout, nil
+	}
+	column, order, _ := strings.Cut(sortBy, ":")
+	switch order {
+	case "", "asc":
+	case "desc":
+		layout.descending = true
+	default:
+		return tableLayout{}, fmt.Errorf("invalid --sort-by order: %s (expected asc or desc)", order)
+	}
+	if err := validate(column); err != nil {
+		return tableLayout{}, fmt.Errorf("invalid --sort-by: %w", err)
+	}
+	layout.sortColumn = column
+	return layout, nil
+}
+
+// sourceColumns returns the columns a table must be built with: the printed
+// columns and the sort column
+func (l tableLayout) sourceColumns() []string {
+	if l.sortColumn == "" {
+		return l.columns
+	}
+	for _, column := range l.columns {
+		if column == l.sortColumn {
+			return l.columns
+		}
+	}
+	return append(append([]string{}, l.columns...), l.sortColumn)
+}
+
+// apply sorts a table built with sourceColumns and returns its printed columns
+func (l tableLayout) apply(table *output.Table) *output.Table {
+	if l.sortColumn != "" {
+		// The sort column is always in the table
+		table.Sort(l.sortColumn, l.descending)
+	}
+	return table.Select(l.columns...)
+}
+
+// fixedColumns returns a column validator accepting only the given columns
+func fixedColumns(columns []string) func(column string) error {
+	return func(column string) error {
+		for _, name := range columns {
+			if column == name {
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown column: %s (expected one of %s)", column, strings.Join(columns, ", "))
+	}
+}
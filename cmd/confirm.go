@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// largeDeletion is the number of items from which a deletion is confirmed by
+// typing the number of items instead of answering y
+const largeDeletion = 20
+
+// errAborted is returned when a confirmation is declined
+var errAborted = errors.New("aborted")
+
+// addConfirmFlags adds the --yes and --force flags of destructive commands
+func addConfirmFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("yes", "y", false, fmt.Sprintf("Do not ask for confirmation (deleting %d or more items still needs --force)", largeDeletion))
+	cmd.Flags().Bool("force", false, "Do not ask for confirmation, even of large deletions")
+}
+
+// confirm asks before a command changes or deletes count items; action
+// describes the change, e.g. "delete 3 runs". In a terminal, the user answers
+// y, or types count for large deletions. --yes and --force skip the question,
+// only --force skips it for large deletions. Without a terminal the command
+// fails unless the flags confirm the change. Dry runs change nothing and are
+// never asked about.
+func confirm(cmd *cobra.Command, action string, count int) error {
+	if viper.GetBool("dry_run") {
+		return nil
+	}
+	yes, _ := cmd.Flags().GetBool("yes")
+	force, _ := cmd.Flags().GetBool("force")
+	large := count >= largeDeletion
+	if force || (yes && !large) {
+		return nil
+	}
+
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stderr) {
+		if large {
+			return fmt.Errorf("refusing to %s without confirmation: pass --force to confirm %d or more items", action, largeDeletion)
+		}
+		return fmt.Errorf("refusing to %s without confirmation: pass --yes to confirm", action)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	if large {
+		fmt.Fprintf(os.Stderr, "About to %s. Type %d to confirm: ", action, count)
+		answer, _ := reader.ReadString('\n')
+		if strings.TrimSpace(answer) != strconv.Itoa(count) {
+			return errAborted
+		}
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "About to %s. Continue? [y/N] ", action)
+	answer, _ := reader.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return errAborted
+	}
+}
+
+// plural returns "<count> <noun>" with an s appended to noun unless count is 1
+func plural(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
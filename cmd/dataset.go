@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/dataset"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+var datasetCmd = &cobra.Command{
+	Use:   "dataset",
+	Short: "Dataset utilities",
+	Long:  `Commands for working with the datasets tracked as inputs of runs.`,
+}
+
+var datasetDigestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Compute the digest of a dataset",
+	Long: `Compute the digest identifying the version of a dataset file and print it on
+stdout. With --run-id, the dataset is also logged as an input of the run, and
+--set-tag records the digest in a run tag.
+
+Algorithms:
+  mlflow  The digest mlflow.data.from_pandas computes for the DataFrame
+          pandas.read_csv reads from a CSV file with default options, so that
+          datasets logged by non-Python jobs match those logged from Python
+  sha256  The SHA-256 of the file contents, or of the files below a directory
+          such as a partitioned Parquet dataset
+
+Both are truncated to 8 characters like MLflow's digests. The default is mlflow
+for .csv files and sha256 otherwise.`,
+	RunE: datasetDigest,
+}
+
+// Digest algorithms of dataset digest
+var datasetDigestAlgorithms = []string{dataset.AlgorithmMLflow, dataset.AlgorithmSHA256}
+
+func init() {
+	rootCmd.AddCommand(datasetCmd)
+	datasetCmd.AddCommand(datasetDigestCmd)
+
+	// Dataset digest command flags
+	datasetDigestCmd.Flags().String("path", "", "Dataset file or directory (required)")
+	datasetDigestCmd.Flags().String("algorithm", "", "Digest algorithm: mlflow or sha256 (default: mlflow for .csv files, sha256 otherwise)")
+	datasetDigestCmd.Flags().String("run-id", "", "Run ID to log the dataset to as an input")
+	datasetDigestCmd.Flags().String("name", "", "Dataset name (default: the file name without extension)")
+	datasetDigestCmd.Flags().String("source", "", "URI or local path the dataset was read from (default: --path)")
+	datasetDigestCmd.Flags().String("context", "", "How the run used the dataset, e.g. training or evaluation")
+	datasetDigestCmd.Flags().String("set-tag", "", "Run tag to set to the digest (requires --run-id)")
+	datasetDigestCmd.MarkFlagRequired("path")
+
+	registerExamples(datasetDigestCmd,
+		example{Command: "mlflow-cli dataset digest --path data/train.csv"},
+		example{
+			Description: "Record the training data of a run for lineage",
+			Command: `mlflow-cli dataset digest --path data/train.parquet --run-id <run-id> \
+  --source s3://datasets/train.parquet --context training --set-tag train_digest`,
+		},
+	)
+}
+
+func datasetDigest(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	path, _ := cmd.Flags().GetString("path")
+	algorithm, _ := cmd.Flags().GetString("algorithm")
+	runID, _ := cmd.Flags().GetString("run-id")
+	name, _ := cmd.Flags().GetString("name")
+	source, _ := cmd.Flags().GetString("source")
+	datasetContext, _ := cmd.Flags().GetString("context")
+	tagKey, _ := cmd.Flags().GetString("set-tag")
+
+	if algorithm == "" {
+		algorithm = dataset.AlgorithmSHA256
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			algorithm = dataset.AlgorithmMLflow
+		}
+	}
+	if runID == "" {
+		for _, flag := range []string{"name", "source", "context", "set-tag"} {
+			if cmd.Flags().Changed(flag) {
+				return fmt.Errorf("--%s requires --run-id", flag)
+			}
+		}
+	}
+
+	var digest *dataset.Digest
+	var err error
+	switch algorithm {
+	case dataset.AlgorithmMLflow:
+		digest, err = pandasDigest(path)
+	case dataset.AlgorithmSHA256:
+		digest, err = dataset.SHA256(path)
+	default:
+		return fmt.Errorf("invalid algorithm: %s (valid: %s)", algorithm, strings.Join(datasetDigestAlgorithms, ", "))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to compute digest of %s: %w", path, err)
+	}
+
+	if runID != "" {
+		if err := logDatasetDigest(runID, path, name, source, datasetContext, tagKey, digest); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(digest.Digest)
+	return nil
+}
+
+// pandasDigest returns the mlflow digest of a CSV file
+func pandasDigest(path string) (*dataset.Digest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if info, err := file.Stat(); err == nil && info.IsDir() {
+		return nil, fmt.Errorf("the mlflow algorithm needs a CSV file, not a directory (use --algorithm sha256)")
+	}
+	return dataset.PandasCSV(file)
+}
+
+// logDatasetDigest logs a dataset as an input of a run, with the profile of
+// MLflow's pandas datasets if it was read as a table, and sets the tag tagKey
+// to its digest if given
+func logDatasetDigest(runID, path, name, source, datasetContext, tagKey string, digest *dataset.Digest) error {
+	if name == "" {
+		base := filepath.Base(path)
+		name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	if source == "" {
+		source = path
+	}
+
+	entry := models.Dataset{Name: name, Digest: digest.Digest}
+	var err error
+	entry.SourceType, entry.Source, err = datasetSource(source, "")
+	if err != nil {
+		return err
+	}
+	if digest.Algorithm == dataset.AlgorithmMLflow {
+		profile, err := json.Marshal(map[string]int64{"num_rows": digest.Rows, "num_elements": digest.Elements})
+		if err != nil {
+			return err
+		}
+		entry.Profile = string(profile)
+	}
+	tags := make(map[string]string)
+	if datasetContext != "" {
+		tags[mlflow.TagDatasetContext] = datasetContext
+	}
+
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	ctx := context.Background()
+	if err := client.LogDatasetInput(ctx, runID, entry, tags); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Logged dataset %s (digest %s) as input of run %s\n", name, digest.Digest, runID)
+
+	if tagKey != "" {
+		if err := client.SetTag(ctx, runID, tagKey, digest.Digest); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Set tag %s=%s on run %s\n", tagKey, digest.Digest, runID)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/output"
+)
+
+// runAttributeColumns are the run attributes selectable as dump columns
+var runAttributeColumns = []string{"run_id", "run_name", "experiment_id", "status", "start_time", "end_time", "artifact_uri"}
+
+// defaultRunAttributeColumns are dumped before all params and metrics when
+// --columns is not given
+var defaultRunAttributeColumns = []string{"run_id", "run_name", "status", "start_time", "end_time"}
+
+var experimentDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Print all runs of an experiment as a flat table",
+	Long: `Print one row per run of an experiment with the selected columns. Columns are
+run attributes (run_id, run_name, experiment_id, status, start_time, end_time,
+artifact_uri) or params.<key>, metrics.<key> (latest value) and tags.<key>.
+Without --columns, the default attributes and all params and metrics are
+printed. Cells of params, metrics or tags a run does not have are empty.
+--sort-by orders the runs by any column.`,
+	RunE: experimentDump,
+}
+
+func init() {
+	experimentCmd.AddCommand(experimentDumpCmd)
+
+	// Experiment dump command flags
+	addExperimentFlags(experimentDumpCmd)
+	experimentDumpCmd.Flags().StringSlice("columns", []string{}, "Comma-separated columns (default: attributes, all params and metrics)")
+	experimentDumpCmd.Flags().String("filter", "", "MLflow search filter expression")
+	addSortByFlag(experimentDumpCmd)
+	experimentDumpCmd.Flags().StringP("output", "o", output.FormatCSV, "Output format (csv/json/jsonl/table)")
+	addAbsoluteTimesFlag(experimentDumpCmd)
+
+	registerExamples(experimentDumpCmd,
+		example{Command: `mlflow-cli experiment dump --experiment-id 1 \
+  --columns run_id,params.lr,metrics.rmse,tags.git_sha --output csv > runs.csv`},
+		example{
+			Description: "Only finished runs",
+			Command:     `mlflow-cli experiment dump --experiment-name nightly --filter "attributes.status = 'FINISHED'"`,
+		},
+		example{
+			Description: "Best runs first",
+			Command:     "mlflow-cli experiment dump --experiment-id 1 --sort-by metrics.rmse --output table",
+		},
+	)
+}
+
+func experimentDump(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	filter, _ := cmd.Flags().GetString("filter")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+	layout, err := getTableLayout(cmd, nil, validateRunColumn)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	experimentID, err := resolveExperimentID(ctx, cmd, client, cfg)
+	if err != nil {
+		return err
+	}
+
+	runs, err := client.SearchRuns(ctx, []string{experimentID}, filter)
+	if err != nil {
+		return err
+	}
+
+	if len(layout.columns) == 0 {
+		layout.columns = defaultRunColumns(runs)
+	}
+
+	table := layout.apply(runTable(runs, layout.sourceColumns()))
+	humanizeTimes(cmd, format, table)
+
+	return output.Write(os.Stdout, format, table)
+}
+
+// validateRunColumn returns an error for columns runColumnValue cannot resolve
+func validateRunColumn(column string) error {
+	return validateColumn(column, runAttributeColumns)
+}
+
+// validateColumn returns an error for columns that are neither one of the
+// attributes nor params.<key>, metrics.<key> or tags.<key>
+func validateColumn(column string, attributes []string) error {
+	for _, prefix := range []string{"params.", "metrics.", "tags."} {
+		if key, ok := strings.CutPrefix(column, prefix); ok {
+			if key == "" {
+				return fmt.Errorf("invalid column: %s (missing key)", column)
+			}
+			return nil
+		}
+	}
+	for _, attribute := range attributes {
+		if column == attribute {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown column: %s (expected %s, params.<key>, metrics.<key> or tags.<key>)", column, strings.Join(attributes, ", "))
+}
+
+// defaultRunColumns returns the default attributes followed by every param and
+// metric key used by any of the runs, in key order
+func defaultRunColumns(runs []*models.RunInfo) []string {
+	params := make(map[string]bool)
+	metrics := make(map[string]bool)
+	for _, run := range runs {
+		for key := range run.Params {
+			params[key] = true
+		}
+		for key := range run.Metrics {
+			metrics[key] = true
+		}
+	}
+
+	columns := append([]string{}, defaultRunAttributeColumns...)
+	columns = append(columns, prefixedKeys("params.", params)...)
+	return append(columns, prefixedKeys("metrics.", metrics)...)
+}
+
+// prefixedKeys returns the sorted keys of a set with a prefix
+func prefixedKeys(prefix string, set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, prefix+key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// runTable returns a table of runs with the given columns
+func runTable(runs []*models.RunInfo, columns []string) *output.Table {
+	table := output.NewTable(columns...)
+	for _, run := range runs {
+		row := make([]any, len(columns))
+		for i, column := range columns {
+			row[i] = runColumnValue(run, column)
+		}
+		table.Append(row...)
+	}
+	return table
+}
+
+// runColumnValue returns the value of a column for a run, or nil if the run has
+// no such param, metric or tag
+func runColumnValue(run *models.RunInfo, column string) any {
+	if key, ok := strings.CutPrefix(column, "params."); ok {
+		if value, exists := run.Params[key]; exists {
+			return value
+		}
+		return nil
+	}
+	if key, ok := strings.CutPrefix(column, "metrics."); ok {
+		if value, exists := run.Metrics[key]; exists {
+			return value
+		}
+		return nil
+	}
+	if key, ok := strings.CutPrefix(column, "tags."); ok {
+		if value, exists := run.Tags[key]; exists {
+			return value
+		}
+		return nil
+	}
+
+	switch column {
+	case "run_id":
+		return run.RunID
+	case "run_name":
+		return run.RunName
+	case "experiment_id":
+		return run.ExperimentID
+	case "status":
+		return run.Status
+	case "start_time":
+		return run.StartTime
+	case "end_time":
+		return run.EndTime
+	case "artifact_uri":
+		return run.ArtifactURI
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Print shell statements setting the MLflow environment variables",
+	Long: `Print statements that set MLFLOW_TRACKING_URI, MLFLOW_EXPERIMENT_ID and
+MLFLOW_RUN_ID to the values of the active configuration (config file profile,
+environment and flags), in the syntax of the given shell. Variables without a
+value are unset, so that switching profiles never leaves a stale run behind.
+
+Shells: bash (also for zsh and sh), fish and powershell. The default is
+powershell on Windows, fish if $SHELL is fish, and bash otherwise.`,
+	RunE: printEnv,
+}
+
+// Shells supported by the env command
+var envShells = []string{"bash", "zsh", "sh", "fish", "powershell"}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+
+	// Env command flags
+	envCmd.Flags().String("shell", "", "Shell syntax: bash, zsh, sh, fish or powershell (default: detected)")
+	addExperimentFlags(envCmd)
+	envCmd.Flags().String("run-id", "", "Run ID (default: run_id of the configuration or MLFLOW_RUN_ID)")
+
+	registerExamples(envCmd,
+		example{
+			Description: "bash, zsh",
+			Command:     `eval "$(mlflow-cli env --profile staging --run-id <run-id>)"`,
+		},
+		example{
+			Description: "fish",
+			Command:     "mlflow-cli env --shell fish --profile staging | source",
+		},
+		example{
+			Description: "PowerShell",
+			Command:     "mlflow-cli env --shell powershell --profile staging | Out-String | Invoke-Expression",
+		},
+	)
+}
+
+func printEnv(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+
+	// Parse flags
+	shell, _ := cmd.Flags().GetString("shell")
+	experimentName, _ := cmd.Flags().GetString("experiment-name")
+	runID, _ := cmd.Flags().GetString("run-id")
+
+	if shell == "" {
+		shell = detectShell()
+	}
+	valid := false
+	for _, s := range envShells {
+		valid = valid || shell == s
+	}
+	if !valid {
+		return fmt.Errorf("unsupported shell: %s (supported: %s)", shell, strings.Join(envShells, ", "))
+	}
+
+	// Only experiment names need the tracking server
+	experimentID, _ := cmd.Flags().GetString("experiment-id")
+	if experimentID == "" {
+		experimentID = cfg.ExperimentID
+	}
+	if experimentName != "" {
+		client, err := mlflow.NewClient(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create MLflow client: %w", err)
+		}
+		if experimentID, err = resolveExperimentID(context.Background(), cmd, client, cfg); err != nil {
+			return err
+		}
+	}
+	if runID == "" {
+		runID = viper.GetString("run_id")
+	}
+
+	vars := []struct{ name, value string }{
+		{"MLFLOW_TRACKING_URI", cfg.TrackingURI},
+		{"MLFLOW_EXPERIMENT_ID", experimentID},
+		{"MLFLOW_RUN_ID", runID},
+	}
+	for _, v := range vars {
+		fmt.Println(envStatement(shell, v.name, v.value))
+	}
+	return nil
+}
+
+// detectShell returns the shell whose syntax is printed by default
+func detectShell() string {
+	if runtime.GOOS == "windows" {
+		return "powershell"
+	}
+	if filepath.Base(os.Getenv("SHELL")) == "fish" {
+		return "fish"
+	}
+	return "bash"
+}
+
+// envStatement returns the statement setting, or for an empty value unsetting,
+// an environment variable in a shell
+func envStatement(shell, name, value string) string {
+	switch shell {
+	case "fish":
+		if value == "" {
+			return fmt.Sprintf("set -e %s;", name)
+		}
+		// Backslashes and single quotes are the only escapes in fish quotes
+		quoted := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+		return fmt.Sprintf("set -gx %s '%s';", name, quoted)
+	case "powershell":
+		if value == "" {
+			return fmt.Sprintf("Remove-Item Env:%s -ErrorAction SilentlyContinue", name)
+		}
+		return fmt.Sprintf("$Env:%s = '%s'", name, strings.ReplaceAll(value, "'", "''"))
+	default:
+		if value == "" {
+			return fmt.Sprintf("unset %s", name)
+		}
+		return fmt.Sprintf("export %s='%s'", name, strings.ReplaceAll(value, "'", `'\''`))
+	}
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import "fmt"
+
+// ExitError carries a specific process exit code out of a command. Err, if
+// set, is reported like any other error.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
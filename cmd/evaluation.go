@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/parser"
+)
+
+var logCurveCmd = &cobra.Command{
+	Use:   "curve",
+	Short: "Log a ROC or precision-recall curve of a run",
+	Long: `Log a ROC or precision-recall curve like mlflow.evaluate, so that evaluation
+jobs not written in Python produce the same charts in the MLflow UI.
+
+The CSV file needs a header row with fpr and tpr columns for ROC curves, or
+recall and precision columns for precision-recall curves, and optionally a
+threshold column, as returned by sklearn.metrics.roc_curve and
+precision_recall_curve. The curve is logged as:
+  roc_curve_plot.svg               chart, shown in the artifact view
+  roc_curve_data.json              points, shown as a table
+  roc_auc                          metric of the area under the curve
+or precision_recall_curve_plot.svg, precision_recall_curve_data.json and
+precision_recall_auc, with all names prefixed by --prefix.`,
+	RunE: logCurve,
+}
+
+var logConfusionMatrixCmd = &cobra.Command{
+	Use:   "confusion-matrix",
+	Short: "Log a confusion matrix of a run",
+	Long: `Log a confusion matrix like mlflow.evaluate, so that evaluation jobs not
+written in Python produce the same chart in the MLflow UI.
+
+The CSV file holds the predicted labels in its header row after a corner cell,
+and a row of counts for each true label, with the labels in the same order:
+  ,cat,dog
+  cat,50,3
+  dog,5,42
+The matrix is logged as:
+  confusion_matrix.svg             heatmap, shown in the artifact view
+  confusion_matrix.json            counts, shown as a table
+  accuracy_score                   metric of the share of correct predictions
+with all names prefixed by --prefix.`,
+	RunE: logConfusionMatrix,
+}
+
+func init() {
+	logCmd.AddCommand(logCurveCmd)
+	logCmd.AddCommand(logConfusionMatrixCmd)
+
+	// Curve command flags
+	logCurveCmd.Flags().String("run-id", "", "Run ID to log the curve to (required)")
+	logCurveCmd.Flags().String("type", "", "Curve type: roc or pr (required)")
+	logCurveCmd.Flags().String("from-file", "", "CSV file of the points of the curve (required)")
+	logCurveCmd.Flags().String("prefix", "", "Prefix of the artifact names and metric key, e.g. test_")
+	logCurveCmd.MarkFlagRequired("run-id")
+	logCurveCmd.MarkFlagRequired("type")
+	logCurveCmd.MarkFlagRequired("from-file")
+
+	// Confusion matrix command flags
+	logConfusionMatrixCmd.Flags().String("run-id", "", "Run ID to log the confusion matrix to (required)")
+	logConfusionMatrixCmd.Flags().String("from-file", "", "CSV file of the confusion matrix (required)")
+	logConfusionMatrixCmd.Flags().String("prefix", "", "Prefix of the artifact names and metric key, e.g. test_")
+	logConfusionMatrixCmd.MarkFlagRequired("run-id")
+	logConfusionMatrixCmd.MarkFlagRequired("from-file")
+
+	registerExamples(logCurveCmd,
+		example{Command: "mlflow-cli log curve --run-id <run-id> --type roc --from-file roc.csv"},
+		example{
+			Description: "Log the precision-recall curve of the test set",
+			Command:     "mlflow-cli log curve --run-id <run-id> --type pr --from-file pr.csv --prefix test_",
+		},
+	)
+	registerExamples(logConfusionMatrixCmd,
+		example{Command: "mlflow-cli log confusion-matrix --run-id <run-id> --from-file cm.csv"},
+	)
+}
+
+func logCurve(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	curveType, _ := cmd.Flags().GetString("type")
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	prefix, _ := cmd.Flags().GetString("prefix")
+
+	file, err := os.Open(fromFile)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", fromFile, err)
+	}
+	defer file.Close()
+	curve, err := parser.ParseCSVCurve(file, models.CurveType(curveType))
+	if err != nil {
+		return err
+	}
+
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	result, err := client.LogCurve(context.Background(), runID, prefix, curve)
+	if err != nil {
+		return fmt.Errorf("failed to log curve %s: %w", fromFile, err)
+	}
+
+	printEvaluationResult(result)
+	return nil
+}
+
+func logConfusionMatrix(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	prefix, _ := cmd.Flags().GetString("prefix")
+
+	file, err := os.Open(fromFile)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", fromFile, err)
+	}
+	defer file.Close()
+	matrix, err := parser.ParseCSVConfusionMatrix(file)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	result, err := client.LogConfusionMatrix(context.Background(), runID, prefix, matrix)
+	if err != nil {
+		return fmt.Errorf("failed to log confusion matrix %s: %w", fromFile, err)
+	}
+
+	printEvaluationResult(result)
+	return nil
+}
+
+// printEvaluationResult prints what was logged for an evaluation chart
+func printEvaluationResult(result *mlflow.EvaluationResult) {
+	fmt.Printf("Successfully logged chart %s and table %s\n", result.Plot, result.Table)
+	fmt.Printf("  %s: %.4f\n", result.MetricKey, result.MetricValue)
+}
@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// example is a usage example of a command. Examples are shown by --help and
+// the examples command, and runnable ones are run by "examples run".
+type example struct {
+	// Description is printed as a comment above the command
+	Description string
+	// Command is the command line, starting with mlflow-cli unless it is a
+	// shell snippet
+	Command string
+	// Runnable examples are a single mlflow-cli command that needs nothing
+	// but a tracking server. <run-id> and <experiment-id> are filled in with
+	// a run created for the self-test and its experiment.
+	Runnable bool
+}
+
+// commandExamples holds the examples registered by each command
+var commandExamples = make(map[*cobra.Command][]example)
+
+// registerExamples adds examples to a command and renders them as the
+// examples section of its help
+func registerExamples(cmd *cobra.Command, examples ...example) {
+	commandExamples[cmd] = append(commandExamples[cmd], examples...)
+	cmd.Example = formatExamples(commandExamples[cmd])
+}
+
+// formatExamples renders examples indented like cobra's help sections.
+// Examples without a description belong to the one above them.
+func formatExamples(examples []example) string {
+	var b strings.Builder
+	for i, ex := range examples {
+		if i > 0 {
+			b.WriteString("\n")
+			if ex.Description != "" {
+				b.WriteString("\n")
+			}
+		}
+		if ex.Description != "" {
+			for _, line := range strings.Split(ex.Description, "\n") {
+				b.WriteString("  # " + line + "\n")
+			}
+		}
+		for j, line := range strings.Split(ex.Command, "\n") {
+			if j > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString("  " + line)
+		}
+	}
+	return b.String()
+}
+
+var examplesCmd = &cobra.Command{
+	Use:   "examples [command]",
+	Short: "Show the usage examples of commands",
+	Long: `Print the usage examples of a command and its subcommands, or of all commands
+without arguments. These are the examples --help shows.
+
+With --runnable, only the examples "examples run" runs are printed. As "run" is
+taken by the self-test, examples of the run commands are printed after --, e.g.
+"mlflow-cli examples -- run start".`,
+	Args: cobra.ArbitraryArgs,
+	RunE: examplesList,
+}
+
+var examplesRunCmd = &cobra.Command{
+	Use:   "run [command]",
+	Short: "Run the runnable examples against the tracking server",
+	Long: `Run the runnable examples of a command and its subcommands, or of all commands
+without arguments, as a self-test of the CLI and the tracking server, e.g. the
+development server of "make docker-up" or a new installation.
+
+The examples are run as separate processes with the global flags of this
+command, in a run created for the self-test in the configured experiment. The
+run is ended as FINISHED if every example succeeded, and FAILED otherwise. The
+output of failed examples is printed, and of all examples with --verbose.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: examplesRun,
+}
+
+func init() {
+	rootCmd.AddCommand(examplesCmd)
+	examplesCmd.AddCommand(examplesRunCmd)
+
+	// Examples command flags
+	examplesCmd.Flags().Bool("runnable", false, "Only print the examples that examples run runs")
+
+	// Examples run command flags
+	examplesRunCmd.Flags().BoolP("verbose", "v", false, "Print the output of every example, not only of failed ones")
+
+	registerExamples(examplesCmd,
+		example{Command: "mlflow-cli examples log artifact"},
+		example{Command: "mlflow-cli examples -- run start"},
+	)
+	registerExamples(examplesRunCmd,
+		example{
+			Description: "Check the CLI against the development server of make docker-up",
+			Command:     "mlflow-cli examples run --tracking-uri http://localhost:5001 --experiment-id 0",
+		},
+		example{Command: "mlflow-cli examples run log"},
+	)
+}
+
+// exampleOf is an example with the command that registered it
+type exampleOf struct {
+	cmd *cobra.Command
+	example
+}
+
+// collectExamples returns the examples of the command named by args and its
+// subcommands, in the order of help
+func collectExamples(root *cobra.Command, args []string) ([]exampleOf, error) {
+	target := root
+	if len(args) > 0 {
+		found, rest, err := root.Find(args)
+		if err != nil || len(rest) > 0 || found == root {
+			return nil, fmt.Errorf("unknown command %q", strings.Join(args, " "))
+		}
+		target = found
+	}
+
+	var examples []exampleOf
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, ex := range commandExamples[cmd] {
+			examples = append(examples, exampleOf{cmd: cmd, example: ex})
+		}
+		for _, sub := range cmd.Commands() {
+			if sub.IsAvailableCommand() {
+				walk(sub)
+			}
+		}
+	}
+	walk(target)
+	return examples, nil
+}
+
+func examplesList(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	runnable, _ := cmd.Flags().GetBool("runnable")
+
+	examples, err := collectExamples(cmd.Root(), args)
+	if err != nil {
+		return err
+	}
+
+	// Examples are printed under the command they belong to
+	var current *cobra.Command
+	var group []example
+	flush := func() {
+		if len(group) > 0 {
+			fmt.Printf("%s:\n%s\n\n", current.CommandPath(), formatExamples(group))
+		}
+		group = nil
+	}
+	for _, ex := range examples {
+		if runnable && !ex.Runnable {
+			continue
+		}
+		if ex.cmd != current {
+			flush()
+			current = ex.cmd
+		}
+		group = append(group, ex.example)
+	}
+	flush()
+	return nil
+}
+
+// examplePlaceholder matches the <...> placeholders of examples
+var examplePlaceholder = regexp.MustCompile(`<[a-z-]+>`)
+
+func examplesRun(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	verbose, _ := cmd.Flags().GetBool("verbose")
+
+	examples, err := collectExamples(cmd.Root(), args)
+	if err != nil {
+		return err
+	}
+	var runnable []exampleOf
+	for _, ex := range examples {
+		if ex.Runnable {
+			runnable = append(runnable, ex)
+		}
+	}
+	if len(runnable) == 0 {
+		return fmt.Errorf("no runnable examples")
+	}
+
+	experimentID := cfg.ExperimentID
+	if experimentID == "" {
+		return fmt.Errorf("experiment ID must be specified via --experiment-id flag or MLFLOW_EXPERIMENT_ID environment variable")
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate mlflow-cli: %w", err)
+	}
+
+	// The examples inherit the global flags given to this command
+	var globalArgs []string
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if cmd.Root().PersistentFlags().Lookup(flag.Name) != nil {
+			globalArgs = append(globalArgs, "--"+flag.Name+"="+flag.Value.String())
+		}
+	})
+
+	ctx := context.Background()
+	runName := "examples-self-test"
+	runInfo, err := client.CreateRun(ctx, &models.RunConfig{ExperimentID: &experimentID, RunName: &runName})
+	if err != nil {
+		return fmt.Errorf("failed to create run: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Running %d examples in run %s\n", len(runnable), runInfo.RunID)
+
+	failed := 0
+	for _, ex := range runnable {
+		line := strings.NewReplacer("<run-id>", runInfo.RunID, "<experiment-id>", experimentID).Replace(ex.Command)
+		output, err := runExample(ctx, executable, globalArgs, line)
+		if err != nil {
+			failed++
+			fmt.Printf("FAIL  %s\n", line)
+		} else {
+			fmt.Printf("ok    %s\n", line)
+		}
+		if err != nil || verbose {
+			for _, outputLine := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+				fmt.Printf("      %s\n", outputLine)
+			}
+			if err != nil {
+				fmt.Printf("      %v\n", err)
+			}
+		}
+	}
+
+	status := models.RunStatusFinished
+	if failed > 0 {
+		status = models.RunStatusFailed
+	}
+	if err := client.UpdateRun(ctx, runInfo.RunID, status); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to end run %s: %v\n", runInfo.RunID, err)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d examples failed", failed, len(runnable))
+	}
+	fmt.Fprintf(os.Stderr, "All %d examples succeeded\n", len(runnable))
+	return nil
+}
+
+// runExample runs the command line of a runnable example with mlflow-cli
+// replaced by executable, and returns its combined output
+func runExample(ctx context.Context, executable string, globalArgs []string, line string) (string, error) {
+	if placeholder := examplePlaceholder.FindString(line); placeholder != "" {
+		return "", fmt.Errorf("example has an unknown placeholder %s", placeholder)
+	}
+	argv, err := splitCommandLine(line)
+	if err != nil {
+		return "", err
+	}
+	if len(argv) == 0 || argv[0] != "mlflow-cli" {
+		return "", fmt.Errorf("runnable examples must be a single mlflow-cli command")
+	}
+
+	child := exec.CommandContext(ctx, executable, append(globalArgs, argv[1:]...)...)
+	output, err := child.CombinedOutput()
+	return string(output), err
+}
+
+// splitCommandLine splits a command line into words like a POSIX shell with
+// single and double quotes, backslash escapes and line continuations. Other
+// shell syntax such as pipes and variables is not supported.
+func splitCommandLine(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case c == '\\':
+			i++
+			if i < len(line) && line[i] != '\n' {
+				word.WriteByte(line[i])
+				inWord = true
+			}
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(line[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote in %s", line)
+			}
+			word.WriteString(line[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case strings.IndexByte("|&;<>()$`", c) >= 0:
+			return nil, fmt.Errorf("shell syntax %q is not supported in runnable examples", c)
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+var runExecCmd = &cobra.Command{
+	Use:   "exec [flags] -- command [args...]",
+	Short: "Run a command inside a new MLflow run",
+	Long: `Create a new MLflow run, execute the given command with MLFLOW_RUN_ID exported
+into its environment, and end the run as FINISHED or FAILED based on the exit status.
+The command's exit status is propagated as the exit status of mlflow-cli.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runExec,
+}
+
+func init() {
+	runCmd.AddCommand(runExecCmd)
+
+	// Exec command flags
+	addRunConfigFlags(runExecCmd)
+	runExecCmd.Flags().Bool("log-environment", false, "Upload pip/conda/system environment snapshots as environment/ artifacts")
+	runExecCmd.Flags().Bool("log-hardware", false, "Record CPU/memory/GPU inventory as hardware.json artifact and tags")
+	addPRCommentFlag(runExecCmd)
+
+	registerExamples(runExecCmd,
+		example{
+			Description: "Track a training script",
+			Command:     "mlflow-cli run exec --experiment-id 1 -- python train.py --epochs 10",
+		},
+		example{
+			Description: "With run name and tags",
+			Command:     "mlflow-cli run exec --run-name nightly --tag stage=ci -- ./train.sh",
+		},
+	)
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	runConfig, err := buildRunConfig(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	hardware := detectHardware(cmd, runConfig)
+	cmd.SilenceUsage = true
+
+	// Create run
+	ctx := context.Background()
+	runInfo, err := client.CreateRun(ctx, runConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create run: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Started run %s\n", runInfo.RunID)
+
+	if logEnv, _ := cmd.Flags().GetBool("log-environment"); logEnv {
+		logEnvironment(ctx, client, runInfo.RunID)
+	}
+	if hardware != nil {
+		logHardware(ctx, client, runInfo.RunID, hardware)
+	}
+
+	exitCode, status, execErr := executeChild(args, childEnv(cfg, runInfo))
+
+	// End run even if the command could not be started
+	if err := client.UpdateRun(ctx, runInfo.RunID, status); err != nil {
+		return fmt.Errorf("failed to end run: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Run %s ended with status %s\n", runInfo.RunID, status)
+	commentOnPullRequest(ctx, cmd, client, cfg.DryRun, runInfo.RunID)
+
+	if execErr != nil {
+		return fmt.Errorf("failed to execute command: %w", execErr)
+	}
+	if exitCode != 0 {
+		cmd.SilenceErrors = true
+		return &ExitError{Code: exitCode}
+	}
+
+	return nil
+}
+
+// childEnv builds the environment for the child process with run context exported
+func childEnv(cfg *config.Config, runInfo *models.RunInfo) []string {
+	return append(os.Environ(),
+		"MLFLOW_RUN_ID="+runInfo.RunID,
+		"MLFLOW_EXPERIMENT_ID="+runInfo.ExperimentID,
+		"MLFLOW_TRACKING_URI="+cfg.TrackingURI,
+	)
+}
+
+// executeChild runs the command, forwarding signals, and maps its outcome to a run status
+func executeChild(args []string, env []string) (int, models.RunStatus, error) {
+	child := exec.Command(args[0], args[1:]...)
+	child.Env = env
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+
+	if err := child.Start(); err != nil {
+		return 1, models.RunStatusFailed, err
+	}
+
+	// Forward interrupts to the child so it can shut down gracefully
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	var interrupted atomic.Bool
+	go func() {
+		for sig := range signals {
+			interrupted.Store(true)
+			child.Process.Signal(sig)
+		}
+	}()
+
+	err := child.Wait()
+	if err == nil {
+		return 0, models.RunStatusFinished, nil
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return 1, models.RunStatusFailed, err
+	}
+
+	// ExitCode is -1 when the child was terminated by a signal, which is
+	// reported as 128+signal like shells do, e.g. 137 for SIGKILL
+	code := exitErr.ExitCode()
+	if code < 0 {
+		code = 1
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			code = 128 + int(status.Signal())
+		}
+	}
+	if interrupted.Load() {
+		return code, models.RunStatusKilled, nil
+	}
+	return code, models.RunStatusFailed, nil
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+var experimentCmd = &cobra.Command{
+	Use:   "experiment",
+	Short: "Experiment management commands",
+	Long:  `Commands for managing MLflow experiments.`,
+}
+
+var experimentUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update an experiment's description and tags",
+	Long: `Update the description (shown on the experiment page) and tags of an
+experiment. The description replaces the existing one.`,
+	RunE: experimentUpdate,
+}
+
+var experimentNoteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Experiment note commands",
+	Long:  `Commands for reading and extending an experiment's note (description).`,
+}
+
+var experimentNoteAppendCmd = &cobra.Command{
+	Use:   "append",
+	Short: "Append text to an experiment's note",
+	Long: `Append text to the note (description) of an experiment, separated from the
+existing note by a blank line.`,
+	RunE: experimentNoteAppend,
+}
+
+var experimentNoteShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print an experiment's note",
+	RunE:  experimentNoteShow,
+}
+
+func init() {
+	rootCmd.AddCommand(experimentCmd)
+	experimentCmd.AddCommand(experimentUpdateCmd)
+	experimentCmd.AddCommand(experimentNoteCmd)
+	experimentNoteCmd.AddCommand(experimentNoteAppendCmd)
+	experimentNoteCmd.AddCommand(experimentNoteShowCmd)
+
+	// Experiment update command flags
+	addExperimentFlags(experimentUpdateCmd)
+	experimentUpdateCmd.Flags().String("description", "", "Experiment description (Markdown)")
+	experimentUpdateCmd.Flags().String("description-file", "", "Read the description from a file (- for stdin)")
+	experimentUpdateCmd.Flags().StringArray("tag", []string{}, "Tag in key=value format (can be specified multiple times)")
+	addTagsFileFlag(experimentUpdateCmd)
+	experimentUpdateCmd.MarkFlagsMutuallyExclusive("description", "description-file")
+	experimentUpdateCmd.MarkFlagsOneRequired("description", "description-file", "tag", "tags-file")
+
+	// Experiment note append command flags
+	addExperimentFlags(experimentNoteAppendCmd)
+	experimentNoteAppendCmd.Flags().String("text", "", "Text to append")
+	experimentNoteAppendCmd.Flags().String("from-file", "", "Read the text to append from a file (- for stdin)")
+	experimentNoteAppendCmd.MarkFlagsMutuallyExclusive("text", "from-file")
+	experimentNoteAppendCmd.MarkFlagsOneRequired("text", "from-file")
+
+	// Experiment note show command flags
+	addExperimentFlags(experimentNoteShowCmd)
+
+	registerExamples(experimentUpdateCmd,
+		example{Command: `mlflow-cli experiment update --experiment-name nightly-benchmark \
+  --description-file docs/benchmark.md --tag owner=ml-platform`},
+	)
+	registerExamples(experimentNoteShowCmd,
+		example{Command: "mlflow-cli experiment note show --experiment-id <experiment-id>", Runnable: true},
+	)
+	registerExamples(experimentNoteAppendCmd,
+		example{Command: `mlflow-cli experiment note append --experiment-id 1 --text "2024-05-01: switched to bf16"
+git log -1 --format=%B | mlflow-cli experiment note append --from-file -`},
+	)
+}
+
+// addExperimentFlags registers the flags selecting an experiment
+func addExperimentFlags(cmd *cobra.Command) {
+	cmd.Flags().String("experiment-id", "", "Experiment ID (overrides MLFLOW_EXPERIMENT_ID)")
+	cmd.Flags().String("experiment-name", "", "Experiment name")
+	cmd.MarkFlagsMutuallyExclusive("experiment-id", "experiment-name")
+}
+
+// resolveExperimentID returns the experiment selected by flags, falling back to
+// the configured experiment ID
+func resolveExperimentID(ctx context.Context, cmd *cobra.Command, client *mlflow.Client, cfg *config.Config) (string, error) {
+	if name, _ := cmd.Flags().GetString("experiment-name"); name != "" {
+		experimentID, err := client.GetExperimentIDByName(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		if experimentID == "" {
+			return "", fmt.Errorf("experiment %s does not exist", name)
+		}
+		return experimentID, nil
+	}
+
+	experimentID, _ := cmd.Flags().GetString("experiment-id")
+	if experimentID == "" {
+		experimentID = cfg.ExperimentID
+	}
+	if experimentID == "" {
+		return "", fmt.Errorf("experiment must be specified via --experiment-id, --experiment-name or MLFLOW_EXPERIMENT_ID")
+	}
+	return experimentID, nil
+}
+
+// readTextInput reads text from a file, or from stdin if path is "-"
+func readTextInput(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+func experimentUpdate(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	description, _ := cmd.Flags().GetString("description")
+	descriptionFile, _ := cmd.Flags().GetString("description-file")
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	tagsFile, _ := cmd.Flags().GetString("tags-file")
+
+	tagMap, err := parseTags(tags)
+	if err != nil {
+		return err
+	}
+	if err := mergeTagsFile(tagMap, tagsFile); err != nil {
+		return err
+	}
+	if descriptionFile != "" {
+		description, err = readTextInput(descriptionFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	experimentID, err := resolveExperimentID(ctx, cmd, client, cfg)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("description") || descriptionFile != "" {
+		if err := client.SetExperimentDescription(ctx, experimentID, description); err != nil {
+			return fmt.Errorf("failed to update description: %w", err)
+		}
+	}
+	for key, value := range tagMap {
+		if err := client.SetExperimentTag(ctx, experimentID, key, value); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Successfully updated experiment %s\n", experimentID)
+	return nil
+}
+
+func experimentNoteAppend(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	text, _ := cmd.Flags().GetString("text")
+	fromFile, _ := cmd.Flags().GetString("from-file")
+
+	if fromFile != "" {
+		text, err = readTextInput(fromFile)
+		if err != nil {
+			return err
+		}
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("nothing to append")
+	}
+
+	ctx := context.Background()
+	experimentID, err := resolveExperimentID(ctx, cmd, client, cfg)
+	if err != nil {
+		return err
+	}
+
+	experiment, err := client.GetExperiment(ctx, experimentID)
+	if err != nil {
+		return err
+	}
+
+	note := text
+	if existing := strings.TrimRight(experiment.Description, "\n"); existing != "" {
+		note = existing + "\n\n" + text
+	}
+	if err := client.SetExperimentDescription(ctx, experimentID, note); err != nil {
+		return fmt.Errorf("failed to update note: %w", err)
+	}
+
+	fmt.Printf("Successfully appended to the note of experiment %s\n", experimentID)
+	return nil
+}
+
+func experimentNoteShow(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	ctx := context.Background()
+	experimentID, err := resolveExperimentID(ctx, cmd, client, cfg)
+	if err != nil {
+		return err
+	}
+
+	experiment, err := client.GetExperiment(ctx, experimentID)
+	if err != nil {
+		return err
+	}
+
+	if experiment.Description != "" {
+		fmt.Println(strings.TrimRight(experiment.Description, "\n"))
+	}
+	return nil
+}
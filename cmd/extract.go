@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/output"
+	"github.com/imishinist/mlflow-cli/internal/parser"
+)
+
+var extractCmd = &cobra.Command{
+	Use:   "extract",
+	Short: "Work with metric extraction patterns",
+	Long:  "Develop the regular expressions used by log metrics --extract and --pattern",
+}
+
+var extractTestCmd = &cobra.Command{
+	Use:   "test <log-file>",
+	Short: "Preview the metrics extracted from a log",
+	Long: `Apply extraction patterns to a log file (or stdin with -) and print the data
+points they produce, without logging anything. Named groups become metric keys;
+the groups step and timestamp set the step and timestamp.`,
+	Args: cobra.ExactArgs(1),
+	RunE: extractTest,
+}
+
+func init() {
+	rootCmd.AddCommand(extractCmd)
+	extractCmd.AddCommand(extractTestCmd)
+
+	// Extract test command flags
+	extractTestCmd.Flags().StringArray("extract", []string{}, "Regular expression with named groups (required, can be specified multiple times)")
+	extractTestCmd.Flags().Bool("show-unmatched", false, "Also list lines no pattern matched")
+	extractTestCmd.Flags().StringP("output", "o", output.FormatTable, "Output format (csv/json/jsonl/table)")
+	extractTestCmd.MarkFlagRequired("extract")
+
+	registerExamples(extractTestCmd,
+		example{Command: `mlflow-cli extract test --extract 'epoch (?P<step>\d+).*loss (?P<loss>[\d.]+)' train.log
+tail -n 100 train.log | mlflow-cli extract test --extract 'acc=(?P<accuracy>[\d.]+)' -`},
+	)
+}
+
+func extractTest(cmd *cobra.Command, args []string) error {
+	extracts, _ := cmd.Flags().GetStringArray("extract")
+	showUnmatched, _ := cmd.Flags().GetBool("show-unmatched")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+	extractor, err := parser.NewLineExtractor(extracts)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader = os.Stdin
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open file %s: %w", args[0], err)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	table := output.NewTable("line", "step", "timestamp", "key", "value")
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber, matched := 0, 0
+	keys := make(map[string]bool)
+
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		point, ok, err := extractor.Extract(line)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: line %d: %v\n", lineNumber, err)
+			continue
+		}
+		if !ok {
+			if showUnmatched {
+				fmt.Fprintf(os.Stderr, "unmatched %d: %s\n", lineNumber, line)
+			}
+			continue
+		}
+
+		matched++
+		for _, key := range sortedPointKeys(point) {
+			keys[key] = true
+			table.Append(lineNumber, extractedStep(point), extractedTimestamp(point), key, point.Values[key])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read log: %w", err)
+	}
+
+	if err := output.Write(os.Stdout, format, table); err != nil {
+		return err
+	}
+
+	metricKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		metricKeys = append(metricKeys, key)
+	}
+	sort.Strings(metricKeys)
+	fmt.Fprintf(os.Stderr, "Matched %d of %d lines (metrics: %s)\n", matched, lineNumber, strings.Join(metricKeys, ", "))
+	return nil
+}
+
+// sortedPointKeys returns the metric keys of a point in order
+func sortedPointKeys(point models.MetricPoint) []string {
+	keys := make([]string, 0, len(point.Values))
+	for key := range point.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// extractedStep returns the captured step, or nil to leave the cell empty
+func extractedStep(point models.MetricPoint) any {
+	if point.Step == nil {
+		return nil
+	}
+	return *point.Step
+}
+
+// extractedTimestamp returns the captured timestamp, or nil to leave the cell empty
+func extractedTimestamp(point models.MetricPoint) any {
+	switch {
+	case point.Timestamp != nil:
+		return *point.Timestamp
+	case point.Offset != nil:
+		return "+" + point.Offset.String()
+	default:
+		return nil
+	}
+}
+
+// readLogMetrics passes the metric points of a free-form log file to fn
+func readLogMetrics(path string, extractor *parser.LineExtractor, fn func(models.MetricPoint) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if err := parser.ReadLogMetrics(file, extractor, fn); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/glob"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	timeutils "github.com/imishinist/mlflow-cli/internal/time"
+)
+
+var runFinalizeCmd = &cobra.Command{
+	Use:   "finalize",
+	Short: "Log final metrics and artifacts, append a completion note, and end a run",
+	Long: `Run the usual tail of a pipeline in one invocation: log summary metrics, upload
+closing artifacts, append a completion note to the run description, and end the
+run.
+
+All inputs are read before anything is sent, so a missing file leaves the run
+untouched. If logging metrics or uploading any artifact fails, the remaining
+steps still run, the completion note records the failure, and the run ends as
+FAILED instead of the requested status. A summary of every step is printed to
+stderr and the command fails, so the finalize can be fixed and run again.`,
+	RunE: runFinalize,
+}
+
+func init() {
+	runCmd.AddCommand(runFinalizeCmd)
+
+	// Run finalize command flags
+	runFinalizeCmd.Flags().String("run-id", "", "Run ID to finalize (required)")
+	runFinalizeCmd.Flags().String("status", "FINISHED", "End status if every step succeeds (FINISHED/FAILED/KILLED)")
+	runFinalizeCmd.Flags().StringArray("summary-metrics", []string{}, "Metrics file (JSON/YAML/CSV, can be specified multiple times)")
+	runFinalizeCmd.Flags().StringArray("artifacts", []string{}, "Artifact file or glob pattern such as 'reports/**' (can be specified multiple times)")
+	runFinalizeCmd.Flags().String("artifact-path", "", "Artifact directory to upload the artifacts into")
+	runFinalizeCmd.Flags().Int("parallelism", 4, "Number of files uploaded concurrently")
+	runFinalizeCmd.Flags().String("note", "", "Text to add to the completion note")
+	addPRCommentFlag(runFinalizeCmd)
+	runFinalizeCmd.MarkFlagRequired("run-id")
+
+	registerExamples(runFinalizeCmd,
+		example{Command: `mlflow-cli run finalize --run-id <run-id> --status FINISHED \
+  --summary-metrics final.json --artifacts 'reports/**'`},
+		example{
+			Description: "Add a custom line to the completion note",
+			Command:     `mlflow-cli run finalize --run-id <run-id> --note "Promoted to staging"`,
+		},
+	)
+}
+
+// finalizeStep is the outcome of one step of a finalize
+type finalizeStep struct {
+	name   string
+	result string
+	err    error
+}
+
+func runFinalize(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	status, _ := cmd.Flags().GetString("status")
+	metricsFiles, _ := cmd.Flags().GetStringArray("summary-metrics")
+	artifactPatterns, _ := cmd.Flags().GetStringArray("artifacts")
+	artifactPath, _ := cmd.Flags().GetString("artifact-path")
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+	note, _ := cmd.Flags().GetString("note")
+
+	// Validation
+	runStatus, valid := validRunStatuses[status]
+	if !valid {
+		return fmt.Errorf("invalid status: %s (valid: FINISHED, FAILED, KILLED)", status)
+	}
+	if parallelism < 1 {
+		return fmt.Errorf("parallelism must be at least 1")
+	}
+
+	// Load all inputs before touching the run
+	location, err := timeutils.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return err
+	}
+	timeConfig := models.TimeConfig{
+		Resolution: cfg.TimeResolution,
+		Alignment:  cfg.TimeAlignment,
+		StepMode:   cfg.StepMode,
+		Aggregate:  cfg.Aggregate,
+		Location:   location,
+	}
+	var metrics []models.Metric
+	for _, fromFile := range metricsFiles {
+		metricsFile, err := loadMetricsFile(fromFile)
+		if err != nil {
+			return err
+		}
+		processed, err := timeutils.ProcessMetrics(metricsFile.Metrics, timeConfig, nil)
+		if err != nil {
+			return fmt.Errorf("failed to process metrics from %s: %w", fromFile, err)
+		}
+		if len(processed) == 0 {
+			return fmt.Errorf("no metrics found in %s", fromFile)
+		}
+		metrics = append(metrics, processed...)
+	}
+
+	for _, pattern := range artifactPatterns {
+		if !glob.HasMeta(pattern) {
+			if _, err := os.Stat(pattern); err != nil {
+				return fmt.Errorf("artifact not found: %s", pattern)
+			}
+			continue
+		}
+		matches, err := glob.Expand(pattern)
+		if err != nil {
+			return fmt.Errorf("failed to expand artifact pattern %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("no files match artifact pattern: %s", pattern)
+		}
+	}
+	uploads, err := collectArtifactUploads(artifactPatterns, nil, nil, artifactPath)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var steps []finalizeStep
+
+	if len(metrics) > 0 {
+		step := finalizeStep{name: "metrics"}
+		if err := client.LogBatchMetrics(ctx, runID, metrics); err != nil {
+			step.err = fmt.Errorf("failed to log metrics: %w", err)
+		} else {
+			step.result = fmt.Sprintf("logged %d metrics", len(metrics))
+		}
+		steps = append(steps, step)
+	}
+
+	if len(uploads) > 0 {
+		steps = append(steps, uploadFinalArtifacts(ctx, client, runID, uploads, parallelism))
+	}
+
+	// A failed step turns the run into a failed one
+	endStatus := runStatus
+	for _, step := range steps {
+		if step.err != nil {
+			endStatus = models.RunStatusFailed
+		}
+	}
+
+	noteStep := finalizeStep{name: "note", result: "appended completion note"}
+	if err := appendRunNote(ctx, client, runID, completionNote(note, endStatus, steps)); err != nil {
+		noteStep.result, noteStep.err = "", err
+	}
+	steps = append(steps, noteStep)
+
+	endStep := finalizeStep{name: "end", result: fmt.Sprintf("ended with status %s", endStatus)}
+	if endStatus != runStatus {
+		endStep.result += fmt.Sprintf(" instead of %s", runStatus)
+	}
+	if err := client.UpdateRun(ctx, runID, endStatus); err != nil {
+		endStep.result, endStep.err = "", fmt.Errorf("failed to end run: %w", err)
+	}
+	steps = append(steps, endStep)
+
+	// Report every step, then fail if any of them did
+	fmt.Fprintf(os.Stderr, "Finalize of run %s:\n", runID)
+	var failed []string
+	for _, step := range steps {
+		if step.err != nil {
+			fmt.Fprintf(os.Stderr, "  %-10s FAILED  %v\n", step.name, step.err)
+			failed = append(failed, step.name)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "  %-10s ok      %s\n", step.name, step.result)
+	}
+
+	if endStep.err == nil {
+		commentOnPullRequest(ctx, cmd, client, cfg.DryRun, runID)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("finalize of run %s incomplete: %s failed", runID, strings.Join(failed, ", "))
+	}
+	fmt.Printf("Run %s finalized with status %s\n", runID, endStatus)
+	return nil
+}
+
+// uploadFinalArtifacts uploads the closing artifacts of a run, reporting each
+// failed file
+func uploadFinalArtifacts(ctx context.Context, client *mlflow.Client, runID string, uploads []mlflow.ArtifactUpload, parallelism int) finalizeStep {
+	step := finalizeStep{name: "artifacts"}
+
+	progress := newProgressPrinter("Uploading artifacts")
+	errs, err := client.UploadArtifactsParallel(ctx, runID, uploads, parallelism, progress.Update)
+	progress.Done()
+	if err != nil {
+		step.err = err
+		return step
+	}
+
+	failedCount := 0
+	for i, err := range errs {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to upload %s: %v\n", uploads[i].FilePath, err)
+			failedCount++
+		}
+	}
+	if failedCount > 0 {
+		step.err = fmt.Errorf("failed to upload %d of %d artifacts", failedCount, len(uploads))
+		return step
+	}
+	step.result = fmt.Sprintf("uploaded %d artifacts", len(uploads))
+	return step
+}
+
+// completionNote describes the outcome of a finalize, after the custom text
+func completionNote(text string, status models.RunStatus, steps []finalizeStep) string {
+	var b strings.Builder
+	if text = strings.TrimSpace(text); text != "" {
+		b.WriteString(text)
+		b.WriteString("\n\n")
+	}
+	fmt.Fprintf(&b, "Finalized with status %s at %s.", status, time.Now().UTC().Format(time.RFC3339))
+	for _, step := range steps {
+		if step.err != nil {
+			fmt.Fprintf(&b, "\n- %s: %v", step.name, step.err)
+		} else {
+			fmt.Fprintf(&b, "\n- %s: %s", step.name, step.result)
+		}
+	}
+	return b.String()
+}
+
+// appendRunNote appends text to the description of a run
+func appendRunNote(ctx context.Context, client *mlflow.Client, runID, text string) error {
+	run, err := client.GetRun(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	note := text
+	if existing := strings.TrimRight(run.Description, "\n"); existing != "" {
+		note = existing + "\n\n" + text
+	}
+	if err := client.SetTag(ctx, runID, mlflow.TagNoteContent, note); err != nil {
+		return fmt.Errorf("failed to update note: %w", err)
+	}
+	return nil
+}
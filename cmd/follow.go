@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/parser"
+)
+
+// lineParser converts a complete line of a followed file into a metric point.
+// It returns ok=false for lines that carry no data point (e.g. a CSV header).
+type lineParser func(line string) (point models.MetricPoint, ok bool, err error)
+
+// newLineParser returns a parser for the format inferred from the file extension
+func newLineParser(path string) (func() lineParser, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return func() lineParser {
+			return func(line string) (models.MetricPoint, bool, error) {
+				point, err := parser.ParseJSONLMetricLine([]byte(line))
+				return point, err == nil, err
+			}
+		}, nil
+	case ".csv":
+		// The header is re-read after truncation or rotation, so every file
+		// generation gets a fresh parser
+		return func() lineParser {
+			var header []string
+			return func(line string) (models.MetricPoint, bool, error) {
+				if header == nil {
+					h, err := parser.ParseCSVHeader(line)
+					if err != nil {
+						return models.MetricPoint{}, false, err
+					}
+					header = h
+					return models.MetricPoint{}, false, nil
+				}
+				point, err := parser.ParseCSVMetricLine(header, line)
+				return point, err == nil, err
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported follow format: %s (supported: .jsonl, .ndjson, .csv)", filepath.Ext(path))
+	}
+}
+
+// fileFollower reads lines appended to a file, like tail -F
+type fileFollower struct {
+	path    string
+	file    *os.File
+	info    os.FileInfo
+	offset  int64
+	partial []byte
+}
+
+// open (re)opens the followed file from the beginning
+func (f *fileFollower) open() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	if f.file != nil {
+		f.file.Close()
+	}
+	f.file = file
+	f.info = info
+	f.offset = 0
+	f.partial = nil
+	return nil
+}
+
+// close closes the followed file
+func (f *fileFollower) close() {
+	if f.file != nil {
+		f.file.Close()
+	}
+}
+
+// readLines returns the complete lines appended since the last call
+func (f *fileFollower) readLines() ([]string, error) {
+	data, err := io.ReadAll(f.file)
+	if err != nil {
+		return nil, err
+	}
+	f.offset += int64(len(data))
+
+	data = append(f.partial, data...)
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		f.partial = data
+		return nil, nil
+	}
+	f.partial = append([]byte(nil), data[end+1:]...)
+
+	var lines []string
+	for _, line := range strings.Split(string(data[:end]), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// checkReset detects truncation and rotation. It returns true when the file
+// was reopened from the beginning.
+func (f *fileFollower) checkReset() (bool, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// Rotated away and not yet recreated
+			return false, nil
+		}
+		return false, err
+	}
+
+	if !os.SameFile(info, f.info) {
+		return true, f.open()
+	}
+
+	if info.Size() < f.offset {
+		// Truncated in place
+		if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+			return false, err
+		}
+		f.offset = 0
+		f.partial = nil
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// followMetrics logs data points appended to a JSONL/CSV file until ctx is
+// cancelled, handling truncation and rotation
+func followMetrics(ctx context.Context, streamer *metricStreamer, path string, pollInterval, flushInterval time.Duration) error {
+	newParser, err := newLineParser(path)
+	if err != nil {
+		return err
+	}
+
+	follower := &fileFollower{path: path}
+	if err := follower.open(); err != nil {
+		return fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	defer follower.close()
+
+	parse := newParser()
+	lineNumber := 0
+
+	consume := func() error {
+		lines, err := follower.readLines()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		for _, line := range lines {
+			lineNumber++
+			point, ok, err := parse(line)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping line %d: %v\n", lineNumber, err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			if err := streamer.Add(ctx, point); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	pollTicker := time.NewTicker(pollInterval)
+	defer pollTicker.Stop()
+	flushTicker := time.NewTicker(flushInterval)
+	defer flushTicker.Stop()
+
+	for {
+		if err := consume(); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			// Use a fresh context so the final flush is not cancelled
+			return streamer.Flush(context.Background())
+		case <-flushTicker.C:
+			if err := streamer.Flush(ctx); err != nil {
+				return err
+			}
+		case <-pollTicker.C:
+			// Drain the old file before switching to a rotated one
+			if err := consume(); err != nil {
+				return err
+			}
+			reset, err := follower.checkReset()
+			if err != nil {
+				return fmt.Errorf("failed to stat %s: %w", path, err)
+			}
+			if reset {
+				fmt.Fprintf(os.Stderr, "%s was truncated or rotated, reading from the beginning\n", path)
+				parse = newParser()
+				lineNumber = 0
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+var runFreezeCmd = &cobra.Command{
+	Use:   "freeze",
+	Short: "Protect a run from changes",
+	Long: `Freeze a run, e.g. a published benchmark, so that it is not changed by
+accident, such as by automation sharing its credentials.
+
+The run is tagged locked=true along with the hash of an unlock token. Commands
+refuse to log to, tag, end, delete or upload artifacts to a frozen run unless
+the token is given with --unlock-token (or MLFLOW_UNLOCK_TOKEN). Without
+--unlock-token a random token is generated and printed; it cannot be recovered.
+
+Freezing is enforced by this CLI only: the tracking server, its UI and other
+clients can still change the run.`,
+	RunE: runFreeze,
+}
+
+var runUnfreezeCmd = &cobra.Command{
+	Use:   "unfreeze",
+	Short: "Allow changes to a frozen run again",
+	Long: `Remove the lock of a run frozen with run freeze. The unlock token of the run
+must be given with --unlock-token (or MLFLOW_UNLOCK_TOKEN).`,
+	RunE: runUnfreeze,
+}
+
+func init() {
+	runCmd.AddCommand(runFreezeCmd)
+	runCmd.AddCommand(runUnfreezeCmd)
+
+	// Run freeze command flags
+	runFreezeCmd.Flags().String("run-id", "", "Run ID to freeze (required)")
+	runFreezeCmd.MarkFlagRequired("run-id")
+
+	// Run unfreeze command flags
+	runUnfreezeCmd.Flags().String("run-id", "", "Run ID to unfreeze (required)")
+	runUnfreezeCmd.MarkFlagRequired("run-id")
+
+	registerExamples(runFreezeCmd,
+		example{
+			Description: "Freeze a run with a generated unlock token",
+			Command:     "mlflow-cli run freeze --run-id <run-id>",
+		},
+		example{
+			Description: "Freeze a run with the token of a secret store",
+			Command:     `mlflow-cli run freeze --run-id <run-id> --unlock-token "$BENCHMARK_UNLOCK_TOKEN"`,
+		},
+	)
+	registerExamples(runUnfreezeCmd,
+		example{Command: `mlflow-cli run unfreeze --run-id <run-id> --unlock-token "$BENCHMARK_UNLOCK_TOKEN"`},
+	)
+}
+
+func runFreeze(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	runID, _ := cmd.Flags().GetString("run-id")
+	token := cfg.UnlockToken
+	generated := token == ""
+	if generated {
+		token = uuid.NewString()
+	}
+
+	ctx := context.Background()
+	if err := client.FreezeRun(ctx, runID, token); err != nil {
+		return fmt.Errorf("failed to freeze run: %w", err)
+	}
+
+	fmt.Printf("Run %s frozen\n", runID)
+	if generated {
+		fmt.Printf("Unlock token: %s\n", token)
+		fmt.Fprintln(os.Stderr, "Keep the unlock token: it is needed to change or unfreeze the run, and cannot be recovered")
+	}
+	return nil
+}
+
+func runUnfreeze(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	runID, _ := cmd.Flags().GetString("run-id")
+
+	ctx := context.Background()
+	if err := client.UnfreezeRun(ctx, runID); err != nil {
+		return fmt.Errorf("failed to unfreeze run: %w", err)
+	}
+
+	fmt.Printf("Run %s unfrozen\n", runID)
+	return nil
+}
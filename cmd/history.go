@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/output"
+	timeutils "github.com/imishinist/mlflow-cli/internal/time"
+)
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Metric query commands",
+	Long:  `Commands for reading metrics logged to MLflow runs.`,
+}
+
+var metricsHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Print the time series of a metric",
+	Long: `Fetch every value logged for one or more metrics of a run, ordered by step,
+and print them as CSV, JSON, JSON Lines or a text table.`,
+	RunE: metricsHistory,
+}
+
+var metricsAtCmd = &cobra.Command{
+	Use:   "at",
+	Short: "Print the value of a metric at a step or time",
+	Long: `Print the value a metric had at a specific step, or the value logged closest
+to a point in time. If a metric was logged several times at the same step, the
+latest value is used.`,
+	RunE: metricsAt,
+}
+
+var metricsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the metric histories of many runs",
+	Long: `Fetch the histories of metrics of several runs concurrently and print them as
+CSV, JSON, JSON Lines or a text table, ordered by run, metric and step.
+
+Runs are given with --run-id, or selected from an experiment with --filter.
+Without --key every metric logged to each run is exported. At most
+--parallelism requests are in flight at once, and all of them share the
+requests-per-second limit of --rate-limit (or the rate_limit config key).`,
+	RunE: metricsExport,
+}
+
+func init() {
+	rootCmd.AddCommand(metricsCmd)
+	metricsCmd.AddCommand(metricsHistoryCmd)
+
+	// Metrics history command flags
+	metricsHistoryCmd.Flags().String("run-id", "", "Run ID (required)")
+	metricsHistoryCmd.Flags().StringArray("key", []string{}, "Metric key (required, can be specified multiple times)")
+	metricsHistoryCmd.Flags().StringP("output", "o", output.FormatCSV, "Output format (csv/json/jsonl/table)")
+	metricsHistoryCmd.MarkFlagRequired("run-id")
+	metricsHistoryCmd.MarkFlagRequired("key")
+
+	// Metrics at command flags
+	metricsCmd.AddCommand(metricsAtCmd)
+	metricsAtCmd.Flags().String("run-id", "", "Run ID (required)")
+	metricsAtCmd.Flags().StringArray("metric", []string{}, "Metric key (required, can be specified multiple times)")
+	metricsAtCmd.Flags().Int64("step", 0, "Step to look up")
+	metricsAtCmd.Flags().String("at-time", "", "Timestamp to look up the closest value for (ISO8601)")
+	metricsAtCmd.Flags().StringP("output", "o", output.FormatCSV, "Output format (csv/json/jsonl/table)")
+	metricsAtCmd.MarkFlagRequired("run-id")
+	metricsAtCmd.MarkFlagRequired("metric")
+	metricsAtCmd.MarkFlagsMutuallyExclusive("step", "at-time")
+	metricsAtCmd.MarkFlagsOneRequired("step", "at-time")
+
+	// Metrics export command flags
+	metricsCmd.AddCommand(metricsExportCmd)
+	addExperimentFlags(metricsExportCmd)
+	metricsExportCmd.Flags().StringArray("run-id", []string{}, "Run ID (can be specified multiple times; default: runs matching --filter)")
+	metricsExportCmd.Flags().String("filter", "", "MLflow search filter selecting the runs of the experiment")
+	metricsExportCmd.Flags().StringArray("key", []string{}, "Metric key (can be specified multiple times; default: all metrics of each run)")
+	metricsExportCmd.Flags().Int("parallelism", 8, "Number of metric histories fetched concurrently")
+	metricsExportCmd.Flags().Int("rate-limit", 0, "Maximum API requests per second across all concurrent requests (default: rate_limit config or 15)")
+	metricsExportCmd.Flags().StringP("output", "o", output.FormatCSV, "Output format (csv/json/jsonl/table)")
+	metricsExportCmd.MarkFlagsMutuallyExclusive("run-id", "filter")
+
+	registerExamples(metricsHistoryCmd,
+		example{Command: "mlflow-cli metrics history --run-id <run-id> --key loss > loss.csv"},
+		example{Command: "mlflow-cli metrics history --run-id <run-id> --key loss --key val_loss --output json", Runnable: true},
+	)
+	registerExamples(metricsAtCmd,
+		example{
+			Description: "Loss at step 1000",
+			Command:     "mlflow-cli metrics at --run-id <run-id> --metric loss --step 1000",
+		},
+		example{
+			Description: "Accuracy closest to midnight UTC on May 1st",
+			Command:     "mlflow-cli metrics at --run-id <run-id> --metric accuracy --at-time 2024-05-01T00:00Z",
+		},
+	)
+	registerExamples(metricsExportCmd,
+		example{Command: "mlflow-cli metrics export --run-id <run-id> --run-id <run-id> --key loss > loss.csv"},
+		example{
+			Description: "Every metric of the tuning runs of an experiment",
+			Command: `mlflow-cli metrics export --experiment-id 1 --filter "tags.stage = 'tuning'" \
+  --parallelism 16 --rate-limit 30 --output jsonl`,
+		},
+	)
+}
+
+func metricsHistory(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	keys, _ := cmd.Flags().GetStringArray("key")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	table := output.NewTable("key", "step", "timestamp", "value")
+	for _, key := range keys {
+		history, err := client.GetMetricHistory(ctx, runID, key)
+		if err != nil {
+			return err
+		}
+		if len(history) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: no values logged for metric %s\n", key)
+		}
+		for _, metric := range history {
+			table.Append(metric.Key, metric.Step, metric.Timestamp, metric.Value)
+		}
+	}
+
+	return output.Write(os.Stdout, format, table)
+}
+
+func metricsAt(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	keys, _ := cmd.Flags().GetStringArray("metric")
+	step, _ := cmd.Flags().GetInt64("step")
+	atTime, _ := cmd.Flags().GetString("at-time")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+
+	var at time.Time
+	if atTime != "" {
+		if at, err = timeutils.ParseTime(atTime); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	table := output.NewTable("key", "step", "timestamp", "value")
+	for _, key := range keys {
+		history, err := client.GetMetricHistory(ctx, runID, key)
+		if err != nil {
+			return err
+		}
+
+		var metric *models.Metric
+		if atTime != "" {
+			metric = metricClosestTo(history, at)
+			if metric == nil {
+				return fmt.Errorf("no values logged for metric %s", key)
+			}
+		} else {
+			metric = metricAtStep(history, step)
+			if metric == nil {
+				return fmt.Errorf("metric %s has no value at step %d", key, step)
+			}
+		}
+		table.Append(metric.Key, metric.Step, metric.Timestamp, metric.Value)
+	}
+
+	return output.Write(os.Stdout, format, table)
+}
+
+func metricsExport(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+
+	// Parse flags
+	runIDs, _ := cmd.Flags().GetStringArray("run-id")
+	filter, _ := cmd.Flags().GetString("filter")
+	keys, _ := cmd.Flags().GetStringArray("key")
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+	if parallelism < 1 {
+		return fmt.Errorf("parallelism must be at least 1")
+	}
+	if cmd.Flags().Changed("rate-limit") {
+		cfg.RateLimit, _ = cmd.Flags().GetInt("rate-limit")
+	}
+
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Select the runs; their metric keys are only needed without --key
+	ctx := context.Background()
+	var runs []*models.RunInfo
+	if len(runIDs) == 0 {
+		experimentID, err := resolveExperimentID(ctx, cmd, client, cfg)
+		if err != nil {
+			return err
+		}
+		if runs, err = client.SearchRuns(ctx, []string{experimentID}, filter); err != nil {
+			return err
+		}
+		if len(runs) == 0 {
+			return fmt.Errorf("no runs match the filter")
+		}
+	} else if len(keys) == 0 {
+		if runs, err = client.GetRuns(ctx, runIDs, parallelism); err != nil {
+			return err
+		}
+	} else {
+		for _, runID := range runIDs {
+			runs = append(runs, &models.RunInfo{RunID: runID})
+		}
+	}
+
+	var requests []mlflow.MetricHistoryRequest
+	for _, run := range runs {
+		runKeys := keys
+		if len(runKeys) == 0 {
+			runKeys = metricKeys(run.Metrics)
+		}
+		for _, key := range runKeys {
+			requests = append(requests, mlflow.MetricHistoryRequest{RunID: run.RunID, Key: key})
+		}
+	}
+
+	progress := newProgressPrinter("Fetching metric histories")
+	results := client.GetMetricHistories(ctx, requests, parallelism, progress.Update)
+	progress.Done()
+
+	table := output.NewTable("run_id", "key", "step", "timestamp", "value")
+	failed := 0
+	for i, result := range results {
+		request := requests[i]
+		if result.Err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to fetch metric %s of run %s: %v\n", request.Key, request.RunID, result.Err)
+			failed++
+			continue
+		}
+		if len(result.Metrics) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: no values logged for metric %s of run %s\n", request.Key, request.RunID)
+		}
+		for _, metric := range result.Metrics {
+			table.Append(request.RunID, metric.Key, metric.Step, metric.Timestamp, metric.Value)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to fetch %d of %d metric histories", failed, len(requests))
+	}
+
+	return output.Write(os.Stdout, format, table)
+}
+
+// metricAtStep returns the latest value logged at a step of a history ordered
+// by step and timestamp, or nil if there is none
+func metricAtStep(history []models.Metric, step int64) *models.Metric {
+	var found *models.Metric
+	for i := range history {
+		if history[i].Step == step {
+			found = &history[i]
+		}
+	}
+	return found
+}
+
+// metricClosestTo returns the value logged closest to t; ties go to the earlier
+// value
+func metricClosestTo(history []models.Metric, t time.Time) *models.Metric {
+	var found *models.Metric
+	var best time.Duration
+	for i := range history {
+		diff := history[i].Timestamp.Sub(t).Abs()
+		if found == nil || diff < best || (diff == best && history[i].Timestamp.Before(found.Timestamp)) {
+			found = &history[i]
+			best = diff
+		}
+	}
+	return found
+}
+
+// metricKeys returns the sorted keys of the final metrics of a run
+func metricKeys(metrics map[string]float64) []string {
+	keys := make([]string, 0, len(metrics))
+	for key := range metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+var logImageCmd = &cobra.Command{
+	Use:   "image",
+	Short: "Log an image of a run under a key and step",
+	Long: `Log a PNG, JPEG or GIF image under a key and step like mlflow.log_image, so
+that the images of a key can be browsed by step and compared across runs in
+the MLflow UI.
+
+The image is stored as PNG in the images/ artifact directory, with the key,
+step and timestamp encoded in its file name, next to a thumbnail for the UI.`,
+	RunE: logImage,
+}
+
+func init() {
+	logCmd.AddCommand(logImageCmd)
+
+	// Image command flags
+	logImageCmd.Flags().String("run-id", "", "Run ID to log the image to (required)")
+	logImageCmd.Flags().String("file", "", "PNG, JPEG or GIF image file (required)")
+	logImageCmd.Flags().String("key", "", "Image key, e.g. accuracy_curve (required)")
+	logImageCmd.Flags().Int64("step", 0, "Step number")
+	logImageCmd.Flags().String("timestamp", "", "Timestamp in ISO8601 format (default: now)")
+	logImageCmd.MarkFlagRequired("run-id")
+	logImageCmd.MarkFlagRequired("file")
+	logImageCmd.MarkFlagRequired("key")
+
+	registerExamples(logImageCmd,
+		example{
+			Description: "Log the accuracy curve of step 10",
+			Command:     "mlflow-cli log image --run-id <run-id> --file plot.png --key accuracy_curve --step 10",
+		},
+	)
+}
+
+func logImage(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	file, _ := cmd.Flags().GetString("file")
+	key, _ := cmd.Flags().GetString("key")
+	step, _ := cmd.Flags().GetInt64("step")
+	timestampStr, _ := cmd.Flags().GetString("timestamp")
+
+	if step < 0 {
+		return fmt.Errorf("invalid step: %d (must not be negative)", step)
+	}
+	timestamp := time.Now()
+	if timestampStr != "" {
+		if timestamp, err = time.Parse(time.RFC3339, timestampStr); err != nil {
+			return fmt.Errorf("invalid timestamp format: %s (expected ISO8601)", timestampStr)
+		}
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read image: %w", err)
+	}
+
+	artifactPath, err := client.LogImage(context.Background(), runID, key, step, timestamp, data)
+	if err != nil {
+		return fmt.Errorf("failed to log image %s: %w", file, err)
+	}
+
+	fmt.Printf("Successfully logged image %s (step %d) as %s\n", key, step, artifactPath)
+	return nil
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/index"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Local run index commands",
+	Long: `Commands for the local SQLite index of runs, which "run search --local" queries
+instantly and offline.`,
+}
+
+var indexSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Mirror the runs of an experiment into the local index",
+	Long: `Fetch the metadata, params, tags and final metrics of all active runs of an
+experiment and replace its runs in the local index. Runs deleted on the server
+are removed from the index.`,
+	RunE: indexSync,
+}
+
+func init() {
+	rootCmd.AddCommand(indexCmd)
+	indexCmd.AddCommand(indexSyncCmd)
+
+	// Index sync command flags
+	addExperimentFlags(indexSyncCmd)
+	addIndexFlag(indexSyncCmd)
+
+	registerExamples(indexSyncCmd,
+		example{Command: "mlflow-cli index sync --experiment-id 1"},
+		example{Command: `mlflow-cli run search --local --experiment-id 1 --filter "metrics.rmse < 0.5"`},
+	)
+}
+
+// addIndexFlag registers the flag selecting the index database
+func addIndexFlag(cmd *cobra.Command) {
+	cmd.Flags().String("index", "", "Local index database (default: index_path config or the user cache directory)")
+}
+
+// openIndex opens the index selected by the --index flag or the config
+func openIndex(cmd *cobra.Command, cfg *config.Config) (*index.Index, string, error) {
+	path, _ := cmd.Flags().GetString("index")
+	if path == "" {
+		path = cfg.IndexPath
+	}
+	if path == "" {
+		defaultPath, err := index.DefaultPath()
+		if err != nil {
+			return nil, "", err
+		}
+		path = defaultPath
+	}
+
+	ix, err := index.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return ix, path, nil
+}
+
+func indexSync(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	ctx := context.Background()
+	experimentID, err := resolveExperimentID(ctx, cmd, client, cfg)
+	if err != nil {
+		return err
+	}
+	experiment, err := client.GetExperiment(ctx, experimentID)
+	if err != nil {
+		return err
+	}
+
+	runs, err := client.SearchRuns(ctx, []string{experimentID}, "")
+	if err != nil {
+		return err
+	}
+
+	ix, path, err := openIndex(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	defer ix.Close()
+
+	if err := ix.Sync(ctx, cfg.TrackingURI, experiment, runs); err != nil {
+		return fmt.Errorf("failed to sync index: %w", err)
+	}
+
+	fmt.Printf("Successfully indexed %d runs of experiment %s (%s) in %s\n", len(runs), experiment.Name, experimentID, path)
+	return nil
+}
+
+// resolveIndexedExperiment returns the synced experiment selected by flags,
+// falling back to the configured experiment ID
+func resolveIndexedExperiment(ctx context.Context, cmd *cobra.Command, cfg *config.Config, ix *index.Index) (index.SyncedExperiment, error) {
+	experiment, _ := cmd.Flags().GetString("experiment-name")
+	byName := experiment != ""
+	if !byName {
+		experiment, _ = cmd.Flags().GetString("experiment-id")
+		if experiment == "" {
+			experiment = cfg.ExperimentID
+		}
+	}
+	if experiment == "" {
+		return index.SyncedExperiment{}, fmt.Errorf("experiment must be specified via --experiment-id, --experiment-name or MLFLOW_EXPERIMENT_ID")
+	}
+
+	synced, ok, err := ix.Experiment(ctx, cfg.TrackingURI, experiment, byName)
+	if err != nil {
+		return index.SyncedExperiment{}, err
+	}
+	if !ok {
+		return index.SyncedExperiment{}, fmt.Errorf("experiment %s of %s is not in the local index; run \"mlflow-cli index sync\" first", experiment, cfg.TrackingURI)
+	}
+	fmt.Fprintf(os.Stderr, "Using local index of experiment %s synced at %s\n", synced.ExperimentID, synced.SyncedAt.Format("2006-01-02 15:04:05"))
+	return synced, nil
+}
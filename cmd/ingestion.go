@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// ingestionTagPrefix prefixes the run tags recording the content hash of each
+// ingestion ID
+const ingestionTagPrefix = "ingestion."
+
+// ingestionPendingMarker separates the content hash from the claim ID in the
+// tag of an ingestion that is being logged
+const ingestionPendingMarker = " pending "
+
+// ingestionClaimDelay is how long a claim is left to settle before it is read
+// back, so that a concurrent invocation claiming the same ID at about the
+// same time has overwritten it by then
+const ingestionClaimDelay = time.Second
+
+// ingestionIDPattern restricts ingestion IDs to characters allowed in tag keys
+var ingestionIDPattern = regexp.MustCompile(`^[A-Za-z0-9_.\-/]+$`)
+
+// ingestionTagKey returns the run tag key of an ingestion ID
+func ingestionTagKey(ingestionID string) (string, error) {
+	if !ingestionIDPattern.MatchString(ingestionID) {
+		return "", fmt.Errorf("invalid ingestion ID: %q (allowed: letters, digits, _ . - /)", ingestionID)
+	}
+	return ingestionTagPrefix + ingestionID, nil
+}
+
+// ingestionHasher hashes the metric points of an ingestion as read, before
+// timestamps missing from the input are filled in with the current time, so
+// that the same input hashes the same on every invocation. The options that
+// turn the points into metrics are hashed as well.
+type ingestionHasher struct {
+	h hash.Hash
+}
+
+func newIngestionHasher(timeConfig models.TimeConfig, baseTime *time.Time) *ingestionHasher {
+	h := sha256.New()
+	location := "UTC"
+	if timeConfig.Location != nil {
+		location = timeConfig.Location.String()
+	}
+	base := "-"
+	if baseTime != nil {
+		base = strconv.FormatInt(baseTime.UnixNano(), 10)
+	}
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%d\x00%d\x00%s\x00%s\n", timeConfig.Resolution, timeConfig.Alignment,
+		timeConfig.StepMode, timeConfig.Aggregate, timeConfig.StepOffset, timeConfig.StepStride, location, base)
+	return &ingestionHasher{h: h}
+}
+
+// Add hashes a data point with its timestamp or offset, step and values
+func (i *ingestionHasher) Add(point models.MetricPoint) {
+	timestamp, offset, step := "-", "-", "-"
+	if point.Timestamp != nil {
+		timestamp = strconv.FormatInt(point.Timestamp.UnixNano(), 10)
+	}
+	if point.Offset != nil {
+		offset = strconv.FormatInt(int64(*point.Offset), 10)
+	}
+	if point.Step != nil {
+		step = strconv.FormatInt(*point.Step, 10)
+	}
+	keys := make([]string, 0, len(point.Values))
+	for key := range point.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(i.h, "%s\x00%s\x00%t\x00%s", timestamp, offset, point.Naive, step)
+	for _, key := range keys {
+		fmt.Fprintf(i.h, "\x00%s\x00%s", key, strconv.FormatFloat(point.Values[key], 'g', -1, 64))
+	}
+	i.h.Write([]byte{'\n'})
+}
+
+// Sum returns the content hash of the points added
+func (i *ingestionHasher) Sum() string {
+	return "sha256:" + hex.EncodeToString(i.h.Sum(nil))
+}
+
+// claimIngestion claims an ingestion ID of a run for metrics with the content
+// hash before they are logged, by recording the hash as pending under a new
+// claim ID. Invocations that start at the same time both claim the ID, and
+// the one whose claim was overwritten backs off. MLflow has no conditional
+// updates, so invocations whose claims are further apart than the settle
+// delay but overlap otherwise are caught by the check of the recorded tag
+// only.
+//
+// It returns the claim, or an empty claim with a reason if the metrics were
+// already logged or are being logged by another invocation. Reusing an
+// ingestion ID for different content is an error.
+func claimIngestion(ctx context.Context, client *mlflow.Client, runID, ingestionID, hash string) (claim, reason string, err error) {
+	key := ingestionTagPrefix + ingestionID
+	reason, err = ingestionState(ctx, client, runID, ingestionID, hash)
+	if err != nil || reason != "" {
+		return "", reason, err
+	}
+
+	claim = hash + ingestionPendingMarker + uuid.NewString()
+	if err := client.SetTag(ctx, runID, key, claim); err != nil {
+		return "", "", fmt.Errorf("failed to claim ingestion %s: %w", ingestionID, err)
+	}
+	// Dry runs do not record the claim
+	if viper.GetBool("dry_run") {
+		return claim, "", nil
+	}
+	time.Sleep(ingestionClaimDelay)
+
+	run, err := client.GetRun(ctx, runID)
+	if err != nil {
+		return "", "", err
+	}
+	if recorded := run.Tags[key]; recorded != claim {
+		reason, err = ingestionState(ctx, client, runID, ingestionID, hash)
+		if err == nil && reason == "" {
+			err = fmt.Errorf("claim of ingestion %s was removed by another invocation", ingestionID)
+		}
+		return "", reason, err
+	}
+	return claim, "", nil
+}
+
+// ingestionState returns why metrics with the content hash need not be
+// logged under an ingestion ID, or an empty reason if the ID is unused
+func ingestionState(ctx context.Context, client *mlflow.Client, runID, ingestionID, hash string) (string, error) {
+	run, err := client.GetRun(ctx, runID)
+	if err != nil {
+		return "", err
+	}
+
+	recorded, exists := run.Tags[ingestionTagPrefix+ingestionID]
+	recordedHash, _, pending := strings.Cut(recorded, ingestionPendingMarker)
+	switch {
+	case !exists:
+		return "", nil
+	case recordedHash != hash:
+		return "", fmt.Errorf("ingestion ID %s was already used for different metrics (recorded %s, now %s)", ingestionID, recordedHash, hash)
+	case pending:
+		return fmt.Sprintf("are being logged by another invocation, or one that stopped without releasing the ID (delete the run tag %s%s to retry)", ingestionTagPrefix, ingestionID), nil
+	}
+	return "were already logged", nil
+}
+
+// releaseIngestion removes the claim of an ingestion whose metrics could not
+// be logged, so that it can be retried
+func releaseIngestion(ctx context.Context, client *mlflow.Client, runID, ingestionID string) {
+	if err := client.DeleteTag(ctx, runID, ingestionTagPrefix+ingestionID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to release ingestion %s; delete the run tag %s%s to retry: %v\n",
+			ingestionID, ingestionTagPrefix, ingestionID, err)
+	}
+}
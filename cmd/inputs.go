@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+var logInputsCmd = &cobra.Command{
+	Use:   "inputs",
+	Short: "Log a dataset as an input of a run",
+	Long: `Record a dataset used by a run, with its name, digest, source and schema, so
+that it appears in the dataset lineage of the MLflow UI like datasets logged
+with mlflow.log_input.
+
+--source is the URI or local path the dataset was read from. Its source type is
+derived from the URI scheme (s3, gs, http, local, ...) unless --source-type is
+given. The schema is an MLflow schema JSON given with --schema or
+--schema-file, or built from --column name:type specs (types: boolean, integer,
+long, float, double, string, binary, datetime). --dataset-profile takes summary
+statistics as JSON, e.g. {"num_rows": 1000}.`,
+	RunE: logInputs,
+}
+
+// Column types of MLflow column-based schemas
+var mlflowColumnTypes = []string{"boolean", "integer", "long", "float", "double", "string", "binary", "datetime"}
+
+func init() {
+	logCmd.AddCommand(logInputsCmd)
+
+	// Inputs command flags
+	logInputsCmd.Flags().String("run-id", "", "Run ID to log the dataset to (required)")
+	logInputsCmd.Flags().String("name", "", "Dataset name (required)")
+	logInputsCmd.Flags().String("digest", "", "Dataset digest identifying its version (required)")
+	logInputsCmd.Flags().String("source", "", "URI or local path the dataset was read from (required)")
+	logInputsCmd.Flags().String("source-type", "", "Dataset source type (default: derived from --source)")
+	logInputsCmd.Flags().String("schema", "", "Dataset schema as MLflow schema JSON")
+	logInputsCmd.Flags().String("schema-file", "", "File containing the dataset schema as MLflow schema JSON")
+	logInputsCmd.Flags().StringArray("column", []string{}, "Schema column in name:type format (can be specified multiple times)")
+	logInputsCmd.Flags().String("dataset-profile", "", "Dataset profile (summary statistics) as JSON")
+	logInputsCmd.Flags().String("context", "", "How the run used the dataset, e.g. training or evaluation")
+	logInputsCmd.Flags().StringArray("tag", []string{}, "Input tags in key=value format")
+	logInputsCmd.MarkFlagRequired("run-id")
+	logInputsCmd.MarkFlagRequired("name")
+	logInputsCmd.MarkFlagRequired("digest")
+	logInputsCmd.MarkFlagRequired("source")
+	logInputsCmd.MarkFlagsMutuallyExclusive("schema", "schema-file", "column")
+
+	registerExamples(logInputsCmd,
+		example{
+			Command: `mlflow-cli log inputs --run-id <run-id> --name nyc-taxi --digest 2f1e4c7a \
+  --source s3://datasets/nyc-taxi/2024-01.parquet --context training \
+  --column fare:double --column passengers:long --dataset-profile '{"num_rows": 2964624}'`,
+			Runnable: true,
+		},
+	)
+}
+
+func logInputs(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	name, _ := cmd.Flags().GetString("name")
+	digest, _ := cmd.Flags().GetString("digest")
+	source, _ := cmd.Flags().GetString("source")
+	sourceType, _ := cmd.Flags().GetString("source-type")
+	schema, _ := cmd.Flags().GetString("schema")
+	schemaFile, _ := cmd.Flags().GetString("schema-file")
+	columns, _ := cmd.Flags().GetStringArray("column")
+	profile, _ := cmd.Flags().GetString("dataset-profile")
+	datasetContext, _ := cmd.Flags().GetString("context")
+	tags, _ := cmd.Flags().GetStringArray("tag")
+
+	tagMap, err := parseTags(tags)
+	if err != nil {
+		return err
+	}
+	if datasetContext != "" {
+		tagMap[mlflow.TagDatasetContext] = datasetContext
+	}
+
+	dataset := models.Dataset{Name: name, Digest: digest, Profile: profile}
+	dataset.SourceType, dataset.Source, err = datasetSource(source, sourceType)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case schemaFile != "":
+		data, err := os.ReadFile(schemaFile)
+		if err != nil {
+			return fmt.Errorf("failed to read schema file: %w", err)
+		}
+		dataset.Schema = strings.TrimSpace(string(data))
+	case len(columns) > 0:
+		if dataset.Schema, err = columnSchema(columns); err != nil {
+			return err
+		}
+	default:
+		dataset.Schema = schema
+	}
+	if dataset.Schema != "" && !json.Valid([]byte(dataset.Schema)) {
+		return fmt.Errorf("invalid schema: not valid JSON")
+	}
+	if profile != "" && !json.Valid([]byte(profile)) {
+		return fmt.Errorf("invalid --dataset-profile: not valid JSON")
+	}
+
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	if err := client.LogDatasetInput(context.Background(), runID, dataset, tagMap); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully logged dataset %s (digest %s) as input of run %s\n", name, digest, runID)
+	return nil
+}
+
+// datasetSource returns the source type and the source JSON of a dataset read
+// from a URI or local path, like the artifact dataset sources of MLflow. Local
+// paths are made absolute.
+func datasetSource(source, sourceType string) (string, string, error) {
+	uri := source
+	parsed, err := url.Parse(source)
+	scheme := ""
+	if err == nil && len(parsed.Scheme) > 1 {
+		// Single letter schemes are Windows drive letters
+		scheme = strings.ToLower(parsed.Scheme)
+	}
+
+	switch scheme {
+	case "", "file":
+		if sourceType == "" {
+			sourceType = "local"
+		}
+		if scheme == "" {
+			if uri, err = filepath.Abs(source); err != nil {
+				return "", "", err
+			}
+		}
+	case "http", "https":
+		if sourceType == "" {
+			sourceType = "http"
+		}
+	default:
+		if sourceType == "" {
+			sourceType = scheme
+		}
+	}
+
+	data, err := json.Marshal(map[string]string{"uri": uri})
+	if err != nil {
+		return "", "", err
+	}
+	return sourceType, string(data), nil
+}
+
+// columnSchema returns the MLflow column-based schema JSON of name:type specs
+func columnSchema(specs []string) (string, error) {
+	type colSpec struct {
+		Type     string `json:"type"`
+		Name     string `json:"name"`
+		Required bool   `json:"required"`
+	}
+
+	columns := make([]colSpec, 0, len(specs))
+	for _, spec := range specs {
+		name, columnType, ok := strings.Cut(spec, ":")
+		if !ok || name == "" {
+			return "", fmt.Errorf("invalid column: %s (expected name:type)", spec)
+		}
+		valid := false
+		for _, t := range mlflowColumnTypes {
+			valid = valid || columnType == t
+		}
+		if !valid {
+			return "", fmt.Errorf("invalid type of column %s: %s (expected one of %s)", name, columnType, strings.Join(mlflowColumnTypes, ", "))
+		}
+		columns = append(columns, colSpec{Type: columnType, Name: name, Required: true})
+	}
+
+	data, err := json.Marshal(map[string][]colSpec{"mlflow_colspec": columns})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
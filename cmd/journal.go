@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/parser"
+)
+
+// journalFilterOptions maps --from-journal keys to journalctl options
+var journalFilterOptions = map[string]string{
+	"unit":       "--unit=",
+	"user-unit":  "--user-unit=",
+	"identifier": "--identifier=",
+	"since":      "--since=",
+	"until":      "--until=",
+	"boot":       "--boot=",
+}
+
+// journalctlArgs converts a --from-journal filter such as
+// 'unit=trainer.service,since=today' into journalctl arguments. Upper-case
+// keys are passed as journal field matches (_PID=1234).
+func journalctlArgs(filter string) ([]string, error) {
+	args := []string{"--output=json", "--no-pager", "--quiet"}
+	for _, part := range strings.Split(filter, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, found := strings.Cut(part, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid journal filter: %s (expected key=value)", part)
+		}
+
+		if option, ok := journalFilterOptions[key]; ok {
+			args = append(args, option+value)
+		} else if key == strings.ToUpper(key) {
+			args = append(args, part)
+		} else {
+			return nil, fmt.Errorf("unknown journal filter key: %s (valid: unit, user-unit, identifier, since, until, boot, or a journal field such as _PID)", key)
+		}
+	}
+	return args, nil
+}
+
+// readJournalMetrics passes the metric points of the systemd journal to fn,
+// reading the output of journalctl as it is written
+func readJournalMetrics(filter string, extractor *parser.LineExtractor, fn func(models.MetricPoint) error) error {
+	args, err := journalctlArgs(filter)
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	journalctl := exec.Command("journalctl", args...)
+	journalctl.Stderr = &stderr
+	stdout, err := journalctl.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to read journal: %w", err)
+	}
+	if err := journalctl.Start(); err != nil {
+		return fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	readErr := parser.ReadJournalMetrics(stdout, extractor, fn)
+	if readErr != nil {
+		// journalctl must not block on a pipe that is no longer read
+		journalctl.Process.Kill()
+	}
+	waitErr := journalctl.Wait()
+	if readErr != nil {
+		return readErr
+	}
+	if waitErr != nil {
+		return fmt.Errorf("failed to read journal: %w: %s", waitErr, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// readSyslogMetrics passes the metric points of a syslog file to fn
+func readSyslogMetrics(path string, extractor *parser.LineExtractor, loc *time.Location, fn func(models.MetricPoint) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if err := parser.ReadSyslogMetrics(file, extractor, loc, time.Now(), fn); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
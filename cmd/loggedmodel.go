@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/output"
+)
+
+var modelLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Log a model to an experiment",
+	Long: `Log a model from local files. On MLflow 3 tracking servers, a logged model is
+created in the experiment, its files are uploaded to the model's artifact
+location, and the model is finalized as ready (or marked failed if an upload
+fails). The model ID is printed to stdout.
+
+Older servers have no logged models: the files are uploaded below <name>/ of the
+--source-run-id run and recorded in the run's model history, like models logged
+by MLflow 2 clients, and the model URI runs:/<run-id>/<name> is printed instead.
+Model params, tags and types require MLflow 3.`,
+	RunE: modelLog,
+}
+
+var modelGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show a logged model",
+	Long: `Print a logged model with its params, tags and metrics as JSON. Models logged as
+run artifacts are selected by their runs:/<run-id>/<path> URI.`,
+	RunE: modelGet,
+}
+
+var modelSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search the logged models of an experiment",
+	Long: `List the logged models of an experiment matching --filter, in the logged model
+search syntax of MLflow 3 (e.g. "metrics.accuracy > 0.9 AND name = 'classifier'").
+
+On older servers, the models logged as artifacts of the runs matching --filter
+(in run search syntax) are listed instead.
+
+--columns selects the printed columns: model attributes (model_id,
+experiment_id, name, model_type, source_run_id, status, artifact_uri,
+created_at, updated_at) or params.<key>, metrics.<key> and tags.<key>.
+--sort-by orders the models by any such column.`,
+	RunE: modelSearch,
+}
+
+var modelSetTagCmd = &cobra.Command{
+	Use:   "set-tag",
+	Short: "Set tags on a logged model",
+	Long:  "Set or overwrite tags on a logged model (MLflow 3)",
+	RunE:  modelSetTag,
+}
+
+// loggedModelAttributeColumns are the logged model attributes selectable as
+// model search columns
+var loggedModelAttributeColumns = []string{"model_id", "experiment_id", "name", "model_type", "source_run_id", "status", "artifact_uri", "created_at", "updated_at"}
+
+// Default columns of model search
+var loggedModelColumns = []string{"model_id", "name", "status", "source_run_id", "created_at"}
+
+func init() {
+	modelCmd.AddCommand(modelLogCmd)
+	modelCmd.AddCommand(modelGetCmd)
+	modelCmd.AddCommand(modelSearchCmd)
+	modelCmd.AddCommand(modelSetTagCmd)
+
+	// Model log command flags
+	addExperimentFlags(modelLogCmd)
+	modelLogCmd.Flags().String("name", "", "Model name (required)")
+	modelLogCmd.Flags().String("source-run-id", "", "Run that produced the model (required on servers before MLflow 3)")
+	modelLogCmd.Flags().String("model-type", "", "Model type, e.g. the framework")
+	modelLogCmd.Flags().StringSlice("file", []string{}, "Model file path or glob pattern (can be specified multiple times)")
+	modelLogCmd.Flags().StringSlice("dir", []string{}, "Model directory to upload recursively (can be specified multiple times)")
+	modelLogCmd.Flags().StringSlice("exclude", []string{}, "Glob pattern of files to leave out of --dir and glob uploads (can be specified multiple times)")
+	modelLogCmd.Flags().StringArray("param", []string{}, "Model params in key=value format")
+	modelLogCmd.Flags().StringArray("tag", []string{}, "Model tags in key=value format")
+	modelLogCmd.Flags().Int("parallelism", 4, "Number of files uploaded concurrently")
+	modelLogCmd.MarkFlagRequired("name")
+	modelLogCmd.MarkFlagsOneRequired("file", "dir")
+
+	// Model get command flags
+	modelGetCmd.Flags().String("model-id", "", "Logged model ID or runs:/<run-id>/<path> URI (required)")
+	modelGetCmd.MarkFlagRequired("model-id")
+
+	// Model search command flags
+	addExperimentFlags(modelSearchCmd)
+	modelSearchCmd.Flags().String("filter", "", "Logged model search filter expression")
+	modelSearchCmd.Flags().Int("limit", 1000, "Maximum number of models listed (0: no limit)")
+	modelSearchCmd.Flags().StringP("output", "o", output.FormatTable, "Output format (csv/json/jsonl/table)")
+	addColumnsFlag(modelSearchCmd, loggedModelColumns)
+	addSortByFlag(modelSearchCmd)
+	addAbsoluteTimesFlag(modelSearchCmd)
+
+	// Model set-tag command flags
+	modelSetTagCmd.Flags().String("model-id", "", "Logged model ID (required)")
+	modelSetTagCmd.Flags().StringArray("tag", []string{}, "Tags in key=value format")
+	addTagsFileFlag(modelSetTagCmd)
+	modelSetTagCmd.MarkFlagRequired("model-id")
+
+	registerExamples(modelLogCmd,
+		example{Command: `mlflow-cli model log --name classifier --dir ./model --source-run-id <run-id> \
+  --model-type sklearn --param alpha=0.5 --tag team=fraud`},
+		example{
+			Description: "Link a metric to the model",
+			Command: `MODEL_ID=$(mlflow-cli model log --name classifier --dir ./model --source-run-id <run-id>)
+mlflow-cli log metric --run-id <run-id> --model-id "$MODEL_ID" --name accuracy --value 0.93`,
+		},
+	)
+	registerExamples(modelGetCmd,
+		example{Command: "mlflow-cli model get --model-id m-0123456789abcdef"},
+		example{Command: "mlflow-cli model get --model-id runs:/<run-id>/classifier"},
+	)
+	registerExamples(modelSearchCmd,
+		example{Command: `mlflow-cli model search --experiment-id 1 --filter "metrics.accuracy > 0.9"`},
+		example{Command: "mlflow-cli model search --columns model_id,name,metrics.accuracy --sort-by metrics.accuracy:desc"},
+	)
+	registerExamples(modelSetTagCmd,
+		example{Command: "mlflow-cli model set-tag --model-id m-0123456789abcdef --tag stage=staging"},
+		example{Command: "mlflow-cli model set-tag --model-id m-0123456789abcdef --tags-file tags.yaml"},
+	)
+}
+
+func modelLog(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	name, _ := cmd.Flags().GetString("name")
+	sourceRunID, _ := cmd.Flags().GetString("source-run-id")
+	modelType, _ := cmd.Flags().GetString("model-type")
+	files, _ := cmd.Flags().GetStringSlice("file")
+	dirs, _ := cmd.Flags().GetStringSlice("dir")
+	excludes, _ := cmd.Flags().GetStringSlice("exclude")
+	params, _ := cmd.Flags().GetStringArray("param")
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+
+	if parallelism < 1 {
+		return fmt.Errorf("--parallelism must be at least 1")
+	}
+	paramMap := make(map[string]string)
+	for _, param := range params {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return fmt.Errorf("invalid parameter format: %s (expected key=value)", param)
+		}
+		paramMap[key] = value
+	}
+	tagMap, err := parseTags(tags)
+	if err != nil {
+		return err
+	}
+
+	uploads, err := collectArtifactUploads(files, dirs, excludes, "")
+	if err != nil {
+		return err
+	}
+	if len(uploads) == 0 {
+		return fmt.Errorf("no model files to upload")
+	}
+
+	ctx := context.Background()
+	opts := mlflow.LoggedModelOptions{
+		Name:        name,
+		ModelType:   modelType,
+		SourceRunID: sourceRunID,
+		Params:      paramMap,
+		Tags:        tagMap,
+	}
+	// The experiment of the source run is used unless one is selected
+	experimentSelected := cmd.Flags().Changed("experiment-id") || cmd.Flags().Changed("experiment-name")
+	if sourceRunID != "" && !experimentSelected {
+		run, err := client.GetRun(ctx, sourceRunID)
+		if err != nil {
+			return err
+		}
+		opts.ExperimentID = run.ExperimentID
+	} else if opts.ExperimentID, err = resolveExperimentID(ctx, cmd, client, cfg); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Logging model %s (%d files)...\n", name, len(uploads))
+	model, err := client.LogModel(ctx, opts, uploads, parallelism)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Logged model %s to experiment %s (%s)\n", model.Name, model.ExperimentID, model.Status)
+	// Output only the model ID for shell scripting
+	fmt.Println(model.ModelID)
+	return nil
+}
+
+func modelGet(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	modelID, _ := cmd.Flags().GetString("model-id")
+
+	model, err := client.GetLoggedModel(context.Background(), modelID)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(displayLoggedModel(model))
+}
+
+func modelSearch(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	filter, _ := cmd.Flags().GetString("filter")
+	limit, _ := cmd.Flags().GetInt("limit")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+	if limit < 0 {
+		return fmt.Errorf("--limit must be >= 0")
+	}
+	layout, err := getTableLayout(cmd, loggedModelColumns, func(column string) error {
+		return validateColumn(column, loggedModelAttributeColumns)
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	experimentID, err := resolveExperimentID(ctx, cmd, client, cfg)
+	if err != nil {
+		return err
+	}
+
+	loggedModels, err := client.SearchLoggedModels(ctx, []string{experimentID}, filter, limit)
+	if err != nil {
+		return err
+	}
+
+	columns := layout.sourceColumns()
+	table := output.NewTable(columns...)
+	for _, model := range loggedModels {
+		row := make([]any, len(columns))
+		for i, column := range columns {
+			row[i] = loggedModelColumnValue(model, column)
+		}
+		table.Append(row...)
+	}
+	table = layout.apply(table)
+	humanizeTimes(cmd, format, table)
+	return output.Write(os.Stdout, format, table)
+}
+
+func modelSetTag(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	modelID, _ := cmd.Flags().GetString("model-id")
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	tagsFile, _ := cmd.Flags().GetString("tags-file")
+
+	if len(tags) == 0 && tagsFile == "" {
+		return fmt.Errorf("either --tag or --tags-file must be specified")
+	}
+
+	tagMap, err := parseTags(tags)
+	if err != nil {
+		return err
+	}
+	if err := mergeTagsFile(tagMap, tagsFile); err != nil {
+		return err
+	}
+
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	if err := client.SetLoggedModelTags(context.Background(), modelID, tagMap); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully set %d tags on model %s\n", len(tagMap), modelID)
+	return nil
+}
+
+// loggedModelColumnValue returns the value of a column for a logged model, or
+// nil if the model has no such param, metric or tag
+func loggedModelColumnValue(model *models.LoggedModel, column string) any {
+	if key, ok := strings.CutPrefix(column, "params."); ok {
+		if value, exists := model.Params[key]; exists {
+			return value
+		}
+		return nil
+	}
+	if key, ok := strings.CutPrefix(column, "metrics."); ok {
+		if value, exists := model.Metrics[key]; exists {
+			return value
+		}
+		return nil
+	}
+	if key, ok := strings.CutPrefix(column, "tags."); ok {
+		if value, exists := model.Tags[key]; exists {
+			return value
+		}
+		return nil
+	}
+
+	switch column {
+	case "model_id":
+		return model.ModelID
+	case "experiment_id":
+		return model.ExperimentID
+	case "name":
+		return model.Name
+	case "model_type":
+		return model.ModelType
+	case "source_run_id":
+		return model.SourceRunID
+	case "status":
+		return model.Status
+	case "artifact_uri":
+		return model.ArtifactURI
+	case "created_at":
+		return model.CreatedAt
+	case "updated_at":
+		return model.UpdatedAt
+	}
+	return nil
+}
+
+// linkableModelID returns the model ID metrics are linked to. Only logged
+// models of MLflow 3 servers can be linked; metrics are logged to the run
+// alone otherwise.
+func linkableModelID(ctx context.Context, client *mlflow.Client, modelID string) (string, error) {
+	capabilities, err := client.Capabilities(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !capabilities.LoggedModels || strings.HasPrefix(modelID, "runs:/") {
+		fmt.Fprintf(os.Stderr, "Warning: metrics can only be linked to logged models of MLflow 3 servers; logging to the run only\n")
+		return "", nil
+	}
+	return modelID, nil
+}
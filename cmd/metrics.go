@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -13,8 +15,11 @@ import (
 	"github.com/imishinist/mlflow-cli/internal/config"
 	"github.com/imishinist/mlflow-cli/internal/mlflow"
 	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/output"
 	"github.com/imishinist/mlflow-cli/internal/parser"
+	"github.com/imishinist/mlflow-cli/internal/spool"
 	timeutils "github.com/imishinist/mlflow-cli/internal/time"
+	"github.com/imishinist/mlflow-cli/internal/units"
 )
 
 var logMetricCmd = &cobra.Command{
@@ -27,8 +32,15 @@ var logMetricCmd = &cobra.Command{
 var logMetricsCmd = &cobra.Command{
 	Use:   "metrics",
 	Short: "Log multiple metrics to MLflow run",
-	Long:  "Log multiple metrics from file to an existing MLflow run",
-	RunE:  logMetrics,
+	Long: `Log multiple metrics from a file, stdin, the systemd journal or a syslog
+file to an existing MLflow run.
+
+With --from-journal or --from-syslog, metric values are extracted from log
+messages with --pattern regular expressions whose named groups become metric
+keys (the groups step and timestamp set the step and timestamp). Without a
+pattern, messages that are JSON objects are read as metric records. Points are
+stamped with the time of their log entry.`,
+	RunE: logMetrics,
 }
 
 func init() {
@@ -41,6 +53,7 @@ func init() {
 	logMetricCmd.Flags().Float64("value", 0, "Metric value (required)")
 	logMetricCmd.Flags().Int64("step", -1, "Step number (optional)")
 	logMetricCmd.Flags().String("timestamp", "", "Timestamp in ISO8601 format (optional)")
+	logMetricCmd.Flags().String("model-id", "", "Logged model the metric is linked to (MLflow 3)")
 	logMetricCmd.MarkFlagRequired("run-id")
 	logMetricCmd.MarkFlagRequired("name")
 	logMetricCmd.MarkFlagRequired("value")
@@ -48,11 +61,45 @@ func init() {
 	// Multiple metrics command flags
 	logMetricsCmd.Flags().String("run-id", "", "Run ID to log metrics to (required)")
 	logMetricsCmd.Flags().String("from-file", "", "Load metrics from file (JSON/YAML/CSV)")
-	logMetricsCmd.Flags().String("time-resolution", "", "Time resolution (1m/5m/1h)")
+	logMetricsCmd.Flags().String("from-journal", "", "Read metrics from the systemd journal, filtered by key=value pairs such as 'unit=trainer.service,since=today'")
+	logMetricsCmd.Flags().String("from-syslog", "", "Read metrics from a syslog file")
+	logMetricsCmd.Flags().StringArray("pattern", []string{}, "Regular expression extracting metrics from journal/syslog messages with named groups (can be specified multiple times)")
+	logMetricsCmd.Flags().StringArray("extract", []string{}, "Read --from-file as a free-form log, extracting metrics with this regular expression's named groups (can be specified multiple times)")
+	logMetricsCmd.Flags().String("time-resolution", "", "Time resolution (duration such as 10s/5m/1h/1d, or none)")
 	logMetricsCmd.Flags().String("time-alignment", "", "Time alignment (floor/ceil/round)")
 	logMetricsCmd.Flags().String("step-mode", "", "Step mode (auto/timestamp/sequence)")
+	logMetricsCmd.Flags().String("aggregate", "", "Collapse data points in the same time bucket into one (none/mean/max/min/last)")
+	logMetricsCmd.Flags().String("timezone", "", "Time zone of timestamps without a zone and of bucket boundaries, e.g. Asia/Tokyo (default: UTC)")
+	logMetricsCmd.Flags().String("base-time", "", "Base time for relative timestamps (+30s) and timestamp-derived steps (ISO8601 or Unix epoch; default: first timestamp)")
+	logMetricsCmd.Flags().Int64("step-offset", 0, "Added to every step, e.g. to continue the numbering of a previous training phase")
+	logMetricsCmd.Flags().Int64("step-stride", 1, "Every step is multiplied by this before the offset is added")
+	logMetricsCmd.Flags().StringArray("map", []string{}, "Rename metric fields in field=key format (empty key drops the field)")
+	logMetricsCmd.Flags().Bool("from-stdin", false, "Stream metrics from stdin, logging them in batches as they arrive")
+	logMetricsCmd.Flags().String("format", "jsonl", "Stdin format (jsonl)")
+	logMetricsCmd.Flags().Int("batch-size", 100, "Number of data points per batch when streaming")
+	units.DurationFlag(logMetricsCmd.Flags(), "flush-interval", time.Second, "Maximum time buffered data points wait before being logged when streaming")
+	logMetricsCmd.Flags().Int("parallelism", 1, "Number of log-batch requests sent concurrently when logging from a file")
+	logMetricsCmd.Flags().String("ingestion-id", "", "Record the metrics under this ID with their content hash, skipping them if already recorded (not for stdin or --follow)")
+	logMetricsCmd.Flags().Bool("follow", false, "Follow the file like tail -F, logging appended data points until interrupted (JSONL/CSV)")
+	units.DurationFlag(logMetricsCmd.Flags(), "poll-interval", time.Second, "How often a followed file is checked for new data")
 	logMetricsCmd.MarkFlagRequired("run-id")
-	logMetricsCmd.MarkFlagRequired("from-file")
+	logMetricsCmd.MarkFlagsMutuallyExclusive("from-file", "from-stdin", "from-journal", "from-syslog")
+	logMetricsCmd.MarkFlagsMutuallyExclusive("follow", "from-stdin", "from-journal", "from-syslog")
+	logMetricsCmd.MarkFlagsMutuallyExclusive("follow", "extract")
+	logMetricsCmd.MarkFlagsMutuallyExclusive("ingestion-id", "from-stdin")
+	logMetricsCmd.MarkFlagsMutuallyExclusive("ingestion-id", "follow")
+	logMetricsCmd.MarkFlagsOneRequired("from-file", "from-stdin", "from-journal", "from-syslog")
+
+	registerExamples(logMetricCmd,
+		example{Command: "mlflow-cli log metric --run-id <run-id> --name loss --value 0.42 --step 100", Runnable: true},
+		example{Command: "mlflow-cli log metric --run-id <run-id> --name accuracy --value 0.93 --timestamp 2024-05-01T12:00:00Z"},
+	)
+	registerExamples(logMetricsCmd,
+		example{Command: `mlflow-cli log metrics --run-id <run-id> --from-journal 'unit=trainer.service' \
+  --pattern 'loss=(?P<loss>[0-9.]+)'`},
+		example{Command: `mlflow-cli log metrics --run-id <run-id> --from-syslog /var/log/syslog \
+  --pattern 'trainer.*step (?P<step>\d+) acc (?P<accuracy>[0-9.]+)'`},
+	)
 }
 
 func logMetric(cmd *cobra.Command, args []string) error {
@@ -68,6 +115,7 @@ func logMetric(cmd *cobra.Command, args []string) error {
 	value, _ := cmd.Flags().GetFloat64("value")
 	step, _ := cmd.Flags().GetInt64("step")
 	timestampStr, _ := cmd.Flags().GetString("timestamp")
+	modelID, _ := cmd.Flags().GetString("model-id")
 
 	var timestamp *time.Time
 	var stepPtr *int64
@@ -87,7 +135,12 @@ func logMetric(cmd *cobra.Command, args []string) error {
 	}
 
 	ctx := context.Background()
-	if err := client.LogMetric(ctx, runID, name, value, timestamp, stepPtr); err != nil {
+	if modelID != "" {
+		if modelID, err = linkableModelID(ctx, client, modelID); err != nil {
+			return err
+		}
+	}
+	if err := client.LogModelMetric(ctx, runID, modelID, name, value, timestamp, stepPtr); err != nil {
 		return fmt.Errorf("failed to log metric: %w", err)
 	}
 
@@ -96,7 +149,7 @@ func logMetric(cmd *cobra.Command, args []string) error {
 		fmt.Printf(" (step: %d)", *stepPtr)
 	}
 	if timestamp != nil {
-		fmt.Printf(" (timestamp: %s)", timestamp.Format(time.RFC3339))
+		fmt.Printf(" (timestamp: %s)", output.DisplayTime(*timestamp).Format(time.RFC3339))
 	}
 	fmt.Println()
 
@@ -116,6 +169,63 @@ func logMetrics(cmd *cobra.Command, args []string) error {
 	timeResolution, _ := cmd.Flags().GetString("time-resolution")
 	timeAlignment, _ := cmd.Flags().GetString("time-alignment")
 	stepMode, _ := cmd.Flags().GetString("step-mode")
+	aggregate, _ := cmd.Flags().GetString("aggregate")
+	stepOffset, _ := cmd.Flags().GetInt64("step-offset")
+	stepStride, _ := cmd.Flags().GetInt64("step-stride")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	baseTimeStr, _ := cmd.Flags().GetString("base-time")
+	mappings, _ := cmd.Flags().GetStringArray("map")
+	fromJournal, _ := cmd.Flags().GetString("from-journal")
+	fromSyslog, _ := cmd.Flags().GetString("from-syslog")
+	patterns, _ := cmd.Flags().GetStringArray("pattern")
+	extracts, _ := cmd.Flags().GetStringArray("extract")
+	ingestionID, _ := cmd.Flags().GetString("ingestion-id")
+
+	var extractor *parser.LineExtractor
+	if len(patterns) > 0 {
+		if fromJournal == "" && fromSyslog == "" {
+			return fmt.Errorf("--pattern requires --from-journal or --from-syslog")
+		}
+		if extractor, err = parser.NewLineExtractor(patterns); err != nil {
+			return err
+		}
+	}
+	if len(extracts) > 0 {
+		if fromFile == "" {
+			return fmt.Errorf("--extract requires --from-file")
+		}
+		if extractor, err = parser.NewLineExtractor(extracts); err != nil {
+			return err
+		}
+	}
+
+	if timezone == "" {
+		timezone = cfg.Timezone
+	}
+	location, err := timeutils.LoadLocation(timezone)
+	if err != nil {
+		return err
+	}
+
+	var baseTime *time.Time
+	if baseTimeStr != "" {
+		t, err := timeutils.ParseTimeIn(baseTimeStr, location)
+		if err != nil {
+			return fmt.Errorf("invalid base time: %w", err)
+		}
+		baseTime = &t
+	}
+	if stepOffset < 0 {
+		return fmt.Errorf("step offset must not be negative")
+	}
+	if stepStride < 1 {
+		return fmt.Errorf("step stride must be at least 1")
+	}
+
+	mapping, err := parseMetricMapping(mappings)
+	if err != nil {
+		return err
+	}
 
 	// Use config defaults if not specified
 	if timeResolution == "" {
@@ -127,56 +237,138 @@ func logMetrics(cmd *cobra.Command, args []string) error {
 	if stepMode == "" {
 		stepMode = cfg.StepMode
 	}
+	if aggregate == "" {
+		aggregate = cfg.Aggregate
+	}
 
-	// Open and parse file
-	file, err := os.Open(fromFile)
-	if err != nil {
-		return fmt.Errorf("failed to open file %s: %w", fromFile, err)
+	// Process metrics with time configuration
+	timeConfig := models.TimeConfig{
+		Resolution: timeResolution,
+		Alignment:  timeAlignment,
+		StepMode:   stepMode,
+		Aggregate:  aggregate,
+		StepOffset: stepOffset,
+		StepStride: stepStride,
+		Location:   location,
 	}
-	defer file.Close()
 
-	var metricsFile *models.MetricsFile
-	ext := strings.ToLower(filepath.Ext(fromFile))
+	var ingestionTag string
+	if ingestionID != "" {
+		if ingestionTag, err = ingestionTagKey(ingestionID); err != nil {
+			return err
+		}
+	}
 
-	switch ext {
-	case ".json":
-		metricsFile, err = parser.ParseJSONMetrics(file)
-	case ".yaml", ".yml":
-		metricsFile, err = parser.ParseYAMLMetrics(file)
-	default:
-		return fmt.Errorf("unsupported file format: %s (supported: .json, .yaml, .yml)", ext)
+	if fromStdin, _ := cmd.Flags().GetBool("from-stdin"); fromStdin {
+		return logMetricsFromStdin(cmd, client, runID, timeConfig, mapping, baseTime)
+	}
+
+	if follow, _ := cmd.Flags().GetBool("follow"); follow {
+		return followMetricsFile(cmd, client, runID, fromFile, timeConfig, mapping, baseTime)
 	}
 
+	// Points are processed as they are read, and the metrics are spooled to
+	// a temporary file beyond the memory budget
+	processor, err := timeutils.NewMetricProcessor(timeConfig, baseTime)
 	if err != nil {
-		return fmt.Errorf("failed to parse metrics file: %w", err)
+		return fmt.Errorf("failed to process metrics: %w", err)
 	}
+	processedMetrics := spool.NewMetricSpool(cfg.MaxMemory)
+	defer processedMetrics.Close()
 
-	// Process metrics with time configuration
-	timeConfig := models.TimeConfig{
-		Resolution: timeResolution,
-		Alignment:  timeAlignment,
-		StepMode:   stepMode,
+	var hasher *ingestionHasher
+	if ingestionID != "" {
+		hasher = newIngestionHasher(timeConfig, baseTime)
+	}
+	var processErr error
+	process := func(point models.MetricPoint) error {
+		point = parser.MapMetricPoint(point, mapping)
+		if hasher != nil {
+			hasher.Add(point)
+		}
+		processErr = processor.Process(point, processedMetrics.Add)
+		return processErr
 	}
 
-	processedMetrics, err := timeutils.ProcessMetrics(metricsFile.Metrics, timeConfig, nil)
+	source := fromFile
+	switch {
+	case fromJournal != "":
+		err = readJournalMetrics(fromJournal, extractor, process)
+		source = "the journal"
+	case fromSyslog != "":
+		err = readSyslogMetrics(fromSyslog, extractor, location, process)
+		source = fromSyslog
+	case extractor != nil:
+		err = readLogMetrics(fromFile, extractor, process)
+	default:
+		err = readMetricsFile(fromFile, process)
+	}
+	if processErr == nil && err == nil {
+		processErr = processor.Finish(processedMetrics.Add)
+	}
+	if processErr != nil {
+		return fmt.Errorf("failed to process metrics: %w", processErr)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to process metrics: %w", err)
+		return err
+	}
+	if spilled := processedMetrics.Spilled(); spilled > 0 {
+		fmt.Fprintf(os.Stderr, "Spooled %d of %d metrics to a temporary file to stay within the memory budget\n", spilled, processedMetrics.Len())
 	}
 
 	// Log metrics using batch API for efficiency
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+	if parallelism < 1 {
+		return fmt.Errorf("parallelism must be at least 1")
+	}
+
+	// The ingestion ID is claimed before logging, so that an invocation
+	// started at the same time backs off, and recorded as done only once all
+	// data points are logged
 	ctx := context.Background()
-	if err := client.LogBatchMetrics(ctx, runID, processedMetrics); err != nil {
+	var hash string
+	if ingestionID != "" {
+		hash = hasher.Sum()
+		claim, reason, err := claimIngestion(ctx, client, runID, ingestionID, hash)
+		if err != nil {
+			return err
+		}
+		if claim == "" {
+			fmt.Printf("Metrics of ingestion %s %s (%s); nothing to do\n", ingestionID, reason, hash)
+			return nil
+		}
+	}
+
+	progress := newProgressPrinter("Logging metrics")
+	err = client.LogMetricSource(ctx, runID, processedMetrics, parallelism, progress.Update)
+	progress.Done()
+	if err != nil {
+		if ingestionID != "" {
+			releaseIngestion(ctx, client, runID, ingestionID)
+		}
 		return fmt.Errorf("failed to log metrics: %w", err)
 	}
 
-	fmt.Printf("Successfully logged %d metrics from %s\n", len(processedMetrics), fromFile)
-	fmt.Printf("Time configuration: resolution=%s, alignment=%s, step_mode=%s\n",
-		timeResolution, timeAlignment, stepMode)
+	if ingestionID != "" {
+		if err := client.SetTag(ctx, runID, ingestionTag, hash); err != nil {
+			return fmt.Errorf("metrics were logged, but recording ingestion %s failed: %w", ingestionID, err)
+		}
+	}
+
+	fmt.Printf("Successfully logged %d metrics from %s\n", processedMetrics.Len(), source)
+	fmt.Printf("Time configuration: resolution=%s, alignment=%s, step_mode=%s, aggregate=%s\n",
+		timeResolution, timeAlignment, stepMode, aggregate)
 
 	// Show summary of metrics
 	metricCounts := make(map[string]int)
-	for _, metric := range processedMetrics {
-		metricCounts[metric.Key]++
+	err = processedMetrics.Batches(mlflow.MaxMetricsPerBatch, func(metrics []models.Metric) error {
+		for _, metric := range metrics {
+			metricCounts[metric.Key]++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	fmt.Println("Metrics summary:")
@@ -186,3 +378,119 @@ func logMetrics(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// logMetricsFromStdin streams metrics from stdin in the requested format
+func logMetricsFromStdin(cmd *cobra.Command, client *mlflow.Client, runID string, timeConfig models.TimeConfig, mapping map[string]string, baseTime *time.Time) error {
+	format, _ := cmd.Flags().GetString("format")
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+	flushInterval, _ := cmd.Flags().GetDuration("flush-interval")
+
+	if format != "jsonl" {
+		return fmt.Errorf("unsupported stdin format: %s (supported: jsonl)", format)
+	}
+	if flushInterval <= 0 {
+		return fmt.Errorf("flush interval must be positive")
+	}
+
+	ctx := context.Background()
+	streamer := newMetricStreamer(client, runID, timeConfig, mapping, batchSize, baseTime)
+	if err := streamJSONLMetrics(ctx, streamer, os.Stdin, flushInterval); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully logged %d metrics from stdin\n", streamer.Logged())
+	return nil
+}
+
+// followMetricsFile logs data points appended to a file until interrupted
+func followMetricsFile(cmd *cobra.Command, client *mlflow.Client, runID, fromFile string, timeConfig models.TimeConfig, mapping map[string]string, baseTime *time.Time) error {
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+	flushInterval, _ := cmd.Flags().GetDuration("flush-interval")
+	pollInterval, _ := cmd.Flags().GetDuration("poll-interval")
+
+	if flushInterval <= 0 || pollInterval <= 0 {
+		return fmt.Errorf("flush and poll intervals must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	streamer := newMetricStreamer(client, runID, timeConfig, mapping, batchSize, baseTime)
+	fmt.Fprintf(os.Stderr, "Following %s (press Ctrl+C to stop)\n", fromFile)
+	if err := followMetrics(ctx, streamer, fromFile, pollInterval, flushInterval); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully logged %d metrics from %s\n", streamer.Logged(), fromFile)
+	return nil
+}
+
+// parseMetricMapping parses mapping strings in field=key format
+func parseMetricMapping(mappings []string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, m := range mappings {
+		parts := strings.SplitN(m, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid mapping format: %s (expected field=key)", m)
+		}
+		mapping[parts[0]] = parts[1]
+	}
+	return mapping, nil
+}
+
+// readMetricsFile passes the points of a JSON/YAML/CSV metrics file to fn.
+// CSV files are read as a stream; JSON and YAML files are decoded in full.
+func readMetricsFile(fromFile string, fn func(models.MetricPoint) error) error {
+	if strings.ToLower(filepath.Ext(fromFile)) != ".csv" {
+		metricsFile, err := loadMetricsFile(fromFile)
+		if err != nil {
+			return err
+		}
+		for _, point := range metricsFile.Metrics {
+			if err := fn(point); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	file, err := os.Open(fromFile)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", fromFile, err)
+	}
+	defer file.Close()
+
+	if err := parser.ReadCSVMetrics(file, fn); err != nil {
+		return fmt.Errorf("failed to parse metrics file: %w", err)
+	}
+	return nil
+}
+
+// loadMetricsFile parses a JSON/YAML/CSV metrics file
+func loadMetricsFile(fromFile string) (*models.MetricsFile, error) {
+	file, err := os.Open(fromFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", fromFile, err)
+	}
+	defer file.Close()
+
+	var metricsFile *models.MetricsFile
+	ext := strings.ToLower(filepath.Ext(fromFile))
+
+	switch ext {
+	case ".json":
+		metricsFile, err = parser.ParseJSONMetrics(file)
+	case ".yaml", ".yml":
+		metricsFile, err = parser.ParseYAMLMetrics(file)
+	case ".csv":
+		metricsFile, err = parser.ParseCSVMetrics(file)
+	default:
+		return nil, fmt.Errorf("unsupported file format: %s (supported: .json, .yaml, .yml, .csv)", ext)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics file: %w", err)
+	}
+
+	return metricsFile, nil
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/imishinist/mlflow-cli/internal/parser"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate files to newer formats",
+	Long:  "Convert input files written for older versions of the CLI to the current formats",
+}
+
+var migrateFileCmd = &cobra.Command{
+	Use:   "file <metrics-file>",
+	Short: "Migrate a metrics file to a newer schema version",
+	Long: `Convert a JSON/YAML metrics file from one schema version to a later one.
+
+Schema versions:
+  v1  fixed execution_time, success_rate and error_count fields
+  v2  every numeric field is logged as a metric (current)
+
+The migrated file logs the same metrics as the original did: v1 skipped zero
+execution_time and success_rate values, always logged error_count and ignored
+all other fields.`,
+	Args: cobra.ExactArgs(1),
+	RunE: migrateFile,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateFileCmd)
+
+	// Migrate file command flags
+	migrateFileCmd.Flags().String("from", "", "Schema version of the file, e.g. v1 (required)")
+	migrateFileCmd.Flags().String("to", "v"+strconv.Itoa(parser.LatestMetricsVersion), "Schema version to migrate to")
+	migrateFileCmd.Flags().String("output", "", "Output file (default: stdout)")
+	migrateFileCmd.Flags().Bool("in-place", false, "Overwrite the input file")
+	migrateFileCmd.MarkFlagRequired("from")
+	migrateFileCmd.MarkFlagsMutuallyExclusive("output", "in-place")
+
+	registerExamples(migrateFileCmd,
+		example{Command: "mlflow-cli migrate file --from v1 --to v2 metrics.json > metrics.v2.json"},
+		example{Command: "mlflow-cli migrate file --from v1 --in-place metrics.yaml"},
+	)
+}
+
+func migrateFile(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	fromValue, _ := cmd.Flags().GetString("from")
+	toValue, _ := cmd.Flags().GetString("to")
+	outputFile, _ := cmd.Flags().GetString("output")
+	inPlace, _ := cmd.Flags().GetBool("in-place")
+
+	from, err := parser.ParseSchemaVersion(fromValue)
+	if err != nil {
+		return err
+	}
+	to, err := parser.ParseSchemaVersion(toValue)
+	if err != nil {
+		return err
+	}
+	if inPlace {
+		outputFile = inputFile
+	}
+
+	data, err := os.ReadFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", inputFile, err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(inputFile))
+	var doc map[string]interface{}
+	switch ext {
+	case ".json":
+		err = json.Unmarshal(data, &doc)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	default:
+		return fmt.Errorf("unsupported file format: %s (supported: .json, .yaml, .yml)", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", inputFile, err)
+	}
+	if doc == nil {
+		doc = make(map[string]interface{})
+	}
+
+	notes, err := parser.MigrateMetrics(doc, from, to)
+	if err != nil {
+		return err
+	}
+	for _, note := range notes {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", note)
+	}
+
+	var migrated bytes.Buffer
+	if ext == ".json" {
+		encoder := json.NewEncoder(&migrated)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(doc)
+	} else {
+		encoder := yaml.NewEncoder(&migrated)
+		encoder.SetIndent(2)
+		err = encoder.Encode(doc)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode migrated file: %w", err)
+	}
+
+	if outputFile == "" {
+		_, err := os.Stdout.Write(migrated.Bytes())
+		return err
+	}
+	if err := os.WriteFile(outputFile, migrated.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputFile, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Successfully migrated %s from v%d to v%d\n", outputFile, from, to)
+	return nil
+}
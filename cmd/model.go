@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/units"
+)
+
+var modelCmd = &cobra.Command{
+	Use:   "model",
+	Short: "Model registry operations",
+	Long:  `Commands for working with registered models and model versions.`,
+}
+
+var modelAwaitCmd = &cobra.Command{
+	Use:     "await",
+	Aliases: []string{"await-ready"},
+	Short:   "Wait until a model version is ready",
+	Long: `Poll a registered model version until its registration finishes. The command
+succeeds when the version becomes READY and fails when registration fails or the
+timeout expires.`,
+	RunE: modelAwait,
+}
+
+func init() {
+	rootCmd.AddCommand(modelCmd)
+	modelCmd.AddCommand(modelAwaitCmd)
+
+	// Model await command flags
+	modelAwaitCmd.Flags().String("name", "", "Registered model name (required)")
+	modelAwaitCmd.Flags().String("version", "", "Model version (required)")
+	units.DurationFlag(modelAwaitCmd.Flags(), "wait-timeout", 10*time.Minute, "Maximum time to wait (--timeout bounds each API request)")
+	units.DurationFlag(modelAwaitCmd.Flags(), "poll-interval", 5*time.Second, "How often the registry is polled")
+	modelAwaitCmd.MarkFlagRequired("name")
+	modelAwaitCmd.MarkFlagRequired("version")
+
+	registerExamples(modelAwaitCmd,
+		example{Command: "mlflow-cli model await --name fraud-detector --version 3 --wait-timeout 10m"},
+	)
+}
+
+func modelAwait(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	name, _ := cmd.Flags().GetString("name")
+	version, _ := cmd.Flags().GetString("version")
+	timeout, _ := cmd.Flags().GetDuration("wait-timeout")
+	pollInterval, _ := cmd.Flags().GetDuration("poll-interval")
+
+	if timeout <= 0 || pollInterval <= 0 {
+		return fmt.Errorf("wait timeout and poll interval must be positive")
+	}
+
+	ctx := context.Background()
+
+	fmt.Fprintf(os.Stderr, "Waiting for model %s version %s to become ready...\n", name, version)
+	modelVersion, err := awaitModelVersionReady(ctx, cmd, client, name, version, timeout, pollInterval)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Model %s version %s is %s\n", name, version, modelVersion.Status)
+	return nil
+}
+
+// Exit codes of commands waiting for model versions, so that pipelines can
+// tell a failed registration from a slow registry
+const (
+	exitRegistrationFailed = 2
+	exitAwaitTimeout       = 3
+)
+
+// awaitModelVersionReady waits until the registration of a model version is
+// no longer pending, and fails unless the version is READY. Failed
+// registrations and timeouts exit with their own codes.
+func awaitModelVersionReady(ctx context.Context, cmd *cobra.Command, client *mlflow.Client, name, version string, timeout, pollInterval time.Duration) (*models.ModelVersion, error) {
+	modelVersion, err := client.AwaitModelVersion(ctx, name, version, timeout, pollInterval)
+	if errors.Is(err, mlflow.ErrAwaitTimeout) {
+		cmd.SilenceUsage = true
+		return nil, &ExitError{Code: exitAwaitTimeout, Err: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if modelVersion.Status != models.ModelVersionStatusReady {
+		err := fmt.Errorf("model %s version %s is %s", name, version, modelVersion.Status)
+		if modelVersion.StatusMessage != "" {
+			err = fmt.Errorf("model %s version %s is %s: %s", name, version, modelVersion.Status, modelVersion.StatusMessage)
+		}
+		cmd.SilenceUsage = true
+		return nil, &ExitError{Code: exitRegistrationFailed, Err: err}
+	}
+	return modelVersion, nil
+}
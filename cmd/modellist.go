@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/output"
+)
+
+var modelListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered models",
+	Long: `List the registered models of the model registry matching --filter, in the
+registered model search syntax (e.g. "name LIKE 'fraud%'" or "tags.team =
+'risk'").
+
+--columns selects the printed columns: name, description, aliases (alias=version
+pairs), latest_versions (stage=version pairs of the latest version in each
+stage), created_at, updated_at or tags.<key>. --sort-by orders the models by any
+such column.`,
+	RunE: modelList,
+}
+
+var modelVersionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the versions of a registered model",
+	Long: `List the versions of a registered model matching --filter, in the model version
+search syntax (e.g. "run_id = '<run-id>'" or "tags.validated = 'true'"), and
+in --stage if given. Versions of Unity Catalog models cannot be filtered and
+have no stages.
+
+--columns selects the printed columns: name, version, current_stage, status,
+aliases, run_id, source, description, created_at, updated_at or tags.<key>.
+--sort-by orders the versions by any such column; versions sort numerically.`,
+	RunE: modelVersionList,
+}
+
+// Columns of model list
+var registeredModelAttributeColumns = []string{"name", "description", "aliases", "latest_versions", "created_at", "updated_at"}
+var registeredModelColumns = []string{"name", "latest_versions", "aliases", "updated_at"}
+
+// Columns of model version list
+var modelVersionAttributeColumns = []string{"name", "version", "current_stage", "status", "aliases", "run_id", "source", "description", "created_at", "updated_at"}
+var modelVersionColumns = []string{"version", "current_stage", "status", "aliases", "run_id", "created_at"}
+
+func init() {
+	modelCmd.AddCommand(modelListCmd)
+	modelVersionCmd.AddCommand(modelVersionListCmd)
+
+	// Model list command flags
+	modelListCmd.Flags().String("filter", "", "Registered model search filter expression")
+	modelListCmd.Flags().Int("limit", 1000, "Maximum number of models listed (0: no limit)")
+	modelListCmd.Flags().StringP("output", "o", output.FormatTable, "Output format (csv/json/jsonl/table)")
+	addColumnsFlag(modelListCmd, registeredModelColumns)
+	addSortByFlag(modelListCmd)
+	addAbsoluteTimesFlag(modelListCmd)
+
+	// Model version list command flags
+	modelVersionListCmd.Flags().String("name", "", "Registered model name (required)")
+	modelVersionListCmd.Flags().String("filter", "", "Model version search filter expression")
+	modelVersionListCmd.Flags().String("stage", "", "Only list versions in this stage: None, Staging, Production or Archived")
+	modelVersionListCmd.Flags().Int("limit", 1000, "Maximum number of versions listed (0: no limit)")
+	modelVersionListCmd.Flags().StringP("output", "o", output.FormatTable, "Output format (csv/json/jsonl/table)")
+	addColumnsFlag(modelVersionListCmd, modelVersionColumns)
+	addSortByFlag(modelVersionListCmd)
+	addAbsoluteTimesFlag(modelVersionListCmd)
+	modelVersionListCmd.MarkFlagRequired("name")
+
+	registerExamples(modelListCmd,
+		example{Command: `mlflow-cli model list --filter "name LIKE 'fraud%'"`},
+		example{Command: "mlflow-cli model list --columns name,aliases,tags.team -o json"},
+	)
+	registerExamples(modelVersionListCmd,
+		example{
+			Description: "Print the latest production version for a deployment",
+			Command: `mlflow-cli model versions list --name fraud-detector --stage Production \
+  --sort-by version:desc --columns version -o csv | sed -n 2p`,
+		},
+		example{Command: `mlflow-cli model versions list --name fraud-detector --filter "tags.validated = 'true'" -o json`},
+	)
+}
+
+func modelList(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	filter, _ := cmd.Flags().GetString("filter")
+	limit, _ := cmd.Flags().GetInt("limit")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+	if limit < 0 {
+		return fmt.Errorf("--limit must be >= 0")
+	}
+	layout, err := getTableLayout(cmd, registeredModelColumns, registryColumns(registeredModelAttributeColumns))
+	if err != nil {
+		return err
+	}
+
+	registeredModels, err := client.SearchRegisteredModels(context.Background(), filter, limit)
+	if err != nil {
+		return err
+	}
+
+	columns := layout.sourceColumns()
+	table := output.NewTable(columns...)
+	for _, model := range registeredModels {
+		row := make([]any, len(columns))
+		for i, column := range columns {
+			row[i] = registeredModelColumnValue(model, column)
+		}
+		table.Append(row...)
+	}
+	table = layout.apply(table)
+	humanizeTimes(cmd, format, table)
+	return output.Write(os.Stdout, format, table)
+}
+
+func modelVersionList(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	name, _ := cmd.Flags().GetString("name")
+	filter, _ := cmd.Flags().GetString("filter")
+	stage, _ := cmd.Flags().GetString("stage")
+	limit, _ := cmd.Flags().GetInt("limit")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+	if limit < 0 {
+		return fmt.Errorf("--limit must be >= 0")
+	}
+	if stage != "" {
+		if client.IsUnityCatalogModel(name) {
+			return fmt.Errorf("model %s is in Unity Catalog, which has no stages: use aliases instead", name)
+		}
+		if stage, err = parseModelStage(stage); err != nil {
+			return err
+		}
+	}
+	layout, err := getTableLayout(cmd, modelVersionColumns, registryColumns(modelVersionAttributeColumns))
+	if err != nil {
+		return err
+	}
+
+	// Not every registry can filter by stage, so the stage is matched here and
+	// all versions are searched for it
+	max := limit
+	if stage != "" {
+		max = 0
+	}
+	versions, err := client.ListModelVersions(context.Background(), name, filter, max)
+	if err != nil {
+		return err
+	}
+
+	columns := layout.sourceColumns()
+	table := output.NewTable(columns...)
+	listed := 0
+	for _, version := range versions {
+		if stage != "" && version.CurrentStage != stage {
+			continue
+		}
+		if limit > 0 && listed >= limit {
+			break
+		}
+		row := make([]any, len(columns))
+		for i, column := range columns {
+			row[i] = modelVersionColumnValue(version, column)
+		}
+		table.Append(row...)
+		listed++
+	}
+	table = layout.apply(table)
+	humanizeTimes(cmd, format, table)
+	return output.Write(os.Stdout, format, table)
+}
+
+// registryColumns returns a column validator accepting the given attributes
+// and tags.<key>
+func registryColumns(attributes []string) func(column string) error {
+	return func(column string) error {
+		if key, ok := strings.CutPrefix(column, "tags."); ok {
+			if key == "" {
+				return fmt.Errorf("invalid column: %s (missing key)", column)
+			}
+			return nil
+		}
+		return fixedColumns(attributes)(column)
+	}
+}
+
+// registeredModelColumnValue returns the value of a column for a registered
+// model, or nil if the model has no such tag
+func registeredModelColumnValue(model *models.RegisteredModel, column string) any {
+	if key, ok := strings.CutPrefix(column, "tags."); ok {
+		if value, exists := model.Tags[key]; exists {
+			return value
+		}
+		return nil
+	}
+
+	switch column {
+	case "name":
+		return model.Name
+	case "description":
+		return model.Description
+	case "aliases":
+		return formatVersionMap(model.Aliases, sortedMapKeys(model.Aliases))
+	case "latest_versions":
+		// Stages are listed in registry order
+		var stages []string
+		for _, stage := range modelStages {
+			if _, ok := model.LatestVersions[stage]; ok {
+				stages = append(stages, stage)
+			}
+		}
+		return formatVersionMap(model.LatestVersions, stages)
+	case "created_at":
+		return model.CreatedAt
+	case "updated_at":
+		return model.UpdatedAt
+	}
+	return nil
+}
+
+// modelVersionColumnValue returns the value of a column for a model version,
+// or nil if the version has no such tag
+func modelVersionColumnValue(version *models.ModelVersion, column string) any {
+	if key, ok := strings.CutPrefix(column, "tags."); ok {
+		if value, exists := version.Tags[key]; exists {
+			return value
+		}
+		return nil
+	}
+
+	switch column {
+	case "name":
+		return version.Name
+	case "version":
+		// Versions are numbers, which sort numerically
+		if number, err := strconv.ParseInt(version.Version, 10, 64); err == nil {
+			return number
+		}
+		return version.Version
+	case "current_stage":
+		return version.CurrentStage
+	case "status":
+		return string(version.Status)
+	case "aliases":
+		if len(version.Aliases) == 0 {
+			return nil
+		}
+		return strings.Join(version.Aliases, ",")
+	case "run_id":
+		return version.RunID
+	case "source":
+		return version.Source
+	case "description":
+		return version.Description
+	case "created_at":
+		return version.CreatedAt
+	case "updated_at":
+		return version.UpdatedAt
+	}
+	return nil
+}
+
+// formatVersionMap formats the versions of keys as key=version pairs, or
+// returns nil if there are none
+func formatVersionMap(versions map[string]string, keys []string) any {
+	if len(keys) == 0 {
+		return nil
+	}
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + versions[key]
+	}
+	return strings.Join(pairs, ",")
+}
+
+// sortedMapKeys returns the keys of m in order
+func sortedMapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
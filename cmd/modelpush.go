@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/oci"
+	"github.com/imishinist/mlflow-cli/internal/units"
+)
+
+var modelPushOCICmd = &cobra.Command{
+	Use:   "push-oci",
+	Short: "Push a model version to an OCI registry",
+	Long: `Package the artifacts of a model version as an OCI artifact and push it to a
+container registry under --ref, so that models can be distributed through the
+registries deployments already pull images from.
+
+The model directory is packed into a single tar.gz layer the way ORAS pushes
+directories, so "oras pull <ref>" recreates it. The manifest is annotated with
+the model name, version, source, run, stage, aliases, tags and description.
+
+Credentials are taken from docker login (the docker config file or its
+credential helpers) unless --username is given. The manifest digest is printed
+on stdout.`,
+	RunE: modelPushOCI,
+}
+
+// ArtifactTypeMLflowModel is the artifact type of pushed models
+const artifactTypeMLflowModel = "application/vnd.mlflow.model.v1"
+
+// Annotations of pushed models
+const (
+	annotationModelName    = "io.mlflow.model.name"
+	annotationModelVersion = "io.mlflow.model.version"
+	annotationModelSource  = "io.mlflow.model.source"
+	annotationModelRunID   = "io.mlflow.model.run_id"
+	annotationModelStage   = "io.mlflow.model.stage"
+	annotationModelAliases = "io.mlflow.model.aliases"
+	annotationModelTag     = "io.mlflow.model.tag."
+)
+
+func init() {
+	modelCmd.AddCommand(modelPushOCICmd)
+
+	// Model push-oci command flags
+	modelPushOCICmd.Flags().String("name", "", "Registered model name (required)")
+	modelPushOCICmd.Flags().String("version", "", "Model version")
+	modelPushOCICmd.Flags().String("alias", "", "Model alias to resolve, e.g. champion")
+	modelPushOCICmd.Flags().String("ref", "", "Reference to push to, e.g. ghcr.io/org/models/foo:3 (required)")
+	modelPushOCICmd.Flags().String("username", "", "Registry username (default: from docker login)")
+	modelPushOCICmd.Flags().Bool("password-stdin", false, "Read the registry password or token from stdin")
+	modelPushOCICmd.Flags().Bool("plain-http", false, "Connect to the registry over HTTP instead of HTTPS")
+	modelPushOCICmd.MarkFlagRequired("name")
+	modelPushOCICmd.MarkFlagRequired("ref")
+	modelPushOCICmd.MarkFlagsMutuallyExclusive("alias", "version")
+	modelPushOCICmd.MarkFlagsOneRequired("alias", "version")
+	modelPushOCICmd.MarkFlagsRequiredTogether("username", "password-stdin")
+
+	registerExamples(modelPushOCICmd,
+		example{Command: "mlflow-cli model push-oci --name fraud-detector --version 3 --ref ghcr.io/org/models/fraud-detector:3"},
+		example{
+			Description: "Push the champion in CI",
+			Command: `echo "$GITHUB_TOKEN" | mlflow-cli model push-oci --name fraud-detector --alias champion \
+  --ref ghcr.io/org/models/fraud-detector:champion --username "$GITHUB_ACTOR" --password-stdin`,
+		},
+	)
+}
+
+func modelPushOCI(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	name, _ := cmd.Flags().GetString("name")
+	version, _ := cmd.Flags().GetString("version")
+	alias, _ := cmd.Flags().GetString("alias")
+	refValue, _ := cmd.Flags().GetString("ref")
+	username, _ := cmd.Flags().GetString("username")
+	passwordStdin, _ := cmd.Flags().GetBool("password-stdin")
+	plainHTTP, _ := cmd.Flags().GetBool("plain-http")
+
+	ref, err := oci.ParseReference(refValue)
+	if err != nil {
+		return err
+	}
+	opts := oci.Options{Username: username, PlainHTTP: plainHTTP}
+	if passwordStdin {
+		password, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read password from stdin: %w", err)
+		}
+		opts.Password = strings.TrimRight(string(password), "\r\n")
+	}
+
+	ctx := context.Background()
+
+	// Resolve model version
+	var modelVersion *models.ModelVersion
+	if alias != "" {
+		modelVersion, err = client.GetModelVersionByAlias(ctx, name, alias)
+	} else {
+		modelVersion, err = client.GetModelVersion(ctx, name, version)
+	}
+	if err != nil {
+		return err
+	}
+	if modelVersion.RunID == "" {
+		return fmt.Errorf("model %s version %s has no source run to push artifacts from", name, modelVersion.Version)
+	}
+	// Model versions fetched from the registry do not list their aliases
+	aliases, err := client.GetModelAliases(ctx, name)
+	if err != nil {
+		return err
+	}
+	for alias, aliasVersion := range aliases {
+		if aliasVersion == modelVersion.Version {
+			modelVersion.Aliases = append(modelVersion.Aliases, alias)
+		}
+	}
+	run, err := client.GetRun(ctx, modelVersion.RunID)
+	if err != nil {
+		return err
+	}
+	artifactPath, ok := modelArtifactPath(modelVersion.Source, run)
+	if !ok {
+		return fmt.Errorf("source %s of model %s version %s is outside the artifacts of run %s", modelVersion.Source, name, modelVersion.Version, run.RunID)
+	}
+
+	// The layer is packed to a temporary file first, as its digest is needed
+	// before it is uploaded
+	layerFile, layer, err := packModelLayer(ctx, client, run.RunID, artifactPath, modelVersion.CreatedAt)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(layerFile.Name())
+	defer layerFile.Close()
+
+	registry := oci.NewClient(ref, opts)
+	configDesc := oci.Descriptor{MediaType: oci.MediaTypeEmpty, Digest: oci.Digest(oci.EmptyConfig), Size: int64(len(oci.EmptyConfig))}
+	if _, err := registry.PushBlob(ctx, configDesc.Digest, configDesc.Size, strings.NewReader(string(oci.EmptyConfig))); err != nil {
+		return fmt.Errorf("failed to push to %s: %w", ref, err)
+	}
+	uploaded, err := registry.PushBlob(ctx, layer.Digest, layer.Size, layerFile)
+	if err != nil {
+		return fmt.Errorf("failed to push to %s: %w", ref, err)
+	}
+	if !uploaded {
+		fmt.Fprintf(os.Stderr, "Layer %s already exists in %s\n", layer.Digest, ref.Repository)
+	}
+
+	manifest := &oci.Manifest{
+		SchemaVersion: 2,
+		MediaType:     oci.MediaTypeManifest,
+		ArtifactType:  artifactTypeMLflowModel,
+		Config:        configDesc,
+		Layers:        []oci.Descriptor{layer},
+		Annotations:   modelAnnotations(modelVersion),
+	}
+	digest, err := registry.PushManifest(ctx, manifest)
+	if err != nil {
+		return fmt.Errorf("failed to push to %s: %w", ref, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Pushed model %s version %s (%s) to %s\n", name, modelVersion.Version, units.FormatSize(layer.Size), ref)
+	fmt.Println(digest)
+	return nil
+}
+
+// packModelLayer packs the model directory of a run into a temporary tar.gz
+// file and returns it with its layer descriptor. Entries are named below the
+// base name of the directory, which ORAS unpacks the layer into.
+func packModelLayer(ctx context.Context, client *mlflow.Client, runID, artifactPath string, modTime time.Time) (*os.File, oci.Descriptor, error) {
+	title := path.Base(artifactPath)
+
+	file, err := os.CreateTemp("", "mlflow-cli-oci-*.tar.gz")
+	if err != nil {
+		return nil, oci.Descriptor{}, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	fail := func(err error) (*os.File, oci.Descriptor, error) {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, oci.Descriptor{}, err
+	}
+
+	// Both the compressed layer and the tar inside are hashed, as ORAS
+	// verifies the tar when unpacking
+	layerHash := sha256.New()
+	tarHash := sha256.New()
+	gz := gzip.NewWriter(io.MultiWriter(file, layerHash))
+	if _, err := client.WriteArtifactsTar(ctx, io.MultiWriter(gz, tarHash), runID, artifactPath, title, modTime); err != nil {
+		return fail(fmt.Errorf("failed to pack model artifacts: %w", err))
+	}
+	if err := gz.Close(); err != nil {
+		return fail(fmt.Errorf("failed to pack model artifacts: %w", err))
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fail(err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fail(err)
+	}
+
+	return file, oci.Descriptor{
+		MediaType: oci.MediaTypeLayerTarGz,
+		Digest:    "sha256:" + hex.EncodeToString(layerHash.Sum(nil)),
+		Size:      size,
+		Annotations: map[string]string{
+			oci.AnnotationTitle:         title,
+			oci.AnnotationUnpack:        "true",
+			oci.AnnotationContentDigest: "sha256:" + hex.EncodeToString(tarHash.Sum(nil)),
+		},
+	}, nil
+}
+
+// modelAnnotations returns the manifest annotations describing a model version
+func modelAnnotations(modelVersion *models.ModelVersion) map[string]string {
+	annotations := map[string]string{
+		annotationModelName:    modelVersion.Name,
+		annotationModelVersion: modelVersion.Version,
+		annotationModelSource:  modelVersion.Source,
+		annotationModelRunID:   modelVersion.RunID,
+	}
+	if !modelVersion.CreatedAt.IsZero() {
+		annotations[oci.AnnotationCreated] = modelVersion.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if modelVersion.Description != "" {
+		annotations[oci.AnnotationDescription] = modelVersion.Description
+	}
+	if modelVersion.CurrentStage != "" && modelVersion.CurrentStage != "None" {
+		annotations[annotationModelStage] = modelVersion.CurrentStage
+	}
+	if len(modelVersion.Aliases) > 0 {
+		aliases := append([]string(nil), modelVersion.Aliases...)
+		sort.Strings(aliases)
+		annotations[annotationModelAliases] = strings.Join(aliases, ",")
+	}
+	for key, value := range modelVersion.Tags {
+		annotations[annotationModelTag+key] = value
+	}
+	return annotations
+}
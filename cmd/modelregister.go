@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/units"
+)
+
+var modelRegisterCmd = &cobra.Command{
+	Use:   "register",
+	Short: "Create a registered model",
+	Long: `Create a registered model in the model registry. Versions are added to it with
+"model version create". With --exist-ok, an existing model is not an error, so
+that pipelines can run the command before every version they create.`,
+	RunE: modelRegister,
+}
+
+var modelVersionCmd = &cobra.Command{
+	Use:     "version",
+	Aliases: []string{"versions"},
+	Short:   "Model version operations",
+	Long:    `Commands for working with versions of registered models.`,
+}
+
+var modelVersionCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a model version from a run's model",
+	Long: `Create a version of a registered model from the model logged below --path of a
+run, like mlflow.register_model with a runs:/<run-id>/<path> URI. --source
+registers model files at any other URI instead. The registered model is created
+first if --create-model is given.
+
+The new version number is printed on stdout. With --await, the command waits
+until the registry has copied the model and the version is READY, and fails if
+the registration fails.`,
+	RunE: modelVersionCreate,
+}
+
+func init() {
+	modelCmd.AddCommand(modelRegisterCmd)
+	modelCmd.AddCommand(modelVersionCmd)
+	modelVersionCmd.AddCommand(modelVersionCreateCmd)
+
+	// Model register command flags
+	modelRegisterCmd.Flags().String("name", "", "Registered model name (required)")
+	modelRegisterCmd.Flags().String("description", "", "Model description")
+	modelRegisterCmd.Flags().StringArray("tag", []string{}, "Model tags in key=value format")
+	modelRegisterCmd.Flags().Bool("exist-ok", false, "Succeed if the model already exists")
+	modelRegisterCmd.MarkFlagRequired("name")
+
+	// Model version create command flags
+	modelVersionCreateCmd.Flags().String("name", "", "Registered model name (required)")
+	modelVersionCreateCmd.Flags().String("run-id", "", "Run the model was logged to")
+	modelVersionCreateCmd.Flags().String("path", "model", "Artifact path of the model in the run")
+	modelVersionCreateCmd.Flags().String("source", "", "URI of the model files (default: the run's artifact URI joined with --path)")
+	modelVersionCreateCmd.Flags().String("description", "", "Version description")
+	modelVersionCreateCmd.Flags().StringArray("tag", []string{}, "Version tags in key=value format")
+	modelVersionCreateCmd.Flags().Bool("create-model", false, "Create the registered model if it does not exist")
+	modelVersionCreateCmd.Flags().Bool("await", false, "Wait until the version is ready (alias: --wait)")
+	units.DurationFlag(modelVersionCreateCmd.Flags(), "wait-timeout", 10*time.Minute, "Maximum time to wait with --await (--timeout bounds each API request)")
+	modelVersionCreateCmd.MarkFlagRequired("name")
+	modelVersionCreateCmd.MarkFlagsMutuallyExclusive("path", "source")
+	modelVersionCreateCmd.Flags().SetNormalizeFunc(func(flags *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "wait" {
+			name = "await"
+		}
+		return pflag.NormalizedName(name)
+	})
+
+	registerExamples(modelRegisterCmd,
+		example{Command: `mlflow-cli model register --name fraud-detector --description "Card fraud classifier" --tag team=risk --exist-ok`},
+	)
+	registerExamples(modelVersionCreateCmd,
+		example{
+			Description: "Register the model of a CI training run and wait until it is ready",
+			Command:     "VERSION=$(mlflow-cli model version create --name fraud-detector --run-id <run-id> --path model --await)",
+		},
+		example{
+			Description: "Register model files from an external location",
+			Command:     "mlflow-cli model version create --name fraud-detector --source s3://models/fraud/2024-06 --run-id <run-id>",
+		},
+	)
+}
+
+func modelRegister(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	name, _ := cmd.Flags().GetString("name")
+	description, _ := cmd.Flags().GetString("description")
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	existOK, _ := cmd.Flags().GetBool("exist-ok")
+
+	tagMap, err := parseTags(tags)
+	if err != nil {
+		return err
+	}
+
+	created, err := client.CreateRegisteredModel(context.Background(), name, description, tagMap, existOK)
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		fmt.Printf("Registered model %s already exists\n", name)
+		return nil
+	}
+	fmt.Printf("Successfully created registered model %s\n", name)
+	return nil
+}
+
+func modelVersionCreate(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	name, _ := cmd.Flags().GetString("name")
+	runID, _ := cmd.Flags().GetString("run-id")
+	artifactPath, _ := cmd.Flags().GetString("path")
+	source, _ := cmd.Flags().GetString("source")
+	description, _ := cmd.Flags().GetString("description")
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	createModel, _ := cmd.Flags().GetBool("create-model")
+	await, _ := cmd.Flags().GetBool("await")
+	timeout, _ := cmd.Flags().GetDuration("wait-timeout")
+
+	if runID == "" && source == "" {
+		return fmt.Errorf("either --run-id or --source must be specified")
+	}
+	if await && timeout <= 0 {
+		return fmt.Errorf("wait timeout must be positive")
+	}
+	tagMap, err := parseTags(tags)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if source == "" {
+		artifactPath = strings.Trim(artifactPath, "/")
+		if artifactPath == "" {
+			return fmt.Errorf("--path must not be empty")
+		}
+		run, err := client.GetRun(ctx, runID)
+		if err != nil {
+			return err
+		}
+		if run.ArtifactURI == "" {
+			return fmt.Errorf("run %s has no artifact URI", runID)
+		}
+		source = strings.TrimSuffix(run.ArtifactURI, "/") + "/" + artifactPath
+	}
+
+	if createModel {
+		created, err := client.CreateRegisteredModel(ctx, name, "", nil, true)
+		if err != nil {
+			return err
+		}
+		if created {
+			fmt.Fprintf(os.Stderr, "Created registered model %s\n", name)
+		}
+	}
+
+	modelVersion, err := client.CreateModelVersion(ctx, mlflow.ModelVersionOptions{
+		Name:        name,
+		Source:      source,
+		RunID:       runID,
+		Description: description,
+		Tags:        tagMap,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Created model %s version %s from %s\n", name, modelVersion.Version, source)
+
+	if await {
+		if modelVersion.Status == models.ModelVersionStatusPending {
+			fmt.Fprintf(os.Stderr, "Waiting for model %s version %s to become ready...\n", name, modelVersion.Version)
+		}
+		modelVersion, err = awaitModelVersionReady(ctx, cmd, client, name, modelVersion.Version, timeout, 5*time.Second)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(modelVersion.Version)
+	return nil
+}
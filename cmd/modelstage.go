@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+var modelTransitionCmd = &cobra.Command{
+	Use:   "transition",
+	Short: "Move a model version to a registry stage",
+	Long: `Move a model version to the stage None, Staging, Production or Archived of the
+workspace model registry. With --archive-existing, the versions in the target
+stage are archived, so that a single version serves the stage.
+
+Stages are deprecated in MLflow and do not exist for Unity Catalog models
+(catalog.schema.model on Databricks); use "model alias set" for those.`,
+	RunE: modelTransition,
+}
+
+var modelAliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage the aliases of registered models",
+	Long: `Commands for the aliases of registered models, such as champion, which name
+the version that consumers load with models:/<name>@<alias>. Aliases work with
+the workspace model registry and with Unity Catalog models.`,
+}
+
+var modelAliasSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Point a model alias at a version",
+	Long: `Point an alias of a registered model at a version. An alias names one version
+at a time: setting it moves it from the version it pointed at before.`,
+	RunE: modelAliasSet,
+}
+
+var modelAliasDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a model alias",
+	Long: `Delete an alias of a registered model. The version it pointed at is kept, but
+loading models:/<name>@<alias> fails afterwards.`,
+	RunE: modelAliasDelete,
+}
+
+// Stages of the workspace model registry
+var modelStages = []string{"None", "Staging", "Production", "Archived"}
+
+func init() {
+	modelCmd.AddCommand(modelTransitionCmd)
+	modelCmd.AddCommand(modelAliasCmd)
+	modelAliasCmd.AddCommand(modelAliasSetCmd)
+	modelAliasCmd.AddCommand(modelAliasDeleteCmd)
+
+	// Model transition command flags
+	modelTransitionCmd.Flags().String("name", "", "Registered model name (required)")
+	modelTransitionCmd.Flags().String("version", "", "Model version (required)")
+	modelTransitionCmd.Flags().String("stage", "", "Target stage: None, Staging, Production or Archived (required)")
+	modelTransitionCmd.Flags().Bool("archive-existing", false, "Archive the versions currently in the target stage (Staging or Production)")
+	modelTransitionCmd.MarkFlagRequired("name")
+	modelTransitionCmd.MarkFlagRequired("version")
+	modelTransitionCmd.MarkFlagRequired("stage")
+
+	// Model alias set command flags
+	modelAliasSetCmd.Flags().String("name", "", "Registered model name (required)")
+	modelAliasSetCmd.Flags().String("alias", "", "Alias (required)")
+	modelAliasSetCmd.Flags().String("version", "", "Model version the alias points at (required)")
+	modelAliasSetCmd.MarkFlagRequired("name")
+	modelAliasSetCmd.MarkFlagRequired("alias")
+	modelAliasSetCmd.MarkFlagRequired("version")
+
+	// Model alias delete command flags
+	modelAliasDeleteCmd.Flags().String("name", "", "Registered model name (required)")
+	modelAliasDeleteCmd.Flags().String("alias", "", "Alias (required)")
+	addConfirmFlags(modelAliasDeleteCmd)
+	modelAliasDeleteCmd.MarkFlagRequired("name")
+	modelAliasDeleteCmd.MarkFlagRequired("alias")
+
+	registerExamples(modelTransitionCmd,
+		example{
+			Description: "Promote version 3 and retire the previous production version",
+			Command:     "mlflow-cli model transition --name fraud-detector --version 3 --stage Production --archive-existing",
+		},
+	)
+	registerExamples(modelAliasSetCmd,
+		example{Command: "mlflow-cli model alias set --name fraud-detector --alias champion --version 3"},
+		example{Command: "mlflow-cli model alias set --name main.risk.fraud_detector --alias champion --version 3"},
+	)
+	registerExamples(modelAliasDeleteCmd,
+		example{Command: "mlflow-cli model alias delete --name fraud-detector --alias challenger --yes"},
+	)
+}
+
+func modelTransition(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	name, _ := cmd.Flags().GetString("name")
+	version, _ := cmd.Flags().GetString("version")
+	stage, _ := cmd.Flags().GetString("stage")
+	archiveExisting, _ := cmd.Flags().GetBool("archive-existing")
+
+	stage, err = parseModelStage(stage)
+	if err != nil {
+		return err
+	}
+	if archiveExisting && stage != "Staging" && stage != "Production" {
+		return fmt.Errorf("--archive-existing requires the stage Staging or Production")
+	}
+
+	ctx := context.Background()
+
+	// The versions archived by the server are looked up first to report them
+	var archived []string
+	if archiveExisting && !client.IsUnityCatalogModel(name) {
+		versions, err := client.SearchModelVersions(ctx, name)
+		if err != nil {
+			return err
+		}
+		for _, v := range versions {
+			if v.CurrentStage == stage && v.Version != version {
+				archived = append(archived, v.Version)
+			}
+		}
+	}
+
+	modelVersion, err := client.TransitionModelVersionStage(ctx, name, version, stage, archiveExisting)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range archived {
+		fmt.Fprintf(os.Stderr, "Archived model %s version %s\n", name, v)
+	}
+	fmt.Printf("Model %s version %s is now in stage %s\n", name, modelVersion.Version, modelVersion.CurrentStage)
+	return nil
+}
+
+func modelAliasSet(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	name, _ := cmd.Flags().GetString("name")
+	alias, _ := cmd.Flags().GetString("alias")
+	version, _ := cmd.Flags().GetString("version")
+
+	if err := validateModelAlias(alias); err != nil {
+		return err
+	}
+
+	if err := client.SetModelAlias(context.Background(), name, alias, version); err != nil {
+		return err
+	}
+
+	fmt.Printf("Alias %s of model %s now points at version %s\n", alias, name, version)
+	return nil
+}
+
+func modelAliasDelete(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	name, _ := cmd.Flags().GetString("name")
+	alias, _ := cmd.Flags().GetString("alias")
+
+	if err := confirm(cmd, fmt.Sprintf("delete alias %s of model %s", alias, name), 1); err != nil {
+		return err
+	}
+
+	if err := client.DeleteModelAlias(context.Background(), name, alias); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully deleted alias %s of model %s\n", alias, name)
+	return nil
+}
+
+// parseModelStage returns the registry stage named by stage, which is matched
+// case-insensitively like by the MLflow client
+func parseModelStage(stage string) (string, error) {
+	for _, s := range modelStages {
+		if strings.EqualFold(stage, s) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("invalid stage: %s (valid: %s)", stage, strings.Join(modelStages, ", "))
+}
+
+// validateModelAlias rejects aliases that MLflow reserves: "latest" and
+// version references such as v3
+func validateModelAlias(alias string) error {
+	lower := strings.ToLower(alias)
+	if lower == "latest" {
+		return fmt.Errorf("invalid alias: %s (reserved for the latest version)", alias)
+	}
+	if len(lower) > 1 && lower[0] == 'v' && strings.Trim(lower[1:], "0123456789") == "" {
+		return fmt.Errorf("invalid alias: %s (aliases of the form v<number> are reserved for versions)", alias)
+	}
+	return nil
+}
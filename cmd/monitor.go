@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/sysmetrics"
+	"github.com/imishinist/mlflow-cli/internal/units"
+)
+
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Log system utilization metrics to a run until interrupted",
+	Long: `Sample CPU, memory, disk, network, and NVIDIA GPU utilization at a fixed
+interval and log them to a run as system/* metrics, using the same metric names
+as MLflow's system metrics. Sampling continues until the command is interrupted.`,
+	RunE: monitor,
+}
+
+func init() {
+	rootCmd.AddCommand(monitorCmd)
+
+	// Monitor command flags
+	monitorCmd.Flags().String("run-id", "", "Run ID (required)")
+	units.DurationFlag(monitorCmd.Flags(), "interval", 10*time.Second, "Sampling interval")
+	monitorCmd.Flags().String("disk-path", "/", "Path on the filesystem whose disk usage is reported")
+	monitorCmd.MarkFlagRequired("run-id")
+
+	registerExamples(monitorCmd,
+		example{Command: `mlflow-cli monitor --run-id $RUN_ID --interval 10s &
+python train.py
+kill %1`},
+	)
+}
+
+func monitor(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	diskPath, _ := cmd.Flags().GetString("disk-path")
+
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sampler := sysmetrics.NewSampler(diskPath)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	fmt.Fprintf(os.Stderr, "Monitoring system metrics every %s (press Ctrl+C to stop)\n", interval)
+
+	var step int64
+	logged := 0
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Printf("Successfully logged %d system metrics to run %s\n", logged, runID)
+			return nil
+		case now := <-ticker.C:
+			metrics := systemMetrics(sampler.Sample(), now, step)
+			if err := client.LogBatchMetrics(ctx, runID, metrics); err != nil {
+				if ctx.Err() != nil {
+					continue
+				}
+				return fmt.Errorf("failed to log system metrics: %w", err)
+			}
+			logged += len(metrics)
+			step++
+		}
+	}
+}
+
+// systemMetrics converts a sample into metrics sorted by key
+func systemMetrics(values map[string]float64, timestamp time.Time, step int64) []models.Metric {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	metrics := make([]models.Metric, 0, len(keys))
+	for _, key := range keys {
+		metrics = append(metrics, models.Metric{
+			Key:        key,
+			Value:      values[key],
+			Timestamp:  timestamp,
+			Step:       step,
+			LocalClock: true,
+		})
+	}
+	return metrics
+}
@@ -34,8 +34,74 @@ func init() {
 	// Params command flags
 	logParamsCmd.Flags().String("run-id", "", "Run ID to log parameters to (required)")
 	logParamsCmd.Flags().StringArray("param", []string{}, "Parameters in key=value format")
-	logParamsCmd.Flags().String("from-file", "", "Load parameters from file (JSON/YAML)")
+	logParamsCmd.Flags().String("from-file", "", "Load parameters from file (JSON/YAML/TOML/.env)")
+	addFlattenFlags(logParamsCmd)
+	addParamLengthFlags(logParamsCmd)
 	logParamsCmd.MarkFlagRequired("run-id")
+
+	registerExamples(logParamsCmd,
+		example{Command: "mlflow-cli log params --run-id <run-id> --param lr=0.01 --param batch_size=32", Runnable: true},
+		example{
+			Description: "Log a nested training config as optimizer.lr, optimizer.momentum, ...",
+			Command:     "mlflow-cli log params --run-id <run-id> --from-file config.yaml --flatten",
+		},
+	)
+}
+
+// addFlattenFlags registers the flags controlling how nested parameter files
+// are flattened
+func addFlattenFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("flatten", false, "Flatten nested JSON/YAML configs into dotted parameter keys (e.g. optimizer.lr)")
+	cmd.Flags().String("flatten-separator", parser.DefaultFlattenOptions.Separator, "Separator joining nested keys")
+	cmd.Flags().Int("flatten-depth", 0, "Number of key levels to flatten; deeper values are JSON encoded (0: no limit)")
+}
+
+// addParamLengthFlags registers the flags of the param length policy
+func addParamLengthFlags(cmd *cobra.Command) {
+	cmd.Flags().String("on-too-long", mlflow.ParamTooLongError, "Handling of param values over --max-param-length: error, truncate, or hash (replace with sha256:<digest>)")
+	cmd.Flags().Int("max-param-length", mlflow.MaxParamValueLength, "Maximum param value length in characters accepted by the server")
+}
+
+// paramLengthPolicy returns the validated param length policy of the command
+func paramLengthPolicy(cmd *cobra.Command) (mlflow.ParamLengthPolicy, error) {
+	onTooLong, _ := cmd.Flags().GetString("on-too-long")
+	maxLength, _ := cmd.Flags().GetInt("max-param-length")
+
+	policy := mlflow.ParamLengthPolicy{OnTooLong: onTooLong, MaxLength: maxLength}
+	return policy, policy.Validate()
+}
+
+// applyParamLengthPolicy applies the policy to params, warning about every
+// value that was changed
+func applyParamLengthPolicy(policy mlflow.ParamLengthPolicy, params map[string]string) (map[string]string, error) {
+	result, changed, err := policy.Apply(params)
+	if err != nil {
+		return nil, err
+	}
+	action := "truncated"
+	if policy.OnTooLong == mlflow.ParamTooLongHash {
+		action = "replaced by its SHA-256 digest"
+	}
+	for _, key := range changed {
+		fmt.Fprintf(os.Stderr, "Warning: value of param %s is longer than %d characters and was %s\n", key, policy.MaxLength, action)
+	}
+	return result, nil
+}
+
+// flattenOptions returns the flatten options of the command and whether
+// nested JSON/YAML files should be flattened. TOML files are always flattened.
+func flattenOptions(cmd *cobra.Command) (parser.FlattenOptions, bool, error) {
+	flatten, _ := cmd.Flags().GetBool("flatten")
+	separator, _ := cmd.Flags().GetString("flatten-separator")
+	depth, _ := cmd.Flags().GetInt("flatten-depth")
+
+	if separator == "" {
+		return parser.FlattenOptions{}, false, fmt.Errorf("--flatten-separator must not be empty")
+	}
+	if depth < 0 {
+		return parser.FlattenOptions{}, false, fmt.Errorf("--flatten-depth must be >= 0")
+	}
+	return parser.FlattenOptions{Separator: separator, MaxDepth: depth}, flatten, nil
 }
 
 func logParams(cmd *cobra.Command, args []string) error {
@@ -49,6 +115,14 @@ func logParams(cmd *cobra.Command, args []string) error {
 	runID, _ := cmd.Flags().GetString("run-id")
 	params, _ := cmd.Flags().GetStringArray("param")
 	fromFile, _ := cmd.Flags().GetString("from-file")
+	flattenOpts, flatten, err := flattenOptions(cmd)
+	if err != nil {
+		return err
+	}
+	lengthPolicy, err := paramLengthPolicy(cmd)
+	if err != nil {
+		return err
+	}
 
 	ctx := context.Background()
 
@@ -62,6 +136,10 @@ func logParams(cmd *cobra.Command, args []string) error {
 			}
 			paramMap[parts[0]] = parts[1]
 		}
+		paramMap, err := applyParamLengthPolicy(lengthPolicy, paramMap)
+		if err != nil {
+			return err
+		}
 
 		if err := client.LogParamsFromMap(ctx, runID, paramMap); err != nil {
 			return fmt.Errorf("failed to log parameters: %w", err)
@@ -75,26 +153,13 @@ func logParams(cmd *cobra.Command, args []string) error {
 
 	// Log parameters from file
 	if fromFile != "" {
-		file, err := os.Open(fromFile)
+		paramMap, err := loadParamsFile(fromFile, flattenOpts, flatten)
 		if err != nil {
-			return fmt.Errorf("failed to open file %s: %w", fromFile, err)
-		}
-		defer file.Close()
-
-		var paramMap map[string]string
-		ext := strings.ToLower(filepath.Ext(fromFile))
-
-		switch ext {
-		case ".json":
-			paramMap, err = parser.ParseJSONParams(file)
-		case ".yaml", ".yml":
-			paramMap, err = parser.ParseYAMLParams(file)
-		default:
-			return fmt.Errorf("unsupported file format: %s (supported: .json, .yaml, .yml)", ext)
+			return err
 		}
-
+		paramMap, err = applyParamLengthPolicy(lengthPolicy, paramMap)
 		if err != nil {
-			return fmt.Errorf("failed to parse parameters file: %w", err)
+			return err
 		}
 
 		if err := client.LogParamsFromMap(ctx, runID, paramMap); err != nil {
@@ -113,3 +178,48 @@ func logParams(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// loadParamsFile parses a JSON/YAML/TOML/dotenv parameters file. Nested
+// JSON/YAML files are only accepted with flatten; TOML files are always
+// flattened with opts.
+func loadParamsFile(fromFile string, opts parser.FlattenOptions, flatten bool) (map[string]string, error) {
+	file, err := os.Open(fromFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", fromFile, err)
+	}
+	defer file.Close()
+
+	var paramMap map[string]string
+	ext := strings.ToLower(filepath.Ext(fromFile))
+	// Dotenv files are usually named .env or .env.<environment>
+	if base := filepath.Base(fromFile); base == ".env" || strings.HasPrefix(base, ".env.") {
+		ext = ".env"
+	}
+
+	switch ext {
+	case ".json":
+		if flatten {
+			paramMap, err = parser.ParseJSONParamsFlattened(file, opts)
+		} else {
+			paramMap, err = parser.ParseJSONParams(file)
+		}
+	case ".yaml", ".yml":
+		if flatten {
+			paramMap, err = parser.ParseYAMLParamsFlattened(file, opts)
+		} else {
+			paramMap, err = parser.ParseYAMLParams(file)
+		}
+	case ".toml":
+		paramMap, err = parser.ParseTOMLParams(file, opts)
+	case ".env":
+		paramMap, err = parser.ParseDotenvParams(file)
+	default:
+		return nil, fmt.Errorf("unsupported file format: %s (supported: .json, .yaml, .yml, .toml, .env)", ext)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse parameters file: %w", err)
+	}
+
+	return paramMap, nil
+}
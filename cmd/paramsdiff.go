@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/output"
+)
+
+// Kinds of param differences
+const (
+	paramAdded     = "added"
+	paramRemoved   = "removed"
+	paramChanged   = "changed"
+	paramUnchanged = "unchanged"
+)
+
+var paramsCmd = &cobra.Command{
+	Use:   "params",
+	Short: "Param query commands",
+	Long:  `Commands for reading params logged to MLflow runs.`,
+}
+
+var paramsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Print the params of a run",
+	Long:  `Fetch the params of a run and print them as a text table, CSV, JSON or JSON Lines.`,
+	RunE:  paramsGet,
+}
+
+var paramsDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the params of two runs",
+	Long: `Compare the params of a run against a baseline run and print every param that
+was added, removed or changed. With --exit-code the command exits with status 1
+if there are differences, like git diff, so CI can fail or report on them.`,
+	RunE: paramsDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(paramsCmd)
+	paramsCmd.AddCommand(paramsGetCmd)
+
+	// Params get command flags
+	paramsGetCmd.Flags().String("run-id", "", "Run ID (required)")
+	paramsGetCmd.Flags().StringArray("key", []string{}, "Param key to print (can be specified multiple times; default: all)")
+	paramsGetCmd.Flags().StringP("output", "o", output.FormatTable, "Output format (csv/json/jsonl/table)")
+	paramsGetCmd.MarkFlagRequired("run-id")
+
+	// Params diff command flags
+	paramsCmd.AddCommand(paramsDiffCmd)
+	paramsDiffCmd.Flags().String("run-id", "", "Run ID of the candidate (required)")
+	paramsDiffCmd.Flags().String("baseline", "", "Run ID of the baseline (required)")
+	paramsDiffCmd.Flags().Bool("all", false, "Also print unchanged params")
+	paramsDiffCmd.Flags().Bool("exit-code", false, "Exit with status 1 if the params differ")
+	paramsDiffCmd.Flags().StringP("output", "o", output.FormatTable, "Output format (csv/json/jsonl/table)")
+	paramsDiffCmd.MarkFlagRequired("run-id")
+	paramsDiffCmd.MarkFlagRequired("baseline")
+
+	registerExamples(paramsGetCmd,
+		example{Command: "mlflow-cli params get --run-id <run-id>", Runnable: true},
+		example{Command: "mlflow-cli params get --run-id <run-id> --key lr --key batch_size --output json", Runnable: true},
+	)
+	registerExamples(paramsDiffCmd,
+		example{Command: "mlflow-cli params diff --baseline <baseline-run-id> --run-id <candidate-run-id>"},
+		example{Command: "mlflow-cli params diff --baseline <baseline-run-id> --run-id <candidate-run-id> --output json --exit-code"},
+	)
+}
+
+func paramsGet(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	keys, _ := cmd.Flags().GetStringArray("key")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+
+	run, err := client.GetRun(context.Background(), runID)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		keys = sortedParamKeys(run.Params)
+	}
+
+	table := output.NewTable("key", "value")
+	for _, key := range keys {
+		value, ok := run.Params[key]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Warning: run %s has no param %s\n", runID, key)
+			continue
+		}
+		table.Append(key, value)
+	}
+
+	return output.Write(os.Stdout, format, table)
+}
+
+func paramsDiff(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	baselineID, _ := cmd.Flags().GetString("baseline")
+	all, _ := cmd.Flags().GetBool("all")
+	exitCode, _ := cmd.Flags().GetBool("exit-code")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	baseline, err := client.GetRun(ctx, baselineID)
+	if err != nil {
+		return err
+	}
+	candidate, err := client.GetRun(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	// Union of both runs' keys
+	union := make(map[string]string, len(baseline.Params)+len(candidate.Params))
+	for key, value := range baseline.Params {
+		union[key] = value
+	}
+	for key, value := range candidate.Params {
+		union[key] = value
+	}
+
+	table := output.NewTable("key", "change", "baseline", "candidate")
+	differences := 0
+	for _, key := range sortedParamKeys(union) {
+		baselineValue, inBaseline := baseline.Params[key]
+		candidateValue, inCandidate := candidate.Params[key]
+
+		var change string
+		switch {
+		case !inBaseline:
+			change = paramAdded
+		case !inCandidate:
+			change = paramRemoved
+		case baselineValue != candidateValue:
+			change = paramChanged
+		default:
+			change = paramUnchanged
+		}
+
+		if change != paramUnchanged {
+			differences++
+		} else if !all {
+			continue
+		}
+		table.Append(key, change, nullableParam(baselineValue, inBaseline), nullableParam(candidateValue, inCandidate))
+	}
+
+	if err := output.Write(os.Stdout, format, table); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d of %d params differ\n", differences, len(union))
+
+	if exitCode && differences > 0 {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return &ExitError{Code: 1}
+	}
+	return nil
+}
+
+// sortedParamKeys returns the keys of params in order
+func sortedParamKeys(params map[string]string) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// nullableParam returns nil for params a run does not have, so that JSON
+// output distinguishes them from empty values
+func nullableParam(value string, ok bool) any {
+	if !ok {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/units"
+)
+
+// pickerRunCount is the number of recent runs offered by the run picker
+const pickerRunCount = 200
+
+// enableRunPicker lets the commands below cmd whose --run-id flag is required
+// pick the run interactively when it is omitted. Cobra checks required flags
+// before any hook of the command runs, so the check moves into PreRunE.
+func enableRunPicker(cmd *cobra.Command) {
+	for _, child := range cmd.Commands() {
+		enableRunPicker(child)
+	}
+
+	flag := cmd.Flags().Lookup("run-id")
+	if flag == nil || flag.Annotations[cobra.BashCompOneRequiredFlag] == nil {
+		return
+	}
+	delete(flag.Annotations, cobra.BashCompOneRequiredFlag)
+
+	preRunE := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("run-id") {
+			runID, err := pickRun(cmd)
+			if err != nil {
+				return err
+			}
+			cmd.Flags().Set("run-id", runID)
+		}
+		if preRunE != nil {
+			return preRunE(cmd, args)
+		}
+		return nil
+	}
+}
+
+// pickRun asks for one of the recent runs of the selected experiment. Without
+// --interactive or a terminal, the run ID is a required flag as usual.
+func pickRun(cmd *cobra.Command) (string, error) {
+	missing := fmt.Errorf(`required flag(s) "run-id" not set`)
+	if !viper.GetBool("interactive") {
+		return "", missing
+	}
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stderr) {
+		return "", fmt.Errorf("%w (--interactive needs a terminal)", missing)
+	}
+
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	ctx := context.Background()
+	experimentID, err := resolveExperimentID(ctx, cmd, client, cfg)
+	if err != nil {
+		return "", err
+	}
+	runs, err := client.RecentRuns(ctx, experimentID, pickerRunCount)
+	if err != nil {
+		return "", err
+	}
+	if len(runs) == 0 {
+		return "", fmt.Errorf("experiment %s has no runs to pick from", experimentID)
+	}
+
+	now := time.Now()
+	labels := make([]string, len(runs))
+	for i, run := range runs {
+		labels[i] = runLabel(run, now)
+	}
+
+	// The picker is drawn on stderr so that stdout stays clean for scripts
+	prompt := promptui.Select{
+		Label:             fmt.Sprintf("Select a run of experiment %s (type to search)", experimentID),
+		Items:             labels,
+		Size:              12,
+		Stdout:            os.Stderr,
+		StartInSearchMode: true,
+		Searcher: func(input string, index int) bool {
+			return fuzzyMatch(input, labels[index])
+		},
+	}
+	index, _, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("no run selected: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Using run %s\n", runs[index].RunID)
+	return runs[index].RunID, nil
+}
+
+// runLabel describes a run in the picker
+func runLabel(run *models.RunInfo, now time.Time) string {
+	return fmt.Sprintf("%s  %-30s  %-8s  %s", run.RunID, run.RunName, run.Status, units.FormatRelativeTime(run.StartTime, now))
+}
+
+// fuzzyMatch reports whether the characters of input appear in text in order,
+// ignoring case and spaces, so that e.g. "rsfail" matches "resnet ... FAILED"
+func fuzzyMatch(input, text string) bool {
+	text = strings.ToLower(text)
+	for _, r := range strings.ToLower(input) {
+		if r == ' ' {
+			continue
+		}
+		i := strings.IndexRune(text, r)
+		if i < 0 {
+			return false
+		}
+		text = text[i+len(string(r)):]
+	}
+	return true
+}
+
+// isTerminal reports whether f is a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+// planDocument is the machine-readable plan written by --plan
+type planDocument struct {
+	DryRun      bool                    `json:"dry_run"`
+	TrackingURI string                  `json:"tracking_uri"`
+	Requests    []mlflow.PlannedRequest `json:"requests"`
+}
+
+// reportDryRun prints the requests recorded during a dry run to stderr and
+// writes them to the --plan file
+func reportDryRun() error {
+	if !viper.GetBool("dry_run") {
+		return nil
+	}
+
+	requests := mlflow.DryRunPlan().Requests()
+	fmt.Fprintf(os.Stderr, "Dry run: %d requests were not sent\n", len(requests))
+	for _, request := range requests {
+		fmt.Fprintf(os.Stderr, "  %s %s (%d bytes)\n", request.Method, request.URL, request.PayloadBytes)
+	}
+
+	if planFile == "" {
+		return nil
+	}
+
+	plan := planDocument{
+		DryRun:      true,
+		TrackingURI: viper.GetString("tracking_uri"),
+		Requests:    requests,
+	}
+	if plan.Requests == nil {
+		plan.Requests = []mlflow.PlannedRequest{}
+	}
+
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode plan: %w", err)
+	}
+	if err := os.WriteFile(planFile, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+	return nil
+}
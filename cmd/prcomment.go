@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/github"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/provenance"
+)
+
+// addPRCommentFlag registers --pr-comment on commands that end runs
+func addPRCommentFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("pr-comment", false, "Comment the run URL and final metrics on the GitHub pull request (token from GITHUB_TOKEN or GH_TOKEN)")
+}
+
+// commentOnPullRequest posts a summary of the ended run on the pull request
+// it is linked to if --pr-comment is set. Failures are reported as warnings,
+// as the run itself has been recorded.
+func commentOnPullRequest(ctx context.Context, cmd *cobra.Command, client *mlflow.Client, dryRun bool, runID string) {
+	if enabled, _ := cmd.Flags().GetBool("pr-comment"); !enabled {
+		return
+	}
+	if dryRun {
+		fmt.Fprintf(os.Stderr, "Dry run: not commenting on the pull request\n")
+		return
+	}
+
+	runInfo, err := client.GetRun(ctx, runID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to comment on pull request: %v\n", err)
+		return
+	}
+
+	pr := provenance.PullRequestFromTags(runInfo.Tags)
+	if pr == nil {
+		pr = provenance.DetectPullRequest()
+	}
+	if pr == nil {
+		fmt.Fprintf(os.Stderr, "Warning: --pr-comment is set but the run is not linked to a pull request\n")
+		return
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GH_TOKEN")
+	}
+	if token == "" {
+		fmt.Fprintf(os.Stderr, "Warning: --pr-comment requires GITHUB_TOKEN or GH_TOKEN\n")
+		return
+	}
+
+	body := pullRequestComment(runInfo.RunName, runInfo.Status, client.RunURL(runInfo.ExperimentID, runID), runInfo.Metrics)
+	if err := github.PostIssueComment(ctx, pr.APIURL, pr.Repository, pr.Number, token, body); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to comment on pull request: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Commented on %s#%d\n", pr.Repository, pr.Number)
+}
+
+// pullRequestComment renders the markdown body of a run summary comment
+func pullRequestComment(runName, status, runURL string, metrics map[string]float64) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**MLflow run [%s](%s)** finished with status `%s`\n", runName, runURL, status)
+
+	if len(metrics) > 0 {
+		keys := make([]string, 0, len(metrics))
+		for key := range metrics {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		b.WriteString("\n| Metric | Value |\n|---|---|\n")
+		for _, key := range keys {
+			fmt.Fprintf(&b, "| %s | %g |\n", key, metrics[key])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// progressPrinter shows a single updating progress line on stderr. Nothing is
+// printed when stderr is not a terminal, so logs and pipelines stay clean.
+type progressPrinter struct {
+	label   string
+	enabled bool
+	shown   bool
+}
+
+func newProgressPrinter(label string) *progressPrinter {
+	info, err := os.Stderr.Stat()
+	return &progressPrinter{
+		label:   label,
+		enabled: err == nil && info.Mode()&os.ModeCharDevice != 0,
+	}
+}
+
+// Update redraws the progress line
+func (p *progressPrinter) Update(done, total int) {
+	if !p.enabled || total == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\r%s: %d/%d (%d%%)", p.label, done, total, done*100/total)
+	p.shown = true
+}
+
+// Done ends the progress line
+func (p *progressPrinter) Done() {
+	if p.shown {
+		fmt.Fprintln(os.Stderr)
+		p.shown = false
+	}
+}
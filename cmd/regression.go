@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/output"
+	"github.com/imishinist/mlflow-cli/internal/regression"
+)
+
+var assertRegressionCmd = &cobra.Command{
+	Use:   "assert-regression",
+	Short: "Fail if a run's metrics regressed against a baseline run",
+	Long: `Compare the final metrics of a run against a baseline run and exit with status 1
+if any metric is worse than the baseline by more than the tolerance, or is
+missing from either run.
+
+Metrics are given as name[:max|min[:tolerance]]. max (the default) means
+higher is better, min means lower is better. Tolerances are absolute values
+such as 0.01 or percentages of the baseline value such as 1%.
+
+The comparison is printed and uploaded to the run as a JSON report artifact.`,
+	RunE: assertRegression,
+}
+
+func init() {
+	rootCmd.AddCommand(assertRegressionCmd)
+
+	// Assert regression command flags
+	assertRegressionCmd.Flags().String("run-id", "", "Run ID of the candidate (required)")
+	assertRegressionCmd.Flags().String("baseline-run-id", "", "Run ID of the baseline (required)")
+	assertRegressionCmd.Flags().StringArray("metric", []string{}, "Metric to compare as name[:max|min[:tolerance]] (required, can be specified multiple times)")
+	assertRegressionCmd.Flags().String("tolerance", "0", "Allowed regression of metrics without their own tolerance, e.g. 0.01 or 1%")
+	assertRegressionCmd.Flags().String("report-path", "regression_report.json", "Artifact path of the JSON report on the candidate run")
+	assertRegressionCmd.Flags().Bool("no-report", false, "Do not upload the report artifact")
+	assertRegressionCmd.Flags().StringP("output", "o", output.FormatTable, "Output format (csv/json/jsonl/table)")
+	assertRegressionCmd.MarkFlagRequired("run-id")
+	assertRegressionCmd.MarkFlagRequired("baseline-run-id")
+	assertRegressionCmd.MarkFlagRequired("metric")
+
+	registerExamples(assertRegressionCmd,
+		example{Command: `mlflow-cli assert-regression --run-id <run-id> --baseline-run-id <baseline-run-id> \
+  --metric accuracy --tolerance 1%`},
+		example{
+			Description: "Lower is better for loss, with its own absolute tolerance",
+			Command: `mlflow-cli assert-regression --run-id <run-id> --baseline-run-id <baseline-run-id> \
+  --metric accuracy --metric loss:min:0.05`,
+		},
+	)
+}
+
+// regressionReport is the report artifact of assert-regression
+type regressionReport struct {
+	RunID         string                   `json:"run_id"`
+	BaselineRunID string                   `json:"baseline_run_id"`
+	Passed        bool                     `json:"passed"`
+	Failures      int                      `json:"failures"`
+	Metrics       []regressionReportMetric `json:"metrics"`
+}
+
+// regressionReportMetric is the comparison of one metric in the report
+type regressionReportMetric struct {
+	Metric        string   `json:"metric"`
+	Direction     string   `json:"direction"`
+	Tolerance     string   `json:"tolerance"`
+	Baseline      *float64 `json:"baseline"`
+	Candidate     *float64 `json:"candidate"`
+	Delta         *float64 `json:"delta"`
+	DeltaPercent  *float64 `json:"delta_percent"`
+	AllowedChange float64  `json:"allowed_change"`
+	Status        string   `json:"status"`
+}
+
+func assertRegression(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	baselineID, _ := cmd.Flags().GetString("baseline-run-id")
+	metricSpecs, _ := cmd.Flags().GetStringArray("metric")
+	toleranceFlag, _ := cmd.Flags().GetString("tolerance")
+	reportPath, _ := cmd.Flags().GetString("report-path")
+	noReport, _ := cmd.Flags().GetBool("no-report")
+	format, _ := cmd.Flags().GetString("output")
+
+	if err := output.ValidateFormat(format); err != nil {
+		return err
+	}
+	if !noReport && reportPath == "" {
+		return fmt.Errorf("--report-path must not be empty (use --no-report to skip the report)")
+	}
+	defaultTolerance, err := regression.ParseTolerance(toleranceFlag)
+	if err != nil {
+		return err
+	}
+	checks := make([]regression.Check, 0, len(metricSpecs))
+	for _, spec := range metricSpecs {
+		check, err := regression.ParseCheck(spec, defaultTolerance)
+		if err != nil {
+			return err
+		}
+		checks = append(checks, check)
+	}
+
+	ctx := context.Background()
+	baseline, err := client.GetRun(ctx, baselineID)
+	if err != nil {
+		return err
+	}
+	candidate, err := client.GetRun(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	report := regressionReport{RunID: runID, BaselineRunID: baselineID}
+	table := output.NewTable("metric", "direction", "baseline", "candidate", "delta", "delta_percent", "tolerance", "status")
+	for _, check := range checks {
+		result := regression.Compare(check, baseline.Metrics, candidate.Metrics)
+		if result.Status != regression.StatusOK {
+			report.Failures++
+		}
+		if result.Status == regression.StatusMissing {
+			fmt.Fprintf(os.Stderr, "Warning: metric %s is missing from %s\n", check.Metric, missingFrom(result))
+		}
+
+		report.Metrics = append(report.Metrics, regressionReportMetric{
+			Metric:        check.Metric,
+			Direction:     check.Direction,
+			Tolerance:     check.Tolerance.String(),
+			Baseline:      result.Baseline,
+			Candidate:     result.Candidate,
+			Delta:         result.Delta,
+			DeltaPercent:  result.RelativeDelta,
+			AllowedChange: result.Allowed,
+			Status:        result.Status,
+		})
+		table.Append(check.Metric, check.Direction, nullableFloat(result.Baseline), nullableFloat(result.Candidate),
+			nullableFloat(result.Delta), nullableFloat(result.RelativeDelta), check.Tolerance.String(), result.Status)
+	}
+	report.Passed = report.Failures == 0
+
+	if err := output.Write(os.Stdout, format, table); err != nil {
+		return err
+	}
+
+	if !noReport {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+		if err := client.UploadArtifactFromReader(ctx, runID, bytes.NewReader(data), int64(len(data)), reportPath); err != nil {
+			return fmt.Errorf("failed to upload report: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Uploaded report to artifact %s\n", reportPath)
+	}
+
+	if report.Failures > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d metrics regressed or are missing compared to baseline run %s\n", report.Failures, len(checks), baselineID)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return &ExitError{Code: 1}
+	}
+	fmt.Fprintf(os.Stderr, "No regressions against baseline run %s\n", baselineID)
+	return nil
+}
+
+// missingFrom names the runs a compared metric is missing from
+func missingFrom(result regression.Result) string {
+	switch {
+	case result.Baseline == nil && result.Candidate == nil:
+		return "both runs"
+	case result.Baseline == nil:
+		return "the baseline run"
+	default:
+		return "the candidate run"
+	}
+}
+
+// nullableFloat returns nil for values that could not be computed, so that
+// JSON output contains null
+func nullableFloat(value *float64) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
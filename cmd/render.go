@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+var modelRenderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render a deployment manifest for a registered model version",
+	Long: `Resolve a registered model version by alias or version number and fill a Go
+text/template with the model URI, version, listing digest, and run metadata,
+e.g. to generate Kubernetes manifests for GitOps.
+
+Template fields:
+  .Name .Version .Alias .URI .Source .Status .Stage .Description
+  .ListingDigest (SHA-256 over the paths and sizes of the model files, not
+  their content)
+  .Tags (model version tags)
+  .Run.RunID .Run.ExperimentID .Run.RunName .Run.ArtifactURI
+  .Run.Tags .Run.Params .Run.Metrics
+
+Template functions: lower, upper, replace, quote, default`,
+	RunE: modelRender,
+}
+
+func init() {
+	modelCmd.AddCommand(modelRenderCmd)
+
+	// Model render command flags
+	modelRenderCmd.Flags().String("name", "", "Registered model name (required)")
+	modelRenderCmd.Flags().String("alias", "", "Model alias to resolve, e.g. champion")
+	modelRenderCmd.Flags().String("version", "", "Model version")
+	modelRenderCmd.Flags().String("template", "", "Template file (required)")
+	modelRenderCmd.Flags().String("output", "", "Output file (default: stdout)")
+	modelRenderCmd.MarkFlagRequired("name")
+	modelRenderCmd.MarkFlagRequired("template")
+	modelRenderCmd.MarkFlagsMutuallyExclusive("alias", "version")
+	modelRenderCmd.MarkFlagsOneRequired("alias", "version")
+
+	registerExamples(modelRenderCmd,
+		example{Command: `mlflow-cli model render --name fraud-detector --alias champion \
+  --template k8s-deploy.yaml.tmpl --output deploy.yaml`},
+	)
+}
+
+// modelRenderData is the data passed to deployment templates
+type modelRenderData struct {
+	Name          string
+	Version       string
+	Alias         string
+	URI           string
+	Source        string
+	Status        string
+	Stage         string
+	Description   string
+	ListingDigest string
+	Tags          map[string]string
+	Run           *models.RunInfo
+}
+
+// renderFuncs are the helper functions available in deployment templates
+var renderFuncs = template.FuncMap{
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"replace": func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"quote":   strconv.Quote,
+	"default": func(fallback, value string) string {
+		if value == "" {
+			return fallback
+		}
+		return value
+	},
+}
+
+func modelRender(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	name, _ := cmd.Flags().GetString("name")
+	alias, _ := cmd.Flags().GetString("alias")
+	version, _ := cmd.Flags().GetString("version")
+	templateFile, _ := cmd.Flags().GetString("template")
+	outputFile, _ := cmd.Flags().GetString("output")
+
+	// Parse the template first so that mistakes fail before any API call
+	tmpl, err := template.New(filepath.Base(templateFile)).Funcs(renderFuncs).Option("missingkey=error").ParseFiles(templateFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	ctx := context.Background()
+
+	// Resolve model version
+	var modelVersion *models.ModelVersion
+	if alias != "" {
+		modelVersion, err = client.GetModelVersionByAlias(ctx, name, alias)
+	} else {
+		modelVersion, err = client.GetModelVersion(ctx, name, version)
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := buildModelRenderData(ctx, client, modelVersion, alias)
+	if err != nil {
+		return err
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	if outputFile == "" {
+		_, err := os.Stdout.Write(rendered.Bytes())
+		return err
+	}
+	if err := os.WriteFile(outputFile, rendered.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputFile, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Successfully rendered model %s version %s to %s\n", name, modelVersion.Version, outputFile)
+	return nil
+}
+
+// buildModelRenderData collects template data for a model version, including
+// the metadata of the run that produced it
+func buildModelRenderData(ctx context.Context, client *mlflow.Client, modelVersion *models.ModelVersion, alias string) (*modelRenderData, error) {
+	data := &modelRenderData{
+		Name:        modelVersion.Name,
+		Version:     modelVersion.Version,
+		Alias:       alias,
+		URI:         fmt.Sprintf("models:/%s/%s", modelVersion.Name, modelVersion.Version),
+		Source:      modelVersion.Source,
+		Status:      string(modelVersion.Status),
+		Stage:       modelVersion.CurrentStage,
+		Description: modelVersion.Description,
+		Tags:        modelVersion.Tags,
+		Run:         &models.RunInfo{},
+	}
+
+	if modelVersion.RunID == "" {
+		fmt.Fprintf(os.Stderr, "Warning: model version has no source run; run metadata and listing digest are empty\n")
+		return data, nil
+	}
+
+	run, err := client.GetRun(ctx, modelVersion.RunID)
+	if err != nil {
+		return nil, err
+	}
+	data.Run = run
+
+	artifactPath, ok := modelArtifactPath(modelVersion.Source, run)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Warning: source %s is outside the run's artifacts; listing digest is empty\n", modelVersion.Source)
+		return data, nil
+	}
+	data.ListingDigest, err = client.ArtifactListingDigest(ctx, run.RunID, artifactPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute model listing digest: %w", err)
+	}
+
+	return data, nil
+}
+
+// modelArtifactPath returns the path of a model version's source relative to
+// the artifact root of its run
+func modelArtifactPath(source string, run *models.RunInfo) (string, bool) {
+	if rest, found := strings.CutPrefix(source, "runs:/"+run.RunID+"/"); found {
+		return rest, true
+	}
+	if run.ArtifactURI != "" {
+		if rest, found := strings.CutPrefix(source, strings.TrimSuffix(run.ArtifactURI, "/")+"/"); found {
+			return rest, true
+		}
+	}
+	return "", false
+}
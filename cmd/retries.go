@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/output"
+)
+
+// throttleReportThreshold is how long requests must have waited for the
+// client-side rate limit for the summary to be printed without any retries;
+// waits of a few milliseconds are normal between concurrent requests
+const throttleReportThreshold = time.Second
+
+// reportRetries prints a summary of the retries of the command to stderr, if
+// there were any, or if requests waited long for the client-side rate limit,
+// so that operators can tell client-side throttling from server-side
+// rejections. Commands writing JSON get the summary as JSON.
+func reportRetries(cmd *cobra.Command) {
+	summary := mlflow.RetryTelemetry()
+	if summary.Retries == 0 && summary.RateLimited == 0 && summary.ServerErrors == 0 && summary.Throttled < throttleReportThreshold {
+		return
+	}
+
+	if cmd != nil {
+		if format, err := cmd.Flags().GetString("output"); err == nil && (format == output.FormatJSON || format == output.FormatJSONL) {
+			data, _ := json.Marshal(struct {
+				RetrySummary mlflow.RetrySummary `json:"retry_summary"`
+			}{summary})
+			fmt.Fprintln(os.Stderr, string(data))
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Retry summary: %d of %d requests were retries, %s spent in backoff\n",
+		summary.Retries, summary.Requests, summary.Backoff.Round(time.Millisecond))
+	fmt.Fprintf(os.Stderr, "  rate limited by the server (429): %d\n", summary.RateLimited)
+	fmt.Fprintf(os.Stderr, "  server or connection errors:      %d\n", summary.ServerErrors)
+	fmt.Fprintf(os.Stderr, "  waited for the client rate limit: %s\n", summary.Throttled.Round(time.Millisecond))
+}
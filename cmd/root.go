@@ -1,11 +1,20 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/httperr"
+	"github.com/imishinist/mlflow-cli/internal/output"
+	"github.com/imishinist/mlflow-cli/internal/proxy"
+	timeutils "github.com/imishinist/mlflow-cli/internal/time"
+	"github.com/imishinist/mlflow-cli/internal/units"
 )
 
 var rootCmd = &cobra.Command{
@@ -13,23 +22,92 @@ var rootCmd = &cobra.Command{
 	Short: "MLflow Tracking CLI Tool",
 	Long: `A command line tool for MLflow tracking operations.
 Supports logging parameters, metrics, and artifacts to MLflow tracking server.`,
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return reportDryRun()
+	},
 }
 
+var (
+	cfgFile  string
+	planFile string
+)
+
 func Execute() error {
-	return rootCmd.Execute()
+	enableRunPicker(rootCmd)
+	enableRunRefs(rootCmd)
+
+	// The retry summary and the clock skew and sink warnings are also wanted
+	// when the command fails
+	cmd, err := rootCmd.ExecuteC()
+	reportRetries(cmd)
+	reportClockSkew()
+	reportSinkFailures()
+	return err
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
 	// Global flags
-	rootCmd.PersistentFlags().String("tracking-uri", "", "MLflow tracking URI (overrides MLFLOW_TRACKING_URI)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "Config file (default: $HOME/.mlflow-cli.yaml or ./.mlflow-cli.yaml)")
+	rootCmd.PersistentFlags().String("tracking-uri", "", "MLflow tracking URI, or the ARN of a SageMaker MLflow tracking server (overrides MLFLOW_TRACKING_URI)")
+	rootCmd.PersistentFlags().String("tracking-username", "", "Username for basic auth to the tracking server (overrides MLFLOW_TRACKING_USERNAME)")
+	rootCmd.PersistentFlags().String("tracking-password", "", "Password for basic auth to the tracking server (overrides MLFLOW_TRACKING_PASSWORD; prefer the variable, as flags are visible to other users)")
+	rootCmd.PersistentFlags().String("auth", "", "Authentication of tracking server requests: basic or sigv4 (AWS credentials; default for SageMaker tracking server ARNs) (overrides MLFLOW_AUTH)")
 	rootCmd.PersistentFlags().String("experiment-id", "", "Experiment ID (overrides MLFLOW_EXPERIMENT_ID)")
+	rootCmd.PersistentFlags().String("unlock-token", "", "Unlock token of frozen runs to change (overrides MLFLOW_UNLOCK_TOKEN)")
+	rootCmd.PersistentFlags().String("profile", "", "Config file profile to use (overrides MLFLOW_PROFILE)")
+	rootCmd.PersistentFlags().Bool("dry-run", false, "Show the requests that would change the tracking server instead of sending them")
+	rootCmd.PersistentFlags().StringVar(&planFile, "plan", "", "Write the dry-run plan as JSON to this file (implies --dry-run)")
+	rootCmd.PersistentFlags().Bool("show-full-errors", false, "Show error responses of servers in full instead of shortened")
+	rootCmd.PersistentFlags().Bool("interactive", false, "Pick the run from a searchable list of recent runs when --run-id is omitted in a terminal")
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output (also disabled by the NO_COLOR environment variable)")
+	rootCmd.PersistentFlags().String("tz", "", "Time zone to display timestamps in, e.g. Asia/Tokyo or Local (overrides MLFLOW_DISPLAY_TIMEZONE)")
+	rootCmd.PersistentFlags().String("max-memory", "", "Memory budget of bulk operations such as 256MB, spilling buffered data to temporary files beyond it (overrides MLFLOW_MAX_MEMORY)")
+	rootCmd.PersistentFlags().String("clock-skew", "", "Handling of a local clock that differs from the server's: warn, correct (shift metric timestamps taken from the local clock) or ignore (overrides MLFLOW_CLOCK_SKEW)")
+	rootCmd.PersistentFlags().Int("max-retries", 5, "Maximum retries of requests that failed with a connection error, 429 or 502-504 (overrides MLFLOW_MAX_RETRIES)")
+	rootCmd.PersistentFlags().String("timeout", "", "Timeout of each API request attempt, 0 for none (default 1m; overrides MLFLOW_TIMEOUT)")
+	rootCmd.PersistentFlags().String("transfer-timeout", "", "Timeout of the transfer of each artifact file, 0 for none (default none; overrides MLFLOW_TRANSFER_TIMEOUT)")
+	rootCmd.PersistentFlags().String("proxy", "", "Proxy URL of all HTTP requests, e.g. http://proxy.example.com:3128 (overrides MLFLOW_PROXY; default: HTTPS_PROXY/HTTP_PROXY)")
 	viper.BindPFlag("tracking_uri", rootCmd.PersistentFlags().Lookup("tracking-uri"))
+	viper.BindPFlag("tracking_username", rootCmd.PersistentFlags().Lookup("tracking-username"))
+	viper.BindPFlag("tracking_password", rootCmd.PersistentFlags().Lookup("tracking-password"))
+	viper.BindPFlag("auth", rootCmd.PersistentFlags().Lookup("auth"))
 	viper.BindPFlag("experiment_id", rootCmd.PersistentFlags().Lookup("experiment-id"))
+	viper.BindPFlag("dry_run", rootCmd.PersistentFlags().Lookup("dry-run"))
+	viper.BindPFlag("unlock_token", rootCmd.PersistentFlags().Lookup("unlock-token"))
+	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+	viper.BindPFlag("show_full_errors", rootCmd.PersistentFlags().Lookup("show-full-errors"))
+	viper.BindPFlag("display_timezone", rootCmd.PersistentFlags().Lookup("tz"))
+	viper.BindPFlag("no_color", rootCmd.PersistentFlags().Lookup("no-color"))
+	viper.BindPFlag("interactive", rootCmd.PersistentFlags().Lookup("interactive"))
+	viper.BindPFlag("max_memory", rootCmd.PersistentFlags().Lookup("max-memory"))
+	viper.BindPFlag("clock_skew", rootCmd.PersistentFlags().Lookup("clock-skew"))
+	viper.BindPFlag("max_retries", rootCmd.PersistentFlags().Lookup("max-retries"))
+	viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	viper.BindPFlag("transfer_timeout", rootCmd.PersistentFlags().Lookup("transfer-timeout"))
+	viper.BindPFlag("proxy", rootCmd.PersistentFlags().Lookup("proxy"))
 }
 
 func initConfig() {
+	// Config file
+	if cfgFile != "" {
+		viper.SetConfigFile(cfgFile)
+	} else {
+		viper.SetConfigName(".mlflow-cli")
+		viper.SetConfigType("yaml")
+		viper.AddConfigPath(".")
+		if home, err := os.UserHomeDir(); err == nil {
+			viper.AddConfigPath(home)
+		}
+	}
+	if err := viper.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if cfgFile != "" || !errors.As(err, &notFound) {
+			checkError(fmt.Errorf("failed to read config file: %w", err))
+		}
+	}
+
 	// Environment variables
 	viper.SetEnvPrefix("MLFLOW")
 	viper.AutomaticEnv()
@@ -38,11 +116,69 @@ func initConfig() {
 	viper.BindEnv("databricks_host", "DATABRICKS_HOST")
 	viper.BindEnv("databricks_token", "DATABRICKS_TOKEN")
 
+	if planFile != "" {
+		viper.Set("dry_run", true)
+	}
+	checkError(applyProfile(viper.GetString("profile")))
+
 	// Set defaults
 	viper.SetDefault("tracking_uri", "http://localhost:5000")
 	viper.SetDefault("time_resolution", "1m")
 	viper.SetDefault("time_alignment", "floor")
 	viper.SetDefault("step_mode", "auto")
+	viper.SetDefault("aggregate", "none")
+	viper.SetDefault("clock_skew", "warn")
+	viper.SetDefault("timeout", "1m")
+}
+
+// applyProfile merges the settings of a config file profile over the
+// top-level settings, and applies the settings used outside of clients
+func applyProfile(name string) error {
+	// Profile settings override the top-level settings of the config file
+	if err := config.ApplyProfile(name); err != nil {
+		return err
+	}
+
+	httperr.ShowFull = viper.GetBool("show_full_errors")
+	if name := viper.GetString("display_timezone"); name != "" {
+		location, err := timeutils.LoadLocation(name)
+		if err != nil {
+			return fmt.Errorf("invalid display time zone: %s (expected an IANA name such as Asia/Tokyo)", name)
+		}
+		output.Location = location
+	}
+	output.Color = colorEnabled()
+	if maxMemory := viper.GetString("max_memory"); maxMemory != "" {
+		if _, err := units.ParseSize(maxMemory); err != nil {
+			return fmt.Errorf("invalid max memory: %w", err)
+		}
+	}
+	for _, key := range []string{"timeout", "transfer_timeout"} {
+		if value := viper.GetString(key); value != "" {
+			timeout, err := units.ParseDuration(value)
+			if err == nil && timeout < 0 {
+				err = fmt.Errorf("must be >= 0")
+			}
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", strings.ReplaceAll(key, "_", " "), err)
+			}
+		}
+	}
+	if proxyURL := viper.GetString("proxy"); proxyURL != "" {
+		if err := proxy.Set(proxyURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// colorEnabled reports whether output is colored: only for terminals, and not
+// if disabled with --no-color or a non-empty NO_COLOR (https://no-color.org)
+func colorEnabled() bool {
+	if viper.GetBool("no_color") || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return isTerminal(os.Stdout)
 }
 
 func checkError(err error) {
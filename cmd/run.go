@@ -2,7 +2,12 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -10,6 +15,7 @@ import (
 	"github.com/imishinist/mlflow-cli/internal/config"
 	"github.com/imishinist/mlflow-cli/internal/mlflow"
 	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/provenance"
 )
 
 // Valid run statuses
@@ -35,8 +41,13 @@ var runStartCmd = &cobra.Command{
 var runEndCmd = &cobra.Command{
 	Use:   "end",
 	Short: "End an MLflow run",
-	Long:  "End an existing MLflow run",
-	RunE:  runEnd,
+	Long: `End an existing MLflow run.
+
+With --run-ids-file, all runs listed in the file (one run ID per line, "-" for
+stdin) are ended with up to --parallelism concurrent requests, e.g. to clean up
+after a cancelled hyperparameter sweep. The result of each run is reported, and
+the command fails if any run could not be ended.`,
+	RunE: runEnd,
 }
 
 func init() {
@@ -45,15 +56,36 @@ func init() {
 	runCmd.AddCommand(runEndCmd)
 
 	// Start command flags
-	runStartCmd.Flags().String("experiment-id", "", "Experiment ID (overrides MLFLOW_EXPERIMENT_ID)")
-	runStartCmd.Flags().String("run-name", "", "Run name (default: timestamp-based)")
-	runStartCmd.Flags().StringArray("tag", []string{}, "Tags in key=value format")
-	runStartCmd.Flags().String("description", "", "Run description")
+	addRunConfigFlags(runStartCmd)
+	runStartCmd.Flags().Bool("log-environment", false, "Upload pip/conda/system environment snapshots as environment/ artifacts")
+	runStartCmd.Flags().Bool("log-hardware", false, "Record CPU/memory/GPU inventory as hardware.json artifact and tags")
 
 	// End command flags
-	runEndCmd.Flags().String("run-id", "", "Run ID to end (required)")
+	runEndCmd.Flags().String("run-id", "", "Run ID to end")
+	runEndCmd.Flags().String("run-ids-file", "", "File listing run IDs to end, one per line (- for stdin)")
+	runEndCmd.Flags().Int("parallelism", 8, "Number of runs ended concurrently (with --run-ids-file)")
 	runEndCmd.Flags().String("status", "FINISHED", "End status (FINISHED/FAILED/KILLED)")
-	runEndCmd.MarkFlagRequired("run-id")
+	addPRCommentFlag(runEndCmd)
+	runEndCmd.MarkFlagsOneRequired("run-id", "run-ids-file")
+	runEndCmd.MarkFlagsMutuallyExclusive("run-id", "run-ids-file")
+	runEndCmd.MarkFlagsMutuallyExclusive("run-ids-file", "pr-comment")
+
+	registerExamples(runStartCmd,
+		example{Command: `RUN_ID=$(mlflow-cli run start --run-name baseline --tag stage=dev)`},
+		example{
+			Description: "Start a child run of a sweep",
+			Command:     `mlflow-cli run start --run-name "sweep-lr-0.01" --parent-run-id "$PARENT_RUN_ID"`,
+		},
+	)
+
+	registerExamples(runEndCmd,
+		example{Command: "mlflow-cli run end --run-id <run-id> --status FAILED"},
+		example{
+			Description: "Kill all runs of a sweep that are still running",
+			Command: `mlflow-cli run search --filter "attributes.status = 'RUNNING' and tags.sweep = 'sweep-42'" --output ids |
+  mlflow-cli run end --run-ids-file - --status KILLED`,
+		},
+	)
 }
 
 func runStart(cmd *cobra.Command, args []string) error {
@@ -67,6 +99,7 @@ func runStart(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	hardware := detectHardware(cmd, runConfig)
 
 	// Create run
 	ctx := context.Background()
@@ -75,19 +108,107 @@ func runStart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create run: %w", err)
 	}
 
+	if logEnv, _ := cmd.Flags().GetBool("log-environment"); logEnv {
+		logEnvironment(ctx, client, runInfo.RunID)
+	}
+	if hardware != nil {
+		logHardware(ctx, client, runInfo.RunID, hardware)
+	}
+
 	// Output only run ID for shell scripting
 	fmt.Printf("%s\n", runInfo.RunID)
 
 	return nil
 }
 
+// logEnvironment captures environment snapshots and uploads them to the run.
+// Failures are reported as warnings so that they never abort the run itself.
+func logEnvironment(ctx context.Context, client *mlflow.Client, runID string) {
+	dir, err := os.MkdirTemp("", "mlflow-cli-env-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to create temp directory: %v\n", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	files, err := provenance.CaptureEnvironment(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to capture environment: %v\n", err)
+		return
+	}
+
+	for _, file := range files {
+		artifactPath := path.Join(provenance.EnvironmentArtifactDir, filepath.Base(file))
+		if err := client.UploadArtifact(ctx, runID, file, artifactPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to upload %s: %v\n", artifactPath, err)
+		}
+	}
+}
+
+// detectHardware collects the hardware inventory when --log-hardware is set and
+// adds its summary tags to the run config without overriding user tags
+func detectHardware(cmd *cobra.Command, runConfig *models.RunConfig) *provenance.Hardware {
+	if logHW, _ := cmd.Flags().GetBool("log-hardware"); !logHW {
+		return nil
+	}
+
+	hardware := provenance.DetectHardware()
+	for key, value := range hardware.Tags() {
+		if _, exists := runConfig.Tags[key]; !exists {
+			runConfig.Tags[key] = value
+		}
+	}
+	return hardware
+}
+
+// logHardware uploads the hardware inventory as hardware.json. Failures are
+// reported as warnings so that they never abort the run itself.
+func logHardware(ctx context.Context, client *mlflow.Client, runID string, hardware *provenance.Hardware) {
+	data, err := json.MarshalIndent(hardware, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to encode hardware inventory: %v\n", err)
+		return
+	}
+
+	dir, err := os.MkdirTemp("", "mlflow-cli-hardware-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to create temp directory: %v\n", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, provenance.HardwareArtifactPath)
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write hardware inventory: %v\n", err)
+		return
+	}
+
+	if err := client.UploadArtifact(ctx, runID, file, provenance.HardwareArtifactPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to upload %s: %v\n", provenance.HardwareArtifactPath, err)
+	}
+}
+
+// addRunConfigFlags registers the flags consumed by buildRunConfig
+func addRunConfigFlags(cmd *cobra.Command) {
+	cmd.Flags().String("experiment-id", "", "Experiment ID (overrides MLFLOW_EXPERIMENT_ID)")
+	cmd.Flags().String("run-name", "", "Run name (default: timestamp-based)")
+	cmd.Flags().StringArray("tag", []string{}, "Tags in key=value format")
+	addTagsFileFlag(cmd)
+	cmd.Flags().String("description", "", "Run description")
+	cmd.Flags().String("parent-run-id", "", "Parent run ID for nested runs")
+	cmd.Flags().String("request-token", "", "Token identifying this run creation; an active run created with the same token is reused, e.g. when a CI job is retried (default: generated)")
+}
+
 // buildRunConfig constructs RunConfig from command flags and configuration
 func buildRunConfig(cmd *cobra.Command, cfg *config.Config) (*models.RunConfig, error) {
 	// Parse flags
 	experimentID, _ := cmd.Flags().GetString("experiment-id")
 	runName, _ := cmd.Flags().GetString("run-name")
 	tags, _ := cmd.Flags().GetStringArray("tag")
+	tagsFile, _ := cmd.Flags().GetString("tags-file")
 	description, _ := cmd.Flags().GetString("description")
+	parentRunID, _ := cmd.Flags().GetString("parent-run-id")
+	requestToken, _ := cmd.Flags().GetString("request-token")
 
 	// Use experiment ID from flag, environment variable, or config
 	if experimentID == "" {
@@ -104,6 +225,16 @@ func buildRunConfig(cmd *cobra.Command, cfg *config.Config) (*models.RunConfig,
 	if err != nil {
 		return nil, err
 	}
+	if err := mergeTagsFile(tagMap, tagsFile); err != nil {
+		return nil, err
+	}
+
+	// Add detected context tags (HPC scheduler, etc.) without overriding user tags
+	for key, value := range provenance.Tags() {
+		if _, exists := tagMap[key]; !exists {
+			tagMap[key] = value
+		}
+	}
 
 	// Build run config
 	runConfig := &models.RunConfig{
@@ -121,6 +252,14 @@ func buildRunConfig(cmd *cobra.Command, cfg *config.Config) (*models.RunConfig,
 		runConfig.Description = &processedDescription
 	}
 
+	if parentRunID != "" {
+		runConfig.ParentRunID = &parentRunID
+	}
+
+	if requestToken != "" {
+		runConfig.RequestToken = &requestToken
+	}
+
 	return runConfig, nil
 }
 
@@ -146,6 +285,8 @@ func runEnd(cmd *cobra.Command, args []string) error {
 
 	// Parse flags
 	runID, _ := cmd.Flags().GetString("run-id")
+	runIDsFile, _ := cmd.Flags().GetString("run-ids-file")
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
 	status, _ := cmd.Flags().GetString("status")
 
 	// Validate status
@@ -154,8 +295,19 @@ func runEnd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid status: %s (valid: FINISHED, FAILED, KILLED)", status)
 	}
 
-	// Update run
 	ctx := context.Background()
+	if runIDsFile != "" {
+		if parallelism < 1 {
+			return fmt.Errorf("--parallelism must be at least 1")
+		}
+		runIDs, err := readRunIDsFile(runIDsFile)
+		if err != nil {
+			return err
+		}
+		return endRuns(ctx, client, runIDs, runStatus, parallelism)
+	}
+
+	// Update run
 	err = client.UpdateRun(ctx, runID, runStatus)
 	if err != nil {
 		return fmt.Errorf("failed to end run: %w", err)
@@ -165,9 +317,66 @@ func runEnd(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Run ID: %s\n", runID)
 	fmt.Printf("Status: %s\n", status)
 
+	commentOnPullRequest(ctx, cmd, client, cfg.DryRun, runID)
+
+	return nil
+}
+
+// endRuns ends many runs concurrently and reports the result of each run in
+// the order of runIDs
+func endRuns(ctx context.Context, client *mlflow.Client, runIDs []string, status models.RunStatus, parallelism int) error {
+	if len(runIDs) == 0 {
+		return fmt.Errorf("no run IDs to end")
+	}
+
+	fmt.Fprintf(os.Stderr, "Ending %d runs as %s...\n", len(runIDs), status)
+	errs := client.EndRuns(ctx, runIDs, status, parallelism)
+
+	failed := 0
+	for i, err := range errs {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to end run %s: %v\n", runIDs[i], err)
+			failed++
+			continue
+		}
+		fmt.Printf("Ended run %s\n", runIDs[i])
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to end %d of %d runs", failed, len(runIDs))
+	}
+	fmt.Fprintf(os.Stderr, "Ended %d runs as %s\n", len(runIDs), status)
 	return nil
 }
 
+// readRunIDsFile reads run IDs, one per line, from a file or "-" for stdin.
+// Blank lines and lines starting with # are skipped, and duplicates are
+// removed.
+func readRunIDsFile(name string) ([]string, error) {
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run IDs: %w", err)
+	}
+
+	var runIDs []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		runID := strings.TrimSpace(line)
+		if runID == "" || strings.HasPrefix(runID, "#") || seen[runID] {
+			continue
+		}
+		seen[runID] = true
+		runIDs = append(runIDs, runID)
+	}
+	return runIDs, nil
+}
+
 // processEscapeSequences processes common escape sequences in strings
 func processEscapeSequences(s string) string {
 	// Replace common escape sequences
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// runRefScheme prefixes run references that name the config file profile of
+// the run's tracking server: runs://<profile>/<run-id>
+const runRefScheme = "runs://"
+
+// runIDArgsAnnotation marks commands whose positional arguments are run IDs,
+// which take run references like their --run-id flag
+const runIDArgsAnnotation = "run-id-args"
+
+// enableRunRefs lets the --run-id flags of the commands below cmd take run
+// references, and the positional arguments of commands annotated with
+// runIDArgsAnnotation. Before the command runs, the profile of the references
+// is selected as if given with --profile, and the flag and arguments are set
+// to the run IDs.
+func enableRunRefs(cmd *cobra.Command) {
+	for _, child := range cmd.Commands() {
+		enableRunRefs(child)
+	}
+
+	flag := cmd.Flags().Lookup("run-id")
+	if flag == nil {
+		return
+	}
+
+	preRunE := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if cmd.Annotations[runIDArgsAnnotation] == "" {
+			args = nil
+		}
+		if err := resolveRunRefs(cmd, cmd.Flags().Lookup("run-id"), args); err != nil {
+			return err
+		}
+		if preRunE != nil {
+			return preRunE(cmd, args)
+		}
+		return nil
+	}
+}
+
+// resolveRunRefs replaces the run references of a --run-id flag and of
+// positional arguments with their run IDs, in place for the arguments, and
+// selects their profile. All references of a command must be of the same
+// profile, which bare run IDs belong to as well, as must a profile selected
+// with --profile or MLFLOW_PROFILE.
+func resolveRunRefs(cmd *cobra.Command, flag *pflag.Flag, args []string) error {
+	values := []string{flag.Value.String()}
+	slice, isSlice := flag.Value.(pflag.SliceValue)
+	if isSlice {
+		values = slice.GetSlice()
+	}
+	flagCount := len(values)
+	values = append(values[:flagCount:flagCount], args...)
+
+	profile := explicitProfile(cmd)
+	refs := false
+	runIDs := make([]string, len(values))
+	for i, value := range values {
+		refProfile, runID, err := parseRunRef(value)
+		if err != nil {
+			return err
+		}
+		if refProfile != "" && profile != "" && !strings.EqualFold(refProfile, profile) {
+			return fmt.Errorf("runs of different profiles cannot be combined: %s and %s", profile, refProfile)
+		}
+		if refProfile != "" {
+			profile = refProfile
+			refs = true
+		}
+		runIDs[i] = runID
+	}
+	if !refs {
+		return nil
+	}
+	copy(args, runIDs[flagCount:])
+
+	if err := selectProfile(profile); err != nil {
+		return err
+	}
+	if isSlice {
+		return slice.Replace(runIDs[:flagCount])
+	}
+	return flag.Value.Set(runIDs[0])
+}
+
+// explicitProfile returns the profile selected with --profile or
+// MLFLOW_PROFILE, or an empty string
+func explicitProfile(cmd *cobra.Command) string {
+	if flag := cmd.Flag("profile"); flag != nil && flag.Changed {
+		return flag.Value.String()
+	}
+	return os.Getenv("MLFLOW_PROFILE")
+}
+
+// parseRunRef splits a run reference runs://<profile>/<run-id> into the
+// profile and the run ID. Bare run IDs have no profile.
+func parseRunRef(value string) (string, string, error) {
+	rest, ok := strings.CutPrefix(value, runRefScheme)
+	if !ok {
+		return "", value, nil
+	}
+	profile, runID, _ := strings.Cut(rest, "/")
+	if profile == "" || runID == "" || strings.Contains(runID, "/") {
+		return "", "", fmt.Errorf("invalid run reference: %s (expected %s<profile>/<run-id>)", value, runRefScheme)
+	}
+	return profile, runID, nil
+}
+
+// selectProfile switches to a config file profile. The config file is read
+// again first, so that no settings of the profile selected before remain.
+func selectProfile(name string) error {
+	if strings.EqualFold(name, viper.GetString("profile")) {
+		return nil
+	}
+
+	if err := viper.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+	viper.Set("profile", name)
+	return applyProfile(name)
+}
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/output"
+	"github.com/imishinist/mlflow-cli/internal/units"
+)
+
+// formatIDs prints one run ID per line, for piping into xargs
+const formatIDs = "ids"
+
+var runSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search the runs of an experiment",
+	Long: `Search the active runs of an experiment with an MLflow filter expression.
+
+With --output ids only the run IDs are printed, one per line, or separated by
+NUL characters with --null, for piping into xargs. If more runs than --limit
+match, nothing is printed and the command fails, so a too broad filter never
+feeds a bulk operation.
+
+With --local, runs are searched in the local index filled by "index sync"
+instead of on the tracking server, instantly and offline.
+
+--columns selects the printed columns: run attributes (run_id, run_name,
+experiment_id, status, start_time, end_time, artifact_uri) or params.<key>,
+metrics.<key> and tags.<key>. --sort-by orders the runs by any such column.
+
+--older-than keeps only runs started longer ago than a duration such as 2w,
+in addition to --filter.`,
+	RunE: runSearch,
+}
+
+var runDeleteCmd = &cobra.Command{
+	Use:   "delete [run-id...]",
+	Short: "Delete runs",
+	// Run IDs appended by xargs are positional arguments
+	Annotations: map[string]string{runIDArgsAnnotation: "true"},
+	Long: `Mark runs as deleted. Run IDs are given with --run-id or as arguments, so that
+IDs appended by xargs are accepted. Every run is attempted; the command fails if
+any of them could not be deleted.
+
+The deletion is confirmed in a terminal, by typing the number of runs when
+deleting 20 or more. --yes skips the confirmation, --force also for 20 or more
+runs; without a terminal, one of them is required.`,
+	RunE: runDelete,
+}
+
+func init() {
+	runCmd.AddCommand(runSearchCmd)
+	runCmd.AddCommand(runDeleteCmd)
+
+	// Run search command flags
+	addExperimentFlags(runSearchCmd)
+	runSearchCmd.Flags().String("filter", "", "MLflow search filter expression")
+	runSearchCmd.Flags().Int("limit", 1000, "Fail if more runs than this match (0: no limit)")
+	runSearchCmd.Flags().StringP("output", "o", output.FormatTable, "Output format (csv/json/jsonl/table/ids)")
+	runSearchCmd.Flags().BoolP("null", "0", false, "Separate run IDs with NUL instead of newline characters (with --output ids)")
+	runSearchCmd.Flags().Bool("local", false, "Search the local index instead of the tracking server")
+	units.DurationFlag(runSearchCmd.Flags(), "older-than", 0, "Only runs started longer ago than this, e.g. 2w or 36h")
+	addIndexFlag(runSearchCmd)
+	addColumnsFlag(runSearchCmd, defaultRunAttributeColumns)
+	addSortByFlag(runSearchCmd)
+	addAbsoluteTimesFlag(runSearchCmd)
+
+	// Run delete command flags
+	runDeleteCmd.Flags().StringArray("run-id", []string{}, "Run ID to delete (can be specified multiple times)")
+	addConfirmFlags(runDeleteCmd)
+
+	registerExamples(runSearchCmd,
+		example{Command: `mlflow-cli run search --filter "params.lr = '0.1'"`, Runnable: true},
+		example{
+			Description: "Latest runs first, with their learning rate and loss",
+			Command:     "mlflow-cli run search --columns run_id,params.lr,metrics.loss --sort-by start_time:desc",
+			Runnable:    true,
+		},
+		example{
+			Description: "Delete smoke test runs started more than two weeks ago",
+			Command: `mlflow-cli run search --filter "tags.stage = 'smoke'" --older-than 2w --output ids |
+  xargs mlflow-cli run delete --force --run-id`,
+		},
+		example{
+			Description: "Delete all failed runs",
+			Command: `mlflow-cli run search --filter "attributes.status = 'FAILED'" --output ids --null |
+  xargs -0 mlflow-cli run delete --force --run-id`,
+		},
+	)
+	registerExamples(runDeleteCmd,
+		example{Command: "mlflow-cli run delete --run-id <run-id>"},
+		example{Command: `mlflow-cli run search --filter "tags.stage = 'smoke'" --output ids | xargs mlflow-cli run delete --force --run-id`},
+	)
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	filter, _ := cmd.Flags().GetString("filter")
+	limit, _ := cmd.Flags().GetInt("limit")
+	format, _ := cmd.Flags().GetString("output")
+	null, _ := cmd.Flags().GetBool("null")
+	local, _ := cmd.Flags().GetBool("local")
+	olderThan, _ := cmd.Flags().GetDuration("older-than")
+
+	if format != formatIDs {
+		if output.ValidateFormat(format) != nil {
+			return fmt.Errorf("unsupported output format: %s (supported: %s, %s)", format, strings.Join(output.Formats, ", "), formatIDs)
+		}
+		if null {
+			return fmt.Errorf("--null can only be used with --output ids")
+		}
+	} else if cmd.Flags().Changed("columns") {
+		return fmt.Errorf("--columns cannot be used with --output ids")
+	}
+	if limit < 0 {
+		return fmt.Errorf("--limit must be >= 0")
+	}
+	if olderThan < 0 {
+		return fmt.Errorf("--older-than must be >= 0")
+	}
+	if olderThan > 0 {
+		filter = startedBeforeFilter(filter, time.Now().Add(-olderThan))
+	}
+	layout, err := getTableLayout(cmd, defaultRunAttributeColumns, validateRunColumn)
+	if err != nil {
+		return err
+	}
+
+	// Fetch one run more than the limit to detect too broad filters
+	max := 0
+	if limit > 0 {
+		max = limit + 1
+	}
+
+	ctx := context.Background()
+	var runs []*models.RunInfo
+	if local {
+		runs, err = searchIndexedRuns(ctx, cmd, cfg, filter, max)
+	} else {
+		runs, err = searchServerRuns(ctx, cmd, client, cfg, filter, max)
+	}
+	if err != nil {
+		return err
+	}
+	if limit > 0 && len(runs) > limit {
+		return fmt.Errorf("more than %d runs match; narrow --filter or raise --limit", limit)
+	}
+
+	if format == formatIDs {
+		separator := "\n"
+		if null {
+			separator = "\x00"
+		}
+		// Only the run IDs are printed, in the order of --sort-by
+		layout.columns = []string{"run_id"}
+		ids := layout.apply(runTable(runs, layout.sourceColumns()))
+		w := bufio.NewWriter(os.Stdout)
+		for _, row := range ids.Rows {
+			fmt.Fprint(w, row[0], separator)
+		}
+		return w.Flush()
+	}
+
+	table := layout.apply(runTable(runs, layout.sourceColumns()))
+	humanizeTimes(cmd, format, table)
+	return output.Write(os.Stdout, format, table)
+}
+
+// startedBeforeFilter narrows a search filter, whose conditions are all joined
+// by AND, to runs started before a time
+func startedBeforeFilter(filter string, before time.Time) string {
+	condition := fmt.Sprintf("attributes.start_time < %d", before.UnixMilli())
+	if strings.TrimSpace(filter) == "" {
+		return condition
+	}
+	return filter + " AND " + condition
+}
+
+// searchServerRuns searches the runs of the selected experiment on the
+// tracking server
+func searchServerRuns(ctx context.Context, cmd *cobra.Command, client *mlflow.Client, cfg *config.Config, filter string, max int) ([]*models.RunInfo, error) {
+	experimentID, err := resolveExperimentID(ctx, cmd, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.SearchRunsUpTo(ctx, []string{experimentID}, filter, max)
+}
+
+// searchIndexedRuns searches the runs of the selected experiment in the local
+// index
+func searchIndexedRuns(ctx context.Context, cmd *cobra.Command, cfg *config.Config, filter string, max int) ([]*models.RunInfo, error) {
+	ix, _, err := openIndex(cmd, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer ix.Close()
+
+	experiment, err := resolveIndexedExperiment(ctx, cmd, cfg, ix)
+	if err != nil {
+		return nil, err
+	}
+	return ix.SearchRuns(ctx, cfg.TrackingURI, experiment.ExperimentID, filter, max)
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runIDs, _ := cmd.Flags().GetStringArray("run-id")
+	runIDs = append(runIDs, args...)
+
+	if len(runIDs) == 0 {
+		return fmt.Errorf("at least one run ID must be specified via --run-id or arguments")
+	}
+	if err := confirm(cmd, "delete "+plural(len(runIDs), "run"), len(runIDs)); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	failed := 0
+	for _, runID := range runIDs {
+		if err := client.DeleteRun(ctx, runID); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to delete run %s: %v\n", runID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Deleted run %s\n", runID)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d runs", failed, len(runIDs))
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+)
+
+// reportSinkFailures warns about output sinks that failed to receive metrics
+// or params. What was logged to MLflow is not affected by them.
+func reportSinkFailures() {
+	for _, failure := range mlflow.SinkFailures() {
+		fmt.Fprintf(os.Stderr, "Warning: writing to sink %s failed, and %d later writes were skipped: %v\n", failure.Sink, failure.Skipped, failure.Err)
+	}
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/parser"
+	timeutils "github.com/imishinist/mlflow-cli/internal/time"
+)
+
+// metricStreamer buffers metric points arriving incrementally and logs them
+// in batches
+type metricStreamer struct {
+	client     *mlflow.Client
+	runID      string
+	timeConfig models.TimeConfig
+	mapping    map[string]string
+	batchSize  int
+
+	baseTime *time.Time
+	// localBase is set if the base time was read from the local clock
+	localBase bool
+	sequence  int64
+	pending   []models.MetricPoint
+	logged    int
+}
+
+func newMetricStreamer(client *mlflow.Client, runID string, timeConfig models.TimeConfig, mapping map[string]string, batchSize int, baseTime *time.Time) *metricStreamer {
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	return &metricStreamer{
+		client:     client,
+		runID:      runID,
+		timeConfig: timeConfig,
+		mapping:    mapping,
+		batchSize:  batchSize,
+		baseTime:   baseTime,
+	}
+}
+
+// Add queues a point and flushes when the batch is full
+func (s *metricStreamer) Add(ctx context.Context, point models.MetricPoint) error {
+	// Steps must keep increasing across batches, so sequence steps are assigned
+	// here rather than per batch in ProcessMetrics
+	if point.Step == nil && (s.timeConfig.StepMode == "sequence" ||
+		(s.timeConfig.StepMode == "auto" && point.Timestamp == nil && point.Offset == nil)) {
+		step := s.sequence
+		point.Step = &step
+	}
+	s.sequence++
+
+	// Unless given, the first timestamp is the base for relative timestamps and
+	// timestamp-derived steps of all batches
+	if s.baseTime == nil {
+		base := time.Now()
+		s.localBase = true
+		if point.Timestamp != nil {
+			base = *timeutils.PointTime(point, s.timeConfig.Location)
+			s.localBase = false
+		}
+		s.baseTime = &base
+	}
+
+	s.pending = append(s.pending, point)
+	if len(s.pending) >= s.batchSize {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush logs all queued points
+func (s *metricStreamer) Flush(ctx context.Context) error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+
+	batch := &models.MetricsFile{Metrics: s.pending}
+	parser.ApplyMetricMapping(batch, s.mapping)
+	s.pending = nil
+
+	processor, err := timeutils.NewMetricProcessor(s.timeConfig, s.baseTime)
+	if err != nil {
+		return fmt.Errorf("failed to process metrics: %w", err)
+	}
+	if s.localBase {
+		processor.SetLocalBase()
+	}
+	metrics, err := processor.ProcessAll(batch.Metrics)
+	if err != nil {
+		return fmt.Errorf("failed to process metrics: %w", err)
+	}
+
+	if err := s.client.LogBatchMetrics(ctx, s.runID, metrics); err != nil {
+		return fmt.Errorf("failed to log metrics: %w", err)
+	}
+	s.logged += len(metrics)
+
+	return nil
+}
+
+// Logged returns the number of metrics logged so far
+func (s *metricStreamer) Logged() int {
+	return s.logged
+}
+
+// streamJSONLMetrics reads JSON Lines metric records from reader and logs
+// them in batches, flushing at least every flushInterval
+func streamJSONLMetrics(ctx context.Context, streamer *metricStreamer, reader io.Reader, flushInterval time.Duration) error {
+	type result struct {
+		line []byte
+		err  error
+	}
+
+	lines := make(chan result)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			lines <- result{line: line}
+		}
+		if err := scanner.Err(); err != nil {
+			lines <- result{err: err}
+		}
+	}()
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	lineNumber := 0
+	for {
+		select {
+		case r, ok := <-lines:
+			if !ok {
+				return streamer.Flush(ctx)
+			}
+			if r.err != nil {
+				return fmt.Errorf("failed to read input: %w", r.err)
+			}
+
+			lineNumber++
+			if len(bytes.TrimSpace(r.line)) == 0 {
+				continue
+			}
+
+			point, err := parser.ParseJSONLMetricLine(r.line)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping line %d: %v\n", lineNumber, err)
+				continue
+			}
+			if err := streamer.Add(ctx, point); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := streamer.Flush(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/parser"
+)
+
+var logTableCmd = &cobra.Command{
+	Use:   "table",
+	Short: "Log a table of a run, such as evaluation results",
+	Long: `Log a CSV or JSON table like mlflow.log_table, so that it is shown as a table
+in the evaluation view of the MLflow UI and can be compared across runs.
+
+CSV files need a header row; numeric and boolean cells are logged as numbers
+and booleans, empty cells as null. JSON files hold either
+{"columns": [...], "data": [[...]]} or an array of records. The table is stored
+as a JSON artifact, <file name>.json unless --artifact-file is given.`,
+	RunE: logTable,
+}
+
+func init() {
+	logCmd.AddCommand(logTableCmd)
+
+	// Table command flags
+	logTableCmd.Flags().String("run-id", "", "Run ID to log the table to (required)")
+	logTableCmd.Flags().String("file", "", "CSV or JSON table file (required)")
+	logTableCmd.Flags().String("artifact-file", "", "Artifact path of the table, ending with .json (default: <file name>.json)")
+	logTableCmd.MarkFlagRequired("run-id")
+	logTableCmd.MarkFlagRequired("file")
+
+	registerExamples(logTableCmd,
+		example{Command: "mlflow-cli log table --run-id <run-id> --file eval_results.csv"},
+		example{
+			Description: "Log several tables of a run below a directory",
+			Command:     "mlflow-cli log table --run-id <run-id> --file predictions.json --artifact-file eval/predictions.json",
+		},
+	)
+}
+
+func logTable(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	file, _ := cmd.Flags().GetString("file")
+	artifactFile, _ := cmd.Flags().GetString("artifact-file")
+
+	if artifactFile == "" {
+		base := filepath.Base(file)
+		artifactFile = strings.TrimSuffix(base, filepath.Ext(base)) + ".json"
+	}
+	artifactFile = path.Clean(strings.Trim(artifactFile, "/"))
+	if !strings.HasSuffix(artifactFile, ".json") {
+		return fmt.Errorf("invalid --artifact-file: %s (tables are logged as .json files)", artifactFile)
+	}
+
+	table, err := loadTableFile(file)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	if err := client.LogTable(context.Background(), runID, artifactFile, table); err != nil {
+		return fmt.Errorf("failed to log table %s: %w", file, err)
+	}
+
+	fmt.Printf("Successfully logged table %s (%d columns, %d rows) to run %s\n", artifactFile, len(table.Columns), len(table.Data), runID)
+	return nil
+}
+
+// loadTableFile reads a CSV or JSON table file
+func loadTableFile(tableFile string) (*models.Table, error) {
+	file, err := os.Open(tableFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", tableFile, err)
+	}
+	defer file.Close()
+
+	var table *models.Table
+	ext := strings.ToLower(filepath.Ext(tableFile))
+
+	switch ext {
+	case ".csv":
+		table, err = parser.ParseCSVTable(file)
+	case ".json":
+		table, err = parser.ParseJSONTable(file)
+	default:
+		return nil, fmt.Errorf("unsupported file format: %s (supported: .csv, .json)", ext)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse table file: %w", err)
+	}
+
+	return table, nil
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/parser"
+)
+
+var runSetTagCmd = &cobra.Command{
+	Use:   "set-tag",
+	Short: "Set tags on an MLflow run",
+	Long:  "Set or overwrite tags on an existing MLflow run",
+	RunE:  runSetTag,
+}
+
+var runDeleteTagCmd = &cobra.Command{
+	Use:   "delete-tag",
+	Short: "Delete tags from an MLflow run",
+	Long:  "Delete tags from an existing MLflow run",
+	RunE:  runDeleteTag,
+}
+
+func init() {
+	runCmd.AddCommand(runSetTagCmd)
+	runCmd.AddCommand(runDeleteTagCmd)
+
+	// Set-tag command flags
+	runSetTagCmd.Flags().String("run-id", "", "Run ID to set tags on (required)")
+	runSetTagCmd.Flags().StringArray("tag", []string{}, "Tags in key=value format")
+	addTagsFileFlag(runSetTagCmd)
+	runSetTagCmd.Flags().String("from-file", "", "Load tags from file (JSON/YAML)")
+	runSetTagCmd.Flags().MarkDeprecated("from-file", "use --tags-file instead")
+	runSetTagCmd.MarkFlagsMutuallyExclusive("tags-file", "from-file")
+	runSetTagCmd.MarkFlagRequired("run-id")
+
+	// Delete-tag command flags
+	runDeleteTagCmd.Flags().String("run-id", "", "Run ID to delete tags from (required)")
+	runDeleteTagCmd.Flags().StringArray("key", []string{}, "Tag key to delete (can be specified multiple times)")
+	runDeleteTagCmd.MarkFlagRequired("run-id")
+	runDeleteTagCmd.MarkFlagRequired("key")
+	addConfirmFlags(runDeleteTagCmd)
+
+	registerExamples(runSetTagCmd,
+		example{
+			Description: "Set tags from the command line",
+			Command:     "mlflow-cli run set-tag --run-id <run-id> --tag stage=staging --tag owner=ml-team",
+			Runnable:    true,
+		},
+		example{
+			Description: "Set tags from file",
+			Command:     "mlflow-cli run set-tag --run-id <run-id> --tags-file tags.yaml",
+		},
+	)
+	registerExamples(runDeleteTagCmd,
+		example{Command: "mlflow-cli run delete-tag --run-id <run-id> --key stage --key owner --yes"},
+	)
+}
+
+func runSetTag(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	tagsFile, _ := cmd.Flags().GetString("tags-file")
+	if fromFile, _ := cmd.Flags().GetString("from-file"); fromFile != "" {
+		tagsFile = fromFile
+	}
+
+	if len(tags) == 0 && tagsFile == "" {
+		return fmt.Errorf("either --tag or --tags-file must be specified")
+	}
+
+	// Parse tags
+	tagMap, err := parseTags(tags)
+	if err != nil {
+		return err
+	}
+	if err := mergeTagsFile(tagMap, tagsFile); err != nil {
+		return err
+	}
+
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	ctx := context.Background()
+	if err := client.SetTagsFromMap(ctx, runID, tagMap); err != nil {
+		return fmt.Errorf("failed to set tags: %w", err)
+	}
+
+	fmt.Printf("Successfully set %d tags\n", len(tagMap))
+	for key, value := range tagMap {
+		fmt.Printf("  %s: %s\n", key, value)
+	}
+
+	return nil
+}
+
+func runDeleteTag(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	runID, _ := cmd.Flags().GetString("run-id")
+	keys, _ := cmd.Flags().GetStringArray("key")
+
+	if err := confirm(cmd, fmt.Sprintf("delete %s of run %s", plural(len(keys), "tag"), runID), len(keys)); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	for _, key := range keys {
+		if err := client.DeleteTag(ctx, runID, key); err != nil {
+			return fmt.Errorf("failed to delete tags: %w", err)
+		}
+	}
+
+	fmt.Printf("Successfully deleted %d tags\n", len(keys))
+	for _, key := range keys {
+		fmt.Printf("  %s\n", key)
+	}
+
+	return nil
+}
+
+// addTagsFileFlag registers the flag of a tags file merged by mergeTagsFile
+func addTagsFileFlag(cmd *cobra.Command) {
+	cmd.Flags().String("tags-file", "", "Load tags from file (JSON/YAML); --tag values take precedence")
+}
+
+// mergeTagsFile adds the tags of a tags file to tagMap without overriding
+// tags already set on the command line. An empty path adds nothing.
+func mergeTagsFile(tagMap map[string]string, tagsFile string) error {
+	if tagsFile == "" {
+		return nil
+	}
+	fileTags, err := loadTagsFile(tagsFile)
+	if err != nil {
+		return err
+	}
+	for key, value := range fileTags {
+		if _, exists := tagMap[key]; !exists {
+			tagMap[key] = value
+		}
+	}
+	return nil
+}
+
+// loadTagsFile parses a JSON/YAML file with a top-level tags mapping. Nested
+// mappings become dotted keys and ${VAR} references are expanded.
+func loadTagsFile(fromFile string) (map[string]string, error) {
+	file, err := os.Open(fromFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", fromFile, err)
+	}
+	defer file.Close()
+
+	var tagMap map[string]string
+	ext := strings.ToLower(filepath.Ext(fromFile))
+
+	switch ext {
+	case ".json":
+		tagMap, err = parser.ParseJSONTags(file)
+	case ".yaml", ".yml":
+		tagMap, err = parser.ParseYAMLTags(file)
+	default:
+		return nil, fmt.Errorf("unsupported file format: %s (supported: .json, .yaml, .yml)", ext)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tags file: %w", err)
+	}
+
+	return tagMap, nil
+}
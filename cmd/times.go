@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/output"
+	"github.com/imishinist/mlflow-cli/internal/units"
+)
+
+// addAbsoluteTimesFlag adds the --absolute-times flag to commands whose text
+// tables show relative times
+func addAbsoluteTimesFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("absolute-times", false, "Print timestamps instead of relative times and durations in table output")
+}
+
+// humanizeTimes rewrites the times of a text table relative to now ("2h ago")
+// and adds a duration column ("1h23m") after end_time if the table has start
+// and end times. Running runs last until now. Other formats and
+// --absolute-times keep the table unchanged.
+func humanizeTimes(cmd *cobra.Command, format string, table *output.Table) {
+	if absolute, _ := cmd.Flags().GetBool("absolute-times"); absolute || format != output.FormatTable {
+		return
+	}
+
+	now := time.Now()
+	start, end := -1, -1
+	for i, column := range table.Columns {
+		switch column {
+		case "start_time":
+			start = i
+		case "end_time":
+			end = i
+		}
+	}
+
+	if start >= 0 && end >= 0 {
+		columns := append([]string{}, table.Columns[:end+1]...)
+		table.Columns = append(append(columns, "duration"), table.Columns[end+1:]...)
+		for i, row := range table.Rows {
+			var duration any
+			if startTime := timeValue(row[start]); startTime != nil {
+				endTime := now
+				if t := timeValue(row[end]); t != nil {
+					endTime = *t
+				}
+				duration = units.FormatDuration(endTime.Sub(*startTime))
+			}
+			values := append([]any{}, row[:end+1]...)
+			table.Rows[i] = append(append(values, duration), row[end+1:]...)
+		}
+	}
+
+	for _, row := range table.Rows {
+		for i, value := range row {
+			if t := timeValue(value); t != nil {
+				row[i] = units.FormatRelativeTime(output.DisplayTime(*t), now)
+			}
+		}
+	}
+}
+
+// timeValue returns the time of a table cell, or nil if the cell holds no time
+func timeValue(value any) *time.Time {
+	switch v := value.(type) {
+	case time.Time:
+		if !v.IsZero() {
+			return &v
+		}
+	case *time.Time:
+		if v != nil && !v.IsZero() {
+			return v
+		}
+	}
+	return nil
+}
+
+// displayRun returns a copy of a run with its times in the display time zone
+func displayRun(run *models.RunInfo) *models.RunInfo {
+	if run == nil {
+		return nil
+	}
+	displayed := *run
+	displayed.StartTime = output.DisplayTime(run.StartTime)
+	if run.EndTime != nil {
+		endTime := output.DisplayTime(*run.EndTime)
+		displayed.EndTime = &endTime
+	}
+	return &displayed
+}
+
+// displayModelVersion returns a copy of a model version with its times in the
+// display time zone
+func displayModelVersion(version *models.ModelVersion) *models.ModelVersion {
+	if version == nil {
+		return nil
+	}
+	displayed := *version
+	displayed.CreatedAt = output.DisplayTime(version.CreatedAt)
+	displayed.UpdatedAt = output.DisplayTime(version.UpdatedAt)
+	return &displayed
+}
+
+// displayLoggedModel returns a copy of a logged model with its times in the
+// display time zone
+func displayLoggedModel(model *models.LoggedModel) *models.LoggedModel {
+	displayed := *model
+	displayed.CreatedAt = output.DisplayTime(model.CreatedAt)
+	displayed.UpdatedAt = output.DisplayTime(model.UpdatedAt)
+	return &displayed
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/glob"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	timeutils "github.com/imishinist/mlflow-cli/internal/time"
+)
+
+var trackCmd = &cobra.Command{
+	Use:   "track",
+	Short: "Create a run, log everything, and end it in one invocation",
+	Long: `Create a new MLflow run, log parameters, metrics, and artifacts from files,
+and end the run. The run ends as FINISHED when everything was logged and as
+FAILED otherwise. The run ID is printed to stdout.`,
+	RunE: track,
+}
+
+func init() {
+	rootCmd.AddCommand(trackCmd)
+
+	// Track command flags
+	addRunConfigFlags(trackCmd)
+	trackCmd.Flags().String("experiment-name", "", "Experiment name (created if it does not exist)")
+	trackCmd.Flags().StringArray("params", []string{}, "Parameters file (JSON/YAML/TOML/.env, can be specified multiple times)")
+	trackCmd.Flags().StringArray("metrics", []string{}, "Metrics file (JSON/YAML/CSV, can be specified multiple times)")
+	trackCmd.Flags().StringArray("artifacts", []string{}, "Artifact file or glob pattern such as 'out/**' (can be specified multiple times)")
+	addFlattenFlags(trackCmd)
+	addParamLengthFlags(trackCmd)
+	addPRCommentFlag(trackCmd)
+
+	registerExamples(trackCmd,
+		example{Command: `mlflow-cli track --experiment-name nightly-benchmark \
+  --params params.yaml --metrics metrics.json \
+  --artifacts 'out/**' --tag stage=nightly`},
+	)
+}
+
+func track(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	experimentName, _ := cmd.Flags().GetString("experiment-name")
+	paramsFiles, _ := cmd.Flags().GetStringArray("params")
+	metricsFiles, _ := cmd.Flags().GetStringArray("metrics")
+	artifactPatterns, _ := cmd.Flags().GetStringArray("artifacts")
+	flattenOpts, flatten, err := flattenOptions(cmd)
+	if err != nil {
+		return err
+	}
+	lengthPolicy, err := paramLengthPolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Load all inputs before creating the run so that bad input leaves no run behind
+	paramMap := make(map[string]string)
+	for _, fromFile := range paramsFiles {
+		fileParams, err := loadParamsFile(fromFile, flattenOpts, flatten)
+		if err != nil {
+			return err
+		}
+		for key, value := range fileParams {
+			paramMap[key] = value
+		}
+	}
+	paramMap, err = applyParamLengthPolicy(lengthPolicy, paramMap)
+	if err != nil {
+		return err
+	}
+
+	location, err := timeutils.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return err
+	}
+	timeConfig := models.TimeConfig{
+		Resolution: cfg.TimeResolution,
+		Alignment:  cfg.TimeAlignment,
+		StepMode:   cfg.StepMode,
+		Aggregate:  cfg.Aggregate,
+		Location:   location,
+	}
+	var metrics []models.Metric
+	for _, fromFile := range metricsFiles {
+		metricsFile, err := loadMetricsFile(fromFile)
+		if err != nil {
+			return err
+		}
+		processed, err := timeutils.ProcessMetrics(metricsFile.Metrics, timeConfig, nil)
+		if err != nil {
+			return fmt.Errorf("failed to process metrics from %s: %w", fromFile, err)
+		}
+		metrics = append(metrics, processed...)
+	}
+
+	var artifacts []glob.Match
+	for _, pattern := range artifactPatterns {
+		matches, err := glob.Expand(pattern)
+		if err != nil {
+			return fmt.Errorf("failed to expand artifact pattern %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("no files match artifact pattern: %s", pattern)
+		}
+		artifacts = append(artifacts, matches...)
+	}
+
+	ctx := context.Background()
+
+	// Resolve experiment by name
+	experimentID, _ := cmd.Flags().GetString("experiment-id")
+	if experimentName != "" && experimentID == "" {
+		experimentID, err = client.EnsureExperiment(ctx, experimentName)
+		if err != nil {
+			return err
+		}
+		cmd.Flags().Set("experiment-id", experimentID)
+	}
+
+	runConfig, err := buildRunConfig(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Create run
+	runInfo, err := client.CreateRun(ctx, runConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create run: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Started run %s\n", runInfo.RunID)
+
+	logErr := logTrackedData(ctx, client, runInfo.RunID, paramMap, metrics, artifacts)
+
+	// End run
+	status := models.RunStatusFinished
+	if logErr != nil {
+		status = models.RunStatusFailed
+	}
+	if err := client.UpdateRun(ctx, runInfo.RunID, status); err != nil {
+		return fmt.Errorf("failed to end run: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Run %s ended with status %s\n", runInfo.RunID, status)
+	commentOnPullRequest(ctx, cmd, client, cfg.DryRun, runInfo.RunID)
+
+	// Output only run ID for shell scripting
+	fmt.Printf("%s\n", runInfo.RunID)
+
+	return logErr
+}
+
+// logTrackedData logs parameters, metrics, and artifacts to the run
+func logTrackedData(ctx context.Context, client *mlflow.Client, runID string, params map[string]string, metrics []models.Metric, artifacts []glob.Match) error {
+	if len(params) > 0 {
+		if err := client.LogParamsFromMap(ctx, runID, params); err != nil {
+			return fmt.Errorf("failed to log parameters: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Logged %d parameters\n", len(params))
+	}
+
+	if len(metrics) > 0 {
+		if err := client.LogBatchMetrics(ctx, runID, metrics); err != nil {
+			return fmt.Errorf("failed to log metrics: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Logged %d metrics\n", len(metrics))
+	}
+
+	for _, artifact := range artifacts {
+		if err := client.UploadArtifact(ctx, runID, artifact.Path, artifact.RelPath); err != nil {
+			return fmt.Errorf("failed to upload %s: %w", artifact.Path, err)
+		}
+	}
+	if len(artifacts) > 0 {
+		fmt.Fprintf(os.Stderr, "Uploaded %d artifacts\n", len(artifacts))
+	}
+
+	return nil
+}
@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/mlflow"
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/output"
+	"github.com/imishinist/mlflow-cli/internal/units"
+)
+
+// Events emitted by experiment watch
+const (
+	eventRunCreated  = "run_created"
+	eventRunFinished = "run_finished"
+)
+
+// Events emitted by model watch
+const (
+	eventVersionCreated = "version_created"
+	eventStageChanged   = "stage_changed"
+	eventAliasSet       = "alias_set"
+	eventAliasRemoved   = "alias_removed"
+)
+
+var experimentWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Emit events for new and finished runs of an experiment",
+	Long: `Poll the runs of an experiment and print an event as one JSON line to stdout
+whenever a run is created ("run_created") or ends ("run_finished", with status
+FINISHED, FAILED or KILLED). Each event carries the run with its params, metrics
+and tags, so that automation can be built from shell pipelines.
+
+Runs that exist when the command starts are not reported, unless
+--include-existing is given. The command runs until interrupted.`,
+	RunE: experimentWatch,
+}
+
+var modelWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Emit events for changes of a registered model",
+	Long: `Poll a registered model and print an event as one JSON line to stdout whenever
+a version is created ("version_created"), a version moves to another stage
+("stage_changed"), or an alias is set or moved ("alias_set") or deleted
+("alias_removed"). This lets deploy automation react to registry updates where
+registry webhooks are not available.
+
+Versions and aliases that exist when the command starts are not reported, unless
+--include-existing is given. The command runs until interrupted.`,
+	RunE: modelWatch,
+}
+
+func init() {
+	experimentCmd.AddCommand(experimentWatchCmd)
+	modelCmd.AddCommand(modelWatchCmd)
+
+	// Experiment watch command flags
+	addExperimentFlags(experimentWatchCmd)
+	experimentWatchCmd.Flags().String("filter", "", "MLflow search filter expression selecting the watched runs")
+	units.DurationFlag(experimentWatchCmd.Flags(), "poll-interval", 10*time.Second, "How often the runs are polled")
+	experimentWatchCmd.Flags().Bool("include-existing", false, "Emit events for the runs existing when the command starts")
+
+	// Model watch command flags
+	modelWatchCmd.Flags().String("name", "", "Registered model name (required)")
+	units.DurationFlag(modelWatchCmd.Flags(), "poll-interval", 10*time.Second, "How often the registry is polled")
+	modelWatchCmd.Flags().Bool("include-existing", false, "Emit events for the versions and aliases existing when the command starts")
+	modelWatchCmd.MarkFlagRequired("name")
+
+	registerExamples(experimentWatchCmd,
+		example{
+			Description: "Register every model whose run finishes with accuracy above 0.9",
+			Command: `mlflow-cli experiment watch --experiment-id 1 |
+  jq -c --unbuffered 'select(.event == "run_finished" and .run.status == "FINISHED" and .run.metrics.accuracy > 0.9)' |
+  while read -r event; do ./register.sh "$(echo "$event" | jq -r .run.run_id)"; done`,
+		},
+	)
+	registerExamples(modelWatchCmd,
+		example{
+			Description: "Deploy whichever version the champion alias points to",
+			Command: `mlflow-cli model watch --name fraud-detector |
+  jq -r --unbuffered 'select(.event == "alias_set" and .alias == "champion") | .version.version' |
+  while read -r version; do ./deploy.sh fraud-detector "$version"; done`,
+		},
+	)
+}
+
+// runEvent is an event emitted by experiment watch
+type runEvent struct {
+	Event string          `json:"event"`
+	Time  time.Time       `json:"time"`
+	Run   *models.RunInfo `json:"run"`
+}
+
+func experimentWatch(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	filter, _ := cmd.Flags().GetString("filter")
+	pollInterval, _ := cmd.Flags().GetDuration("poll-interval")
+	includeExisting, _ := cmd.Flags().GetBool("include-existing")
+
+	if pollInterval <= 0 {
+		return fmt.Errorf("poll interval must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	experimentID, err := resolveExperimentID(ctx, cmd, client, cfg)
+	if err != nil {
+		return err
+	}
+
+	// The first poll establishes the runs known before watching
+	statuses := make(map[string]string)
+	if !includeExisting {
+		runs, err := client.SearchRuns(ctx, []string{experimentID}, filter)
+		if err != nil {
+			return fmt.Errorf("failed to search runs: %w", err)
+		}
+		for _, run := range runs {
+			statuses[run.RunID] = run.Status
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Watching runs of experiment %s every %s (press Ctrl+C to stop)\n", experimentID, pollInterval)
+
+	encoder := json.NewEncoder(os.Stdout)
+	return watchLoop(ctx, pollInterval, includeExisting, func() error {
+		runs, err := client.SearchRuns(ctx, []string{experimentID}, filter)
+		if err != nil {
+			warnPollFailed(ctx, fmt.Errorf("failed to search runs: %w", err))
+			return nil
+		}
+		for _, event := range runEvents(runs, statuses) {
+			event.Time = output.DisplayTime(event.Time)
+			event.Run = displayRun(event.Run)
+			if err := encoder.Encode(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// watchLoop calls poll every pollInterval until ctx is done, starting
+// immediately if pollNow is set. It stops early if poll fails.
+func watchLoop(ctx context.Context, pollInterval time.Duration, pollNow bool, poll func() error) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if pollNow {
+			if err := poll(); err != nil {
+				return err
+			}
+		}
+		pollNow = true
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// warnPollFailed reports a failed poll, which is retried at the next interval.
+// Polls cut short by an interrupt are not reported.
+func warnPollFailed(ctx context.Context, err error) {
+	if ctx.Err() == nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// runEvents returns the events of runs that are new or ended since the
+// statuses seen before, and records their current statuses. Runs created and
+// ended between two polls produce both events.
+func runEvents(runs []*models.RunInfo, statuses map[string]string) []runEvent {
+	now := time.Now()
+	var events []runEvent
+	for _, run := range runs {
+		previous, known := statuses[run.RunID]
+		statuses[run.RunID] = run.Status
+
+		if !known {
+			events = append(events, runEvent{Event: eventRunCreated, Time: now, Run: run})
+		}
+		if isEndedRunStatus(run.Status) && (!known || previous != run.Status) {
+			events = append(events, runEvent{Event: eventRunFinished, Time: now, Run: run})
+		}
+	}
+	return events
+}
+
+// isEndedRunStatus reports whether a run with the status has ended
+func isEndedRunStatus(status string) bool {
+	switch models.RunStatus(status) {
+	case models.RunStatusFinished, models.RunStatusFailed, models.RunStatusKilled:
+		return true
+	}
+	return false
+}
+
+// modelEvent is an event emitted by model watch
+type modelEvent struct {
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+	Name  string    `json:"name"`
+	// Alias is the alias set or removed
+	Alias string `json:"alias,omitempty"`
+	// Version is the version created, moved, or pointed to by the alias
+	Version *models.ModelVersion `json:"version,omitempty"`
+	// PreviousStage is the stage a version moved from
+	PreviousStage string `json:"previous_stage,omitempty"`
+	// PreviousVersion is the version an alias pointed to before
+	PreviousVersion string `json:"previous_version,omitempty"`
+}
+
+// modelState is the state of a registered model seen by model watch
+type modelState struct {
+	// stages holds the stages of the versions by version
+	stages map[string]string
+	// aliases holds the versions of the aliases by alias
+	aliases map[string]string
+}
+
+func modelWatch(cmd *cobra.Command, args []string) error {
+	cfg := config.New()
+	client, err := mlflow.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MLflow client: %w", err)
+	}
+
+	// Parse flags
+	name, _ := cmd.Flags().GetString("name")
+	pollInterval, _ := cmd.Flags().GetDuration("poll-interval")
+	includeExisting, _ := cmd.Flags().GetBool("include-existing")
+
+	if pollInterval <= 0 {
+		return fmt.Errorf("poll interval must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The first poll establishes the versions and aliases known before watching
+	state := &modelState{stages: make(map[string]string), aliases: make(map[string]string)}
+	if !includeExisting {
+		versions, err := client.SearchModelVersions(ctx, name)
+		if err != nil {
+			return err
+		}
+		aliases, err := client.GetModelAliases(ctx, name)
+		if err != nil {
+			return err
+		}
+		state.modelEvents(name, versions, aliases)
+	}
+
+	fmt.Fprintf(os.Stderr, "Watching model %s every %s (press Ctrl+C to stop)\n", name, pollInterval)
+
+	encoder := json.NewEncoder(os.Stdout)
+	return watchLoop(ctx, pollInterval, includeExisting, func() error {
+		versions, err := client.SearchModelVersions(ctx, name)
+		if err != nil {
+			warnPollFailed(ctx, err)
+			return nil
+		}
+		// Without aliases, the aliases seen before are kept so that they are
+		// not reported as removed
+		aliases, err := client.GetModelAliases(ctx, name)
+		if err != nil {
+			warnPollFailed(ctx, err)
+			aliases = state.aliases
+		}
+		for _, event := range state.modelEvents(name, versions, aliases) {
+			event.Time = output.DisplayTime(event.Time)
+			event.Version = displayModelVersion(event.Version)
+			if err := encoder.Encode(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// modelEvents returns the events of the changes of the versions and aliases
+// since the state seen before, and records them as the current state
+func (s *modelState) modelEvents(name string, versions []*models.ModelVersion, aliases map[string]string) []modelEvent {
+	now := time.Now()
+	var events []modelEvent
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versionLess(versions[i].Version, versions[j].Version)
+	})
+	byVersion := make(map[string]*models.ModelVersion, len(versions))
+	stages := make(map[string]string, len(versions))
+	for _, version := range versions {
+		byVersion[version.Version] = version
+		stages[version.Version] = version.CurrentStage
+		previousStage, known := s.stages[version.Version]
+
+		switch {
+		case !known:
+			events = append(events, modelEvent{Event: eventVersionCreated, Time: now, Name: name, Version: version})
+		case previousStage != version.CurrentStage:
+			events = append(events, modelEvent{Event: eventStageChanged, Time: now, Name: name, Version: version, PreviousStage: previousStage})
+		}
+	}
+
+	names := make([]string, 0, len(aliases)+len(s.aliases))
+	for alias := range aliases {
+		names = append(names, alias)
+	}
+	for alias := range s.aliases {
+		if _, ok := aliases[alias]; !ok {
+			names = append(names, alias)
+		}
+	}
+	sort.Strings(names)
+	for _, alias := range names {
+		previous, known := s.aliases[alias]
+		current, ok := aliases[alias]
+		switch {
+		case !ok:
+			events = append(events, modelEvent{Event: eventAliasRemoved, Time: now, Name: name, Alias: alias, PreviousVersion: previous})
+		case !known || previous != current:
+			version := byVersion[current]
+			if version == nil {
+				version = &models.ModelVersion{Name: name, Version: current}
+			}
+			events = append(events, modelEvent{Event: eventAliasSet, Time: now, Name: name, Alias: alias, Version: version, PreviousVersion: previous})
+		}
+	}
+
+	s.stages = stages
+	s.aliases = make(map[string]string, len(aliases))
+	for alias, version := range aliases {
+		s.aliases[alias] = version
+	}
+	return events
+}
+
+// versionLess orders model versions numerically
+func versionLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
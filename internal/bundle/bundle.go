@@ -0,0 +1,278 @@
+// Package bundle packs many files into a single tar.gz or zip archive and
+// extracts such archives, so that directories of small files can be uploaded
+// and downloaded as one artifact.
+package bundle
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Archive formats
+const (
+	FormatTarGz = "tar.gz"
+	FormatZip   = "zip"
+)
+
+// Formats lists the supported archive formats
+var Formats = []string{FormatTarGz, FormatZip}
+
+// extensions maps file name extensions to archive formats
+var extensions = []struct {
+	suffix string
+	format string
+}{
+	{".tar.gz", FormatTarGz}, {".tgz", FormatTarGz}, {".zip", FormatZip},
+}
+
+// File is a local file and its slash-separated name in an archive
+type File struct {
+	Path string
+	Name string
+}
+
+// ValidateFormat returns an error for unsupported formats
+func ValidateFormat(format string) error {
+	for _, supported := range Formats {
+		if format == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported archive format: %s (supported: %s)", format, strings.Join(Formats, ", "))
+}
+
+// Name returns the archive file name of a directory in a format, e.g.
+// samples.tar.gz
+func Name(dir, format string) string {
+	return dir + "." + format
+}
+
+// Detect returns the archive format of a file name by its extension and the
+// name without the extension
+func Detect(name string) (format, base string, ok bool) {
+	for _, ext := range extensions {
+		if base, found := strings.CutSuffix(name, ext.suffix); found && base != "" {
+			return ext.format, base, true
+		}
+	}
+	return "", "", false
+}
+
+// Write writes files to w as an archive in the given format, keeping their
+// permissions and modification times
+func Write(w io.Writer, format string, files []File) error {
+	switch format {
+	case FormatTarGz:
+		return writeTarGz(w, files)
+	case FormatZip:
+		return writeZip(w, files)
+	}
+	return ValidateFormat(format)
+}
+
+// writeTarGz writes a gzip-compressed tar archive
+func writeTarGz(w io.Writer, files []File) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, file := range files {
+		if err := addTarFile(tw, file); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", file.Path, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// addTarFile streams a single file into a tar archive
+func addTarFile(tw *tar.Writer, file File) error {
+	f, err := os.Open(file.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = file.Name
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// writeZip writes a deflate-compressed zip archive
+func writeZip(w io.Writer, files []File) error {
+	zw := zip.NewWriter(w)
+	for _, file := range files {
+		if err := addZipFile(zw, file); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", file.Path, err)
+		}
+	}
+	return zw.Close()
+}
+
+// addZipFile streams a single file into a zip archive
+func addZipFile(zw *zip.Writer, file File) error {
+	f, err := os.Open(file.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = file.Name
+	header.Method = zip.Deflate
+	entry, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, f)
+	return err
+}
+
+// Extract extracts an archive file into destDir and returns the number of
+// files extracted. Entries must stay below destDir; only regular files and
+// directories are supported.
+func Extract(archivePath, format, destDir string) (int, error) {
+	switch format {
+	case FormatTarGz:
+		return extractTarGz(archivePath, destDir)
+	case FormatZip:
+		return extractZip(archivePath, destDir)
+	}
+	return 0, ValidateFormat(format)
+}
+
+// extractTarGz extracts a gzip-compressed tar archive
+func extractTarGz(archivePath, destDir string) (int, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", archivePath, err)
+	}
+	defer gz.Close()
+
+	count := 0
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to read %s: %w", archivePath, err)
+		}
+
+		dest, err := entryPath(destDir, header.Name)
+		if err != nil {
+			return count, err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(dest, 0755)
+		case tar.TypeReg:
+			err = extractFile(tr, dest, header.FileInfo().Mode())
+			count++
+		default:
+			err = fmt.Errorf("unsupported archive entry type of %s", header.Name)
+		}
+		if err != nil {
+			return count, err
+		}
+	}
+}
+
+// extractZip extracts a zip archive
+func extractZip(archivePath, destDir string) (int, error) {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", archivePath, err)
+	}
+	defer zr.Close()
+
+	count := 0
+	for _, entry := range zr.File {
+		dest, err := entryPath(destDir, entry.Name)
+		if err != nil {
+			return count, err
+		}
+
+		mode := entry.Mode()
+		switch {
+		case mode.IsDir():
+			err = os.MkdirAll(dest, 0755)
+		case mode.IsRegular():
+			err = extractZipFile(entry, dest)
+			count++
+		default:
+			err = fmt.Errorf("unsupported archive entry type of %s", entry.Name)
+		}
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// extractZipFile extracts a single file of a zip archive
+func extractZipFile(entry *zip.File, dest string) error {
+	r, err := entry.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return extractFile(r, dest, entry.Mode())
+}
+
+// entryPath returns the local path of an archive entry below destDir
+func entryPath(destDir, name string) (string, error) {
+	rel := filepath.FromSlash(strings.TrimSuffix(name, "/"))
+	if !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("refusing to extract archive entry outside the destination directory: %s", name)
+	}
+	return filepath.Join(destDir, rel), nil
+}
+
+// extractFile writes the content of an archive entry to dest with the
+// permissions of the entry
+func extractFile(r io.Reader, dest string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -3,8 +3,12 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+
+	timeutils "github.com/imishinist/mlflow-cli/internal/time"
+	"github.com/imishinist/mlflow-cli/internal/units"
 )
 
 // Databricks domain suffixes for URL detection
@@ -16,15 +20,27 @@ var databricksDomains = []string{
 
 // Valid configuration values
 var (
-	validTimeResolutions = map[string]bool{
-		"1m": true, "5m": true, "1h": true,
-	}
 	validTimeAlignments = map[string]bool{
 		"floor": true, "ceil": true, "round": true,
 	}
 	validStepModes = map[string]bool{
 		"auto": true, "timestamp": true, "sequence": true,
 	}
+	validAggregates = map[string]bool{
+		"none": true, "mean": true, "max": true, "min": true, "last": true,
+	}
+	validClockSkewModes = map[string]bool{
+		"warn": true, "correct": true, "ignore": true,
+	}
+	validAuthModes = map[string]bool{
+		"": true, AuthBasic: true, AuthSigV4: true,
+	}
+)
+
+// Authentication modes of tracking server requests
+const (
+	AuthBasic = "basic"
+	AuthSigV4 = "sigv4"
 )
 
 type Config struct {
@@ -33,20 +49,102 @@ type Config struct {
 	TimeResolution  string
 	TimeAlignment   string
 	StepMode        string
+	Aggregate       string
+	Timezone        string
 	DatabricksHost  string
 	DatabricksToken string
+	// TrackingUsername and TrackingPassword authenticate to MLflow tracking
+	// servers with basic auth, as used by MLflow's built-in authentication
+	TrackingUsername string
+	TrackingPassword string
+	// Auth is how tracking server requests are authenticated: basic auth,
+	// AWS SigV4 signing, or if empty, basic auth if a username is set
+	Auth string
+	// SageMaker is the SageMaker MLflow tracking server whose ARN was given
+	// as the tracking URI, which is then its endpoint; nil otherwise
+	SageMaker    *SageMakerARN
+	MetricNaming MetricNamingPolicy
+	// Sinks are written the metrics and params logged to MLflow as well
+	Sinks []SinkConfig
+	// Profile is the name of the selected config file profile, if any
+	Profile string
+	// AllowedExperiments restricts the experiments, by ID or name, that
+	// commands may target; empty allows all experiments
+	AllowedExperiments []string
+	// IndexPath is the local run index database; empty means the default
+	IndexPath string
+	// RateLimit caps the API requests per second shared by all concurrent
+	// requests of a client; 0 means the default of 15
+	RateLimit int
+	// MaxRetries is how often failed requests are retried with backoff
+	MaxRetries int
+	// Timeout bounds each attempt of an API call; 0 means no timeout
+	Timeout time.Duration
+	// TransferTimeout bounds the transfer of each artifact file; 0 means no
+	// timeout
+	TransferTimeout time.Duration
+	// MaxMemory bounds the bytes buffered by bulk operations, such as metric
+	// points and upload chunks, which spill to temporary files beyond it; 0
+	// means unlimited
+	MaxMemory int64
+	// BandwidthLimit bounds the bytes per second of all artifact uploads and
+	// downloads of a client together; 0 means unlimited
+	BandwidthLimit int64
+	// ClockSkew is what to do when the local clock differs from the server's:
+	// warn, correct metric timestamps, or ignore
+	ClockSkew string
+	// UnlockToken allows changes to runs frozen with this token
+	UnlockToken string
+	// DryRun records mutating requests instead of sending them
+	DryRun bool
 }
 
 func New() *Config {
-	return &Config{
-		TrackingURI:     viper.GetString("tracking_uri"),
-		ExperimentID:    viper.GetString("experiment_id"),
-		TimeResolution:  viper.GetString("time_resolution"),
-		TimeAlignment:   viper.GetString("time_alignment"),
-		StepMode:        viper.GetString("step_mode"),
-		DatabricksHost:  viper.GetString("databricks_host"),
-		DatabricksToken: viper.GetString("databricks_token"),
+	cfg := &Config{
+		TrackingURI:      viper.GetString("tracking_uri"),
+		ExperimentID:     viper.GetString("experiment_id"),
+		TimeResolution:   viper.GetString("time_resolution"),
+		TimeAlignment:    viper.GetString("time_alignment"),
+		StepMode:         viper.GetString("step_mode"),
+		Aggregate:        viper.GetString("aggregate"),
+		Timezone:         viper.GetString("timezone"),
+		DatabricksHost:   viper.GetString("databricks_host"),
+		DatabricksToken:  viper.GetString("databricks_token"),
+		TrackingUsername: viper.GetString("tracking_username"),
+		TrackingPassword: viper.GetString("tracking_password"),
+		Auth:             viper.GetString("auth"),
+		UnlockToken:      viper.GetString("unlock_token"),
+		DryRun:           viper.GetBool("dry_run"),
+		Profile:          viper.GetString("profile"),
+		IndexPath:        viper.GetString("index_path"),
+		RateLimit:        viper.GetInt("rate_limit"),
+		MaxRetries:       viper.GetInt("max_retries"),
+		ClockSkew:        viper.GetString("clock_skew"),
+	}
+	// SageMaker tracking servers are addressed by their ARN, and require
+	// signed requests
+	if arn, ok := ParseSageMakerARN(cfg.TrackingURI); ok {
+		cfg.SageMaker = &arn
+		cfg.TrackingURI = arn.Endpoint()
+		if cfg.Auth == "" {
+			cfg.Auth = AuthSigV4
+		}
+	}
+	cfg.AllowedExperiments = viper.GetStringSlice("allowed_experiments")
+	// Invalid sizes and durations are rejected when the configuration is
+	// loaded
+	if maxMemory := viper.GetString("max_memory"); maxMemory != "" {
+		cfg.MaxMemory, _ = units.ParseSize(maxMemory)
 	}
+	if timeout := viper.GetString("timeout"); timeout != "" {
+		cfg.Timeout, _ = units.ParseDuration(timeout)
+	}
+	if timeout := viper.GetString("transfer_timeout"); timeout != "" {
+		cfg.TransferTimeout, _ = units.ParseDuration(timeout)
+	}
+	viper.UnmarshalKey("metric_naming", &cfg.MetricNaming)
+	viper.UnmarshalKey("sinks", &cfg.Sinks)
+	return cfg
 }
 
 func (c *Config) Validate() error {
@@ -54,9 +152,26 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("tracking URI is required")
 	}
 
+	if (c.TrackingUsername == "") != (c.TrackingPassword == "") {
+		return fmt.Errorf("tracking username and password must be set together (MLFLOW_TRACKING_USERNAME and MLFLOW_TRACKING_PASSWORD)")
+	}
+
+	// Validate authentication
+	if !validAuthModes[c.Auth] {
+		return fmt.Errorf("invalid auth: %s (valid: basic, sigv4)", c.Auth)
+	}
+	switch {
+	case c.Auth == AuthBasic && c.TrackingUsername == "":
+		return fmt.Errorf("basic auth requires MLFLOW_TRACKING_USERNAME and MLFLOW_TRACKING_PASSWORD")
+	case c.Auth == AuthSigV4 && c.TrackingUsername != "":
+		return fmt.Errorf("sigv4 auth signs requests with AWS credentials and cannot be combined with a tracking username")
+	case c.Auth == AuthSigV4 && c.IsDatabricks():
+		return fmt.Errorf("sigv4 auth is not supported for Databricks")
+	}
+
 	// Validate time resolution
-	if !validTimeResolutions[c.TimeResolution] {
-		return fmt.Errorf("invalid time resolution: %s (valid: 1m, 5m, 1h)", c.TimeResolution)
+	if _, err := timeutils.ParseResolution(c.TimeResolution); err != nil {
+		return fmt.Errorf("invalid time resolution: %s (valid: a duration such as 10s, 5m, 1h or 1d, or none)", c.TimeResolution)
 	}
 
 	// Validate time alignment
@@ -69,9 +184,68 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid step mode: %s (valid: auto, timestamp, sequence)", c.StepMode)
 	}
 
+	// Validate aggregation
+	if !validAggregates[c.Aggregate] {
+		return fmt.Errorf("invalid aggregate: %s (valid: none, mean, max, min, last)", c.Aggregate)
+	}
+
+	// Validate time zone
+	if _, err := timeutils.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("invalid time zone: %s (expected an IANA name such as Asia/Tokyo)", c.Timezone)
+	}
+
+	// Validate clock skew handling
+	if !validClockSkewModes[c.ClockSkew] {
+		return fmt.Errorf("invalid clock skew mode: %s (valid: warn, correct, ignore)", c.ClockSkew)
+	}
+
+	if c.RateLimit < 0 {
+		return fmt.Errorf("invalid rate limit: %d (must be >= 0)", c.RateLimit)
+	}
+
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("invalid max retries: %d (must be >= 0)", c.MaxRetries)
+	}
+
+	// Validate metric naming policy
+	if err := c.MetricNaming.Compile(); err != nil {
+		return err
+	}
+
+	// Validate output sinks
+	for i := range c.Sinks {
+		if err := c.Sinks[i].Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// ApplyProfile merges the settings of the named profile under the profiles key
+// over the top-level settings of the config file. Flags and environment
+// variables still take precedence.
+func ApplyProfile(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	profiles := viper.GetStringMap("profiles")
+	settings, ok := profiles[strings.ToLower(name)].(map[string]any)
+	if !ok {
+		return fmt.Errorf("profile %s is not defined in the config file", name)
+	}
+	// A profile without allowed_experiments must not inherit the top-level list
+	if _, ok := settings["allowed_experiments"]; !ok {
+		settings["allowed_experiments"] = []string{}
+	}
+	// Nor the top-level sinks, which may belong to another team
+	if _, ok := settings["sinks"]; !ok {
+		settings["sinks"] = []any{}
+	}
+	return viper.MergeConfigMap(settings)
+}
+
 // IsDatabricks checks if the tracking URI points to Databricks
 func (c *Config) IsDatabricks() bool {
 	if c.TrackingURI == "databricks" {
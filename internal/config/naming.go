@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MetricNamingPolicy constrains metric keys so that teams sharing an
+// experiment keep consistent namespaces (e.g. train/*, val/*, system/*)
+type MetricNamingPolicy struct {
+	// AllowedPrefixes lists the namespaces metric keys must start with
+	AllowedPrefixes []string `mapstructure:"allowed_prefixes"`
+	// Patterns maps a namespace to a regular expression its keys must match
+	Patterns map[string]string `mapstructure:"patterns"`
+	// Separator separates the namespace from the rest of the key (default: /)
+	Separator string `mapstructure:"separator"`
+
+	compiled map[string]*regexp.Regexp
+}
+
+// IsEmpty reports whether the policy imposes no constraint
+func (p *MetricNamingPolicy) IsEmpty() bool {
+	return len(p.AllowedPrefixes) == 0 && len(p.Patterns) == 0
+}
+
+// Compile validates and compiles the namespace patterns
+func (p *MetricNamingPolicy) Compile() error {
+	p.compiled = make(map[string]*regexp.Regexp, len(p.Patterns))
+	for prefix, pattern := range p.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid metric naming pattern for %s: %w", prefix, err)
+		}
+		p.compiled[prefix] = re
+	}
+	return nil
+}
+
+// ValidateMetricKey checks a metric key against the policy
+func (p *MetricNamingPolicy) ValidateMetricKey(key string) error {
+	if p.IsEmpty() {
+		return nil
+	}
+	if p.compiled == nil {
+		if err := p.Compile(); err != nil {
+			return err
+		}
+	}
+
+	separator := p.Separator
+	if separator == "" {
+		separator = "/"
+	}
+
+	prefix, _, found := strings.Cut(key, separator)
+	if !found {
+		prefix = ""
+	}
+
+	if len(p.AllowedPrefixes) > 0 && !p.isAllowedPrefix(prefix) {
+		return fmt.Errorf("metric %q violates naming policy: must start with one of %s",
+			key, p.describePrefixes(separator))
+	}
+
+	if re, ok := p.compiled[prefix]; ok && !re.MatchString(key) {
+		return fmt.Errorf("metric %q violates naming policy: %s%s* metrics must match %s",
+			key, prefix, separator, re.String())
+	}
+
+	return nil
+}
+
+// isAllowedPrefix checks if the prefix is in the allowed list
+func (p *MetricNamingPolicy) isAllowedPrefix(prefix string) bool {
+	if prefix == "" {
+		return false
+	}
+	for _, allowed := range p.AllowedPrefixes {
+		if prefix == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// describePrefixes formats the allowed prefixes for error messages
+func (p *MetricNamingPolicy) describePrefixes(separator string) string {
+	prefixes := make([]string, len(p.AllowedPrefixes))
+	for i, prefix := range p.AllowedPrefixes {
+		prefixes[i] = prefix + separator
+	}
+	return strings.Join(prefixes, ", ")
+}
@@ -0,0 +1,26 @@
+package config
+
+import "strings"
+
+// SageMakerARN is the ARN of an Amazon SageMaker managed MLflow tracking
+// server, arn:aws:sagemaker:<region>:<account>:mlflow-tracking-server/<name>
+type SageMakerARN struct {
+	ARN    string
+	Region string
+}
+
+// ParseSageMakerARN parses the ARN of a SageMaker MLflow tracking server
+func ParseSageMakerARN(value string) (SageMakerARN, bool) {
+	// arn:partition:service:region:account:resource
+	parts := strings.SplitN(value, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "sagemaker" || parts[3] == "" ||
+		!strings.HasPrefix(parts[5], "mlflow-tracking-server/") {
+		return SageMakerARN{}, false
+	}
+	return SageMakerARN{ARN: value, Region: parts[3]}, true
+}
+
+// Endpoint returns the URL of the MLflow API of the tracking server
+func (a SageMakerARN) Endpoint() string {
+	return "https://" + a.Region + ".experiments.sagemaker.aws"
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// Output sink types
+const (
+	SinkCSV    = "csv"
+	SinkStatsD = "statsd"
+	SinkOTLP   = "otlp"
+)
+
+// SinkConfig configures an output sink, another destination that the metrics
+// and params logged to MLflow are written to, e.g. to bridge MLflow logging
+// with an existing observability stack
+type SinkConfig struct {
+	// Type is csv, statsd or otlp
+	Type string `mapstructure:"type"`
+	// Path is the file csv sinks append to
+	Path string `mapstructure:"path"`
+	// Address is the host:port of the StatsD server of statsd sinks
+	Address string `mapstructure:"address"`
+	// Prefix is prepended to the metric names of statsd sinks
+	Prefix string `mapstructure:"prefix"`
+	// Endpoint is the OTLP/HTTP endpoint of otlp sinks, e.g.
+	// http://localhost:4318
+	Endpoint string `mapstructure:"endpoint"`
+	// Headers are sent with the requests of otlp sinks, e.g. for
+	// authentication
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+// Validate checks that the settings of the sink type are given
+func (s *SinkConfig) Validate() error {
+	switch s.Type {
+	case SinkCSV:
+		if s.Path == "" {
+			return fmt.Errorf("invalid csv sink: path is required")
+		}
+	case SinkStatsD:
+		if _, _, err := net.SplitHostPort(s.Address); err != nil {
+			return fmt.Errorf("invalid statsd sink: address %q must be host:port", s.Address)
+		}
+	case SinkOTLP:
+		u, err := url.Parse(s.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid otlp sink: endpoint %q must be an http or https URL", s.Endpoint)
+		}
+	default:
+		return fmt.Errorf("invalid sink type: %q (valid: csv, statsd, otlp)", s.Type)
+	}
+	return nil
+}
+
+// String describes the sink in messages
+func (s *SinkConfig) String() string {
+	switch s.Type {
+	case SinkCSV:
+		return "csv " + s.Path
+	case SinkStatsD:
+		return "statsd " + s.Address
+	case SinkOTLP:
+		return "otlp " + s.Endpoint
+	}
+	return s.Type
+}
@@ -0,0 +1,109 @@
+// Package dataset computes the digests that identify dataset versions in
+// MLflow's dataset tracking
+package dataset
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Digest algorithms
+const (
+	// AlgorithmMLflow is the digest of a DataFrame read with pandas, as logged
+	// by mlflow.data.from_pandas
+	AlgorithmMLflow = "mlflow"
+	// AlgorithmSHA256 is the digest of the file contents
+	AlgorithmSHA256 = "sha256"
+)
+
+// digestLength is the length of MLflow's dataset digests
+const digestLength = 8
+
+// Digest is the digest of a dataset, with the size of the table it holds if
+// it was read as a table
+type Digest struct {
+	Digest    string
+	Algorithm string
+	// Rows and Elements are the num_rows and num_elements of MLflow's dataset
+	// profiles; both are 0 unless the dataset was read as a table
+	Rows     int64
+	Elements int64
+}
+
+// SHA256 returns the SHA-256 digest of a file, or of the relative paths and
+// contents of the files below a directory in path order, truncated to the
+// length of MLflow's digests
+func SHA256(path string) (*Digest, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	if !info.IsDir() {
+		if err := hashFile(hash, path); err != nil {
+			return nil, err
+		}
+	} else {
+		var files []string
+		err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.Type().IsRegular() {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+
+		// Paths and contents are length-prefixed, so that moving bytes between
+		// them changes the digest
+		for _, file := range files {
+			rel, err := filepath.Rel(path, file)
+			if err != nil {
+				return nil, err
+			}
+			rel = filepath.ToSlash(rel)
+			hash.Write(binary.BigEndian.AppendUint64(nil, uint64(len(rel))))
+			io.WriteString(hash, rel)
+			if err := hashFile(hash, file); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &Digest{
+		Digest:    hex.EncodeToString(hash.Sum(nil))[:digestLength],
+		Algorithm: AlgorithmSHA256,
+	}, nil
+}
+
+// hashFile writes the size and contents of a file to hash
+func hashFile(hash io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	hash.Write(binary.BigEndian.AppendUint64(nil, uint64(info.Size())))
+	if _, err := io.Copy(hash, file); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
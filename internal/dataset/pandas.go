@@ -0,0 +1,351 @@
+package dataset
+
+import (
+	"bufio"
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxDigestRows is the number of leading rows MLflow hashes for the digest of
+// a DataFrame
+const maxDigestRows = 10000
+
+// pandasNaN is the bit pattern of the NaN pandas stores for missing values;
+// Go's math.NaN differs from it
+const pandasNaN = 0x7FF8000000000000
+
+// pandasNAValues are the strings pandas.read_csv reads as missing by default
+var pandasNAValues = map[string]bool{
+	"": true, "#N/A": true, "#N/A N/A": true, "#NA": true, "-1.#IND": true,
+	"-1.#QNAN": true, "-NaN": true, "-nan": true, "1.#IND": true, "1.#QNAN": true,
+	"<NA>": true, "N/A": true, "NA": true, "NULL": true, "NaN": true,
+	"None": true, "n/a": true, "nan": true, "null": true,
+}
+
+// pandasHashKey is the SipHash key pandas hashes strings with
+var pandasHashKey = [2]uint64{
+	binary.LittleEndian.Uint64([]byte("01234567")),
+	binary.LittleEndian.Uint64([]byte("89123456")),
+}
+
+// columnKind is the dtype pandas.read_csv infers for a column, as far as the
+// digest depends on it
+type columnKind int
+
+const (
+	kindInt columnKind = iota
+	kindUint
+	kindFloat
+	kindBool
+	// kindString holds strings, and missing values as NaN
+	kindString
+)
+
+// csvColumn is a column of a CSV file as read by pandas. It keeps the values
+// of the rows that are hashed, and infers the dtype from all values.
+type csvColumn struct {
+	name   string
+	values []string
+	count  int64
+	// missing is set once a value is missing; the other flags are cleared
+	// once a value is not of their type
+	missing                   bool
+	notInt, notUint, notFloat bool
+	notBool                   bool
+}
+
+// add appends the value of the next row
+func (c *csvColumn) add(value string) {
+	if len(c.values) < maxDigestRows {
+		c.values = append(c.values, value)
+	}
+	c.count++
+
+	if pandasNAValues[value] {
+		c.missing = true
+		return
+	}
+	number := strings.TrimSpace(value)
+	if !c.notInt {
+		_, err := strconv.ParseInt(number, 10, 64)
+		c.notInt = err != nil
+	}
+	if !c.notUint {
+		_, err := strconv.ParseUint(strings.TrimPrefix(number, "+"), 10, 64)
+		c.notUint = err != nil
+	}
+	if !c.notFloat {
+		c.notFloat = parseFloat(number) != nil
+	}
+	if !c.notBool {
+		switch value {
+		case "True", "TRUE", "true", "False", "FALSE", "false":
+		default:
+			c.notBool = true
+		}
+	}
+}
+
+// kind returns the dtype pandas infers for the column: integers unless there
+// are missing values, then floats, then booleans, and strings otherwise
+func (c *csvColumn) kind() columnKind {
+	switch {
+	case c.count == 0:
+		// Empty columns are object columns, which hold only strings
+		return kindString
+	case !c.notInt && !c.missing:
+		return kindInt
+	case !c.notUint && !c.missing:
+		return kindUint
+	case !c.notInt || !c.notUint || !c.notFloat:
+		// Missing values turn integer columns into float columns
+		return kindFloat
+	case !c.notBool && !c.missing:
+		return kindBool
+	}
+	return kindString
+}
+
+// hashes returns the pandas hashes of the first rows values of the column
+func (c *csvColumn) hashes(kind columnKind, rows int) []uint64 {
+	result := make([]uint64, rows)
+	for i, value := range c.values[:rows] {
+		var bits uint64
+		switch kind {
+		case kindInt:
+			n, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			bits = uint64(n)
+		case kindUint:
+			bits, _ = strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(value), "+"), 10, 64)
+		case kindFloat:
+			bits = pandasNaN
+			if !pandasNAValues[value] {
+				f, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				bits = math.Float64bits(f)
+			}
+		case kindString:
+			bits = sipHash(pandasHashKey, []byte(value))
+		}
+		result[i] = mixHash(bits)
+	}
+	return result
+}
+
+// parseFloat checks that a value is a float as read by pandas, which does not
+// take Go's hexadecimal floats or digit separators
+func parseFloat(value string) error {
+	if strings.ContainsAny(value, "xX_") {
+		return strconv.ErrSyntax
+	}
+	_, err := strconv.ParseFloat(value, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		// Out of range values are read as infinities or zeros
+		return nil
+	}
+	return err
+}
+
+// PandasCSV returns MLflow's digest of the DataFrame pandas.read_csv reads
+// from r with its default options, like mlflow.data.from_pandas computes it:
+// the MD5 of the pandas hashes of the string and numeric columns of the
+// first 10000 rows, the number of rows and the column names. Type inference
+// follows pandas for common files; files pandas reads in chunks of mixed
+// types may get other digests.
+func PandasCSV(r io.Reader) (*Digest, error) {
+	reader := csv.NewReader(skipBOM(r))
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("no columns to parse from file")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]*csvColumn, len(header))
+	seen := make(map[string]bool)
+	for i, name := range header {
+		// Missing names are made up and duplicate names numbered like by pandas
+		if name == "" {
+			name = fmt.Sprintf("Unnamed: %d", i)
+		}
+		unique := name
+		for n := 1; seen[unique]; n++ {
+			unique = fmt.Sprintf("%s.%d", name, n)
+		}
+		seen[unique] = true
+		columns[i] = &csvColumn{name: unique}
+	}
+
+	var rows int64
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) > len(columns) {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: expected %d fields, saw %d", line, len(columns), len(record))
+		}
+		for i, column := range columns {
+			value := ""
+			if i < len(record) {
+				value = record[i]
+			}
+			column.add(value)
+		}
+		rows++
+	}
+
+	// String columns (only strings, no missing values) and numeric columns
+	// are hashed; pandas sorts their names when both kinds are present
+	var stringColumns, numericColumns []*csvColumn
+	kinds := make(map[*csvColumn]columnKind)
+	for _, column := range columns {
+		kind := column.kind()
+		kinds[column] = kind
+		switch {
+		case kind == kindString && !column.missing:
+			stringColumns = append(stringColumns, column)
+		case kind == kindInt || kind == kindUint || kind == kindFloat:
+			numericColumns = append(numericColumns, column)
+		}
+	}
+	hashed := append(stringColumns, numericColumns...)
+	if len(stringColumns) > 0 && len(numericColumns) > 0 {
+		sort.SliceStable(hashed, func(i, j int) bool { return hashed[i].name < hashed[j].name })
+	}
+
+	trimmed := int(min(rows, maxDigestRows))
+	arrays := make([][]uint64, 0, len(hashed)+1)
+	for _, column := range hashed {
+		arrays = append(arrays, column.hashes(kinds[column], trimmed))
+	}
+	// The index of the DataFrame is hashed as well
+	index := make([]uint64, trimmed)
+	for i := range index {
+		index[i] = mixHash(uint64(i))
+	}
+	arrays = append(arrays, index)
+
+	hash := md5.New()
+	var buf []byte
+	for _, value := range combineHashes(arrays, trimmed) {
+		buf = binary.LittleEndian.AppendUint64(buf, value)
+	}
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(rows))
+	hash.Write(buf)
+	for _, column := range columns {
+		io.WriteString(hash, column.name)
+	}
+
+	return &Digest{
+		Digest:    hex.EncodeToString(hash.Sum(nil))[:digestLength],
+		Algorithm: AlgorithmMLflow,
+		Rows:      rows,
+		Elements:  rows * int64(len(columns)),
+	}, nil
+}
+
+// skipBOM returns a reader of r without a leading UTF-8 byte order mark
+func skipBOM(r io.Reader) io.Reader {
+	reader := bufio.NewReader(r)
+	if bom, err := reader.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+		reader.Discard(3)
+	}
+	return reader
+}
+
+// mixHash redistributes a 64-bit value like pandas' hash_array
+func mixHash(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xBF58476D1CE4E5B9
+	x ^= x >> 27
+	x *= 0x94D049BB133111EB
+	x ^= x >> 31
+	return x
+}
+
+// combineHashes combines the hashes of the columns of rows rows into row
+// hashes like pandas' combine_hash_arrays
+func combineHashes(arrays [][]uint64, rows int) []uint64 {
+	out := make([]uint64, rows)
+	for i := range out {
+		out[i] = 0x345678
+	}
+	mult := uint64(1000003)
+	for i, array := range arrays {
+		inverse := uint64(len(arrays) - i)
+		for j := range out {
+			out[j] = (out[j] ^ array[j]) * mult
+		}
+		mult += 82520 + inverse + inverse
+	}
+	for i := range out {
+		out[i] += 97531
+	}
+	return out
+}
+
+// sipHash returns the SipHash-2-4 of data, which pandas hashes strings with
+func sipHash(key [2]uint64, data []byte) uint64 {
+	v0 := key[0] ^ 0x736f6d6570736575
+	v1 := key[1] ^ 0x646f72616e646f6d
+	v2 := key[0] ^ 0x6c7967656e657261
+	v3 := key[1] ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = v1<<13 | v1>>51
+		v1 ^= v0
+		v0 = v0<<32 | v0>>32
+		v2 += v3
+		v3 = v3<<16 | v3>>48
+		v3 ^= v2
+		v0 += v3
+		v3 = v3<<21 | v3>>43
+		v3 ^= v0
+		v2 += v1
+		v1 = v1<<17 | v1>>47
+		v1 ^= v2
+		v2 = v2<<32 | v2>>32
+	}
+
+	length := len(data)
+	for len(data) >= 8 {
+		m := binary.LittleEndian.Uint64(data)
+		v3 ^= m
+		round()
+		round()
+		v0 ^= m
+		data = data[8:]
+	}
+
+	last := uint64(length) << 56
+	for i, b := range data {
+		last |= uint64(b) << (8 * i)
+	}
+	v3 ^= last
+	round()
+	round()
+	v0 ^= last
+
+	v2 ^= 0xff
+	for i := 0; i < 4; i++ {
+		round()
+	}
+	return v0 ^ v1 ^ v2 ^ v3
+}
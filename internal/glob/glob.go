@@ -0,0 +1,147 @@
+// Package glob expands file patterns supporting the ** wildcard.
+package glob
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Match is a file matched by a pattern
+type Match struct {
+	// Path is the file path as found on disk
+	Path string
+	// RelPath is the slash-separated path relative to the pattern's base directory
+	RelPath string
+}
+
+// HasMeta reports whether the pattern contains glob metacharacters
+func HasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// Expand returns the regular files matching pattern, sorted by path. A pattern
+// without metacharacters matches the file itself. "**" matches any number of
+// directories, including none.
+func Expand(pattern string) ([]Match, error) {
+	pattern = filepath.ToSlash(pattern)
+
+	if !HasMeta(pattern) {
+		info, err := os.Stat(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("%s is a directory", pattern)
+		}
+		return []Match{{Path: filepath.FromSlash(pattern), RelPath: path.Base(pattern)}}, nil
+	}
+
+	base := baseDir(pattern)
+	var matches []Match
+	err := filepath.WalkDir(filepath.FromSlash(base), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		slashPath := filepath.ToSlash(p)
+		ok, err := MatchPath(pattern, slashPath)
+		if err != nil {
+			return err
+		}
+		if ok {
+			matches = append(matches, Match{Path: p, RelPath: relPath(base, slashPath)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Path < matches[j].Path
+	})
+	return matches, nil
+}
+
+// MatchPath reports whether the slash-separated name matches pattern
+func MatchPath(pattern, name string) (bool, error) {
+	return matchSegments(splitPath(pattern), splitPath(name))
+}
+
+// matchSegments matches path segments, expanding ** to zero or more segments
+func matchSegments(pattern, name []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Collapse consecutive ** and try every possible split point
+			rest := pattern[1:]
+			for i := 0; i <= len(name); i++ {
+				ok, err := matchSegments(rest, name[i:])
+				if err != nil || ok {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+
+		if len(name) == 0 {
+			return false, nil
+		}
+		ok, err := path.Match(pattern[0], name[0])
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern: %w", err)
+		}
+		if !ok {
+			return false, nil
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0, nil
+}
+
+// baseDir returns the leading directory of pattern without metacharacters
+func baseDir(pattern string) string {
+	var base []string
+	segments := splitPath(pattern)
+	for _, segment := range segments[:len(segments)-1] {
+		if HasMeta(segment) {
+			break
+		}
+		base = append(base, segment)
+	}
+
+	dir := strings.Join(base, "/")
+	if strings.HasPrefix(pattern, "/") {
+		dir = "/" + dir
+	}
+	if dir == "" {
+		dir = "."
+	}
+	return dir
+}
+
+// relPath returns name relative to base
+func relPath(base, name string) string {
+	if base == "." {
+		return strings.TrimPrefix(name, "./")
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(name, base), "/")
+}
+
+// splitPath splits a slash-separated path into non-empty segments, ignoring "."
+func splitPath(p string) []string {
+	var segments []string
+	for _, segment := range strings.Split(p, "/") {
+		if segment != "" && segment != "." {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
@@ -0,0 +1,152 @@
+// Package httperr turns HTTP error responses into readable error messages of
+// bounded size.
+package httperr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxBodySize bounds the bytes of an error response read into memory
+const MaxBodySize = 1 << 20
+
+// summaryLength is the number of characters error bodies are shortened to
+const summaryLength = 512
+
+// ShowFull disables the shortening of error bodies (--show-full-errors).
+// Bodies are still bounded by MaxBodySize.
+var ShowFull bool
+
+// ReadBody reads an error response body up to MaxBodySize bytes. truncated is
+// set if the body was longer.
+func ReadBody(r io.Reader) (body []byte, truncated bool) {
+	body, _ = io.ReadAll(io.LimitReader(r, MaxBodySize+1))
+	if len(body) > MaxBodySize {
+		return body[:MaxBodySize], true
+	}
+	return body, false
+}
+
+// ReadMessage reads an error response body and returns its Message
+func ReadMessage(r io.Reader) string {
+	body, truncated := ReadBody(r)
+	message := Message(body)
+	if truncated && ShowFull {
+		message += fmt.Sprintf(" (truncated at %d bytes)", MaxBodySize)
+	}
+	return message
+}
+
+// mlflowError is the error body of the MLflow and Databricks REST APIs
+type mlflowError struct {
+	ErrorCode string `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+var (
+	htmlHiddenRe = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)>`)
+	htmlTitleRe  = regexp.MustCompile(`(?is)<title>(.*?)</title>`)
+	htmlTagRe    = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// Message returns a readable message for an error response body: the
+// error_code and message of MLflow errors, or the text of other bodies with
+// HTML markup removed. Unless ShowFull is set, the text is shortened.
+func Message(body []byte) string {
+	var apiErr mlflowError
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+		if apiErr.ErrorCode == "" {
+			return apiErr.Message
+		}
+		return apiErr.ErrorCode + ": " + apiErr.Message
+	}
+
+	text := strings.TrimSpace(string(body))
+	if ShowFull {
+		return text
+	}
+	if isHTML(text) {
+		text = htmlText(text)
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	return shorten(text)
+}
+
+// isHTML reports whether a body looks like an HTML page
+func isHTML(text string) bool {
+	prefix := strings.ToLower(text[:min(len(text), 512)])
+	return strings.HasPrefix(prefix, "<!doctype html") || strings.Contains(prefix, "<html")
+}
+
+// htmlText returns the title and visible text of an HTML page
+func htmlText(page string) string {
+	var title string
+	if m := htmlTitleRe.FindStringSubmatch(page); m != nil {
+		title = strings.TrimSpace(m[1])
+	}
+	text := htmlHiddenRe.ReplaceAllString(page, " ")
+	text = htmlTagRe.ReplaceAllString(text, " ")
+	text = strings.TrimSpace(text)
+	// Error pages usually repeat the title as heading
+	text = strings.TrimSpace(strings.TrimPrefix(text, title))
+	if title == "" {
+		return text
+	}
+	if text == "" {
+		return title
+	}
+	return title + ": " + text
+}
+
+// shorten cuts text after summaryLength characters, noting how much was left out
+func shorten(text string) string {
+	if utf8.RuneCountInString(text) <= summaryLength {
+		return text
+	}
+	cut := 0
+	for i := range text {
+		if cut == summaryLength {
+			cut = i
+			break
+		}
+		cut++
+	}
+	return fmt.Sprintf("%s... (%d more bytes; use --show-full-errors to see all)", text[:cut], len(text)-cut)
+}
+
+// Transport bounds the bodies of error responses, and shortens bodies other
+// than JSON unless ShowFull is set, so that errors built from them by any
+// client stay readable
+type Transport struct {
+	next http.RoundTripper
+}
+
+// NewTransport returns a Transport sending requests with next
+func NewTransport(next http.RoundTripper) *Transport {
+	return &Transport{next: next}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode < 400 {
+		return resp, err
+	}
+
+	body, truncated := ReadBody(resp.Body)
+	resp.Body.Close()
+	if truncated || !ShowFull && !json.Valid(body) {
+		body = []byte(Message(body))
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return resp, nil
+}
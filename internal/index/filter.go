@@ -0,0 +1,302 @@
+package index
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// filterAttributes maps run attributes usable in filters to their column and
+// whether they are numeric
+var filterAttributes = map[string]struct {
+	column  string
+	numeric bool
+}{
+	"run_id":       {"r.run_id", false},
+	"run_name":     {"r.run_name", false},
+	"status":       {"r.status", false},
+	"artifact_uri": {"r.artifact_uri", false},
+	"start_time":   {"r.start_time", true},
+	"created":      {"r.start_time", true},
+	"end_time":     {"r.end_time", true},
+}
+
+// filterEntities maps the entity prefixes of filter identifiers to their
+// canonical names
+var filterEntities = map[string]string{
+	"metric": "metrics", "metrics": "metrics",
+	"param": "params", "params": "params", "parameter": "params", "parameters": "params",
+	"tag": "tags", "tags": "tags",
+	"attribute": "attributes", "attributes": "attributes", "attr": "attributes", "run": "attributes",
+}
+
+// filterComparison is one comparison of a filter, e.g. metrics.rmse < 0.5
+type filterComparison struct {
+	entity string
+	key    string
+	op     string
+	values []any
+}
+
+// compileFilter translates an MLflow search filter, comparisons joined by AND,
+// into an SQL condition on the runs table aliased r and its arguments
+func compileFilter(filter string) (string, []any, error) {
+	comparisons, err := parseFilter(filter)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid filter %q: %w", filter, err)
+	}
+
+	var conditions []string
+	var args []any
+	for _, c := range comparisons {
+		condition, conditionArgs, err := c.sql()
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid filter %q: %w", filter, err)
+		}
+		conditions = append(conditions, condition)
+		args = append(args, conditionArgs...)
+	}
+	if len(conditions) == 0 {
+		return "1 = 1", nil, nil
+	}
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// sql returns the SQL condition of a comparison
+func (c filterComparison) sql() (string, []any, error) {
+	numeric := false
+	var column string
+	switch c.entity {
+	case "metrics":
+		numeric, column = true, "m.value"
+	case "params", "tags":
+		column = "k.value"
+	case "attributes":
+		attribute, ok := filterAttributes[c.key]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown attribute %s", c.key)
+		}
+		numeric, column = attribute.numeric, attribute.column
+	}
+
+	for _, value := range c.values {
+		_, isNumber := value.(float64)
+		if numeric && !isNumber {
+			return "", nil, fmt.Errorf("%s.%s must be compared with a number", c.entity, c.key)
+		}
+		if !numeric && isNumber {
+			return "", nil, fmt.Errorf("%s.%s must be compared with a quoted string", c.entity, c.key)
+		}
+	}
+
+	var condition string
+	args := append([]any{}, c.values...)
+	switch c.op {
+	case "=", "!=", "<", "<=", ">", ">=":
+		condition = fmt.Sprintf("%s %s ?", column, c.op)
+	case "LIKE":
+		condition = fmt.Sprintf("%s LIKE ?", column)
+	case "ILIKE":
+		condition = fmt.Sprintf("lower(%s) LIKE lower(?)", column)
+	case "IN", "NOT IN":
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(c.values)), ", ")
+		condition = fmt.Sprintf("%s %s (%s)", column, c.op, placeholders)
+	}
+	if numeric && (c.op == "LIKE" || c.op == "ILIKE") {
+		return "", nil, fmt.Errorf("%s cannot be used with %s.%s", c.op, c.entity, c.key)
+	}
+
+	switch c.entity {
+	case "metrics":
+		return "EXISTS (SELECT 1 FROM metrics m WHERE m.run = r.id AND m.key = ? AND " + condition + ")", append([]any{c.key}, args...), nil
+	case "params", "tags":
+		return "EXISTS (SELECT 1 FROM " + c.entity + " k WHERE k.run = r.id AND k.key = ? AND " + condition + ")", append([]any{c.key}, args...), nil
+	}
+	return condition, args, nil
+}
+
+// filterParser parses MLflow search filters
+type filterParser struct {
+	input string
+	pos   int
+}
+
+// parseFilter parses comparisons joined by AND
+func parseFilter(filter string) ([]filterComparison, error) {
+	p := &filterParser{input: filter}
+	var comparisons []filterComparison
+	p.skipSpace()
+	if p.done() {
+		return nil, nil
+	}
+	for {
+		comparison, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		comparisons = append(comparisons, comparison)
+
+		p.skipSpace()
+		if p.done() {
+			return comparisons, nil
+		}
+		if !strings.EqualFold(p.word(), "AND") {
+			return nil, fmt.Errorf("expected AND at position %d", p.pos+1)
+		}
+	}
+}
+
+// comparison parses entity.key op value
+func (p *filterParser) comparison() (filterComparison, error) {
+	p.skipSpace()
+	start := p.pos
+	entityName := p.word()
+	entity, ok := filterEntities[strings.ToLower(entityName)]
+	if !ok || p.done() || p.input[p.pos] != '.' {
+		return filterComparison{}, fmt.Errorf("expected metrics.<key>, params.<key>, tags.<key> or attributes.<name> at position %d", start+1)
+	}
+	p.pos++
+
+	var key string
+	if !p.done() && (p.input[p.pos] == '`' || p.input[p.pos] == '"') {
+		quoted, err := p.quoted()
+		if err != nil {
+			return filterComparison{}, err
+		}
+		key = quoted
+	} else {
+		key = p.identifier()
+	}
+	if key == "" {
+		return filterComparison{}, fmt.Errorf("missing key at position %d", p.pos+1)
+	}
+
+	op, err := p.operator()
+	if err != nil {
+		return filterComparison{}, err
+	}
+
+	comparison := filterComparison{entity: entity, key: key, op: op}
+	p.skipSpace()
+	if op == "IN" || op == "NOT IN" {
+		if p.done() || p.input[p.pos] != '(' {
+			return filterComparison{}, fmt.Errorf("expected ( after %s at position %d", op, p.pos+1)
+		}
+		p.pos++
+		for {
+			value, err := p.value()
+			if err != nil {
+				return filterComparison{}, err
+			}
+			comparison.values = append(comparison.values, value)
+			p.skipSpace()
+			if !p.done() && p.input[p.pos] == ',' {
+				p.pos++
+				continue
+			}
+			if !p.done() && p.input[p.pos] == ')' {
+				p.pos++
+				return comparison, nil
+			}
+			return filterComparison{}, fmt.Errorf("expected , or ) at position %d", p.pos+1)
+		}
+	}
+
+	value, err := p.value()
+	if err != nil {
+		return filterComparison{}, err
+	}
+	comparison.values = []any{value}
+	return comparison, nil
+}
+
+// operator parses a comparison operator
+func (p *filterParser) operator() (string, error) {
+	p.skipSpace()
+	for _, op := range []string{"!=", "<=", ">=", "=", "<", ">"} {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			p.pos += len(op)
+			return op, nil
+		}
+	}
+
+	start := p.pos
+	switch word := strings.ToUpper(p.word()); word {
+	case "LIKE", "ILIKE", "IN":
+		return word, nil
+	case "NOT":
+		if strings.EqualFold(p.word(), "IN") {
+			return "NOT IN", nil
+		}
+	}
+	return "", fmt.Errorf("expected a comparison operator at position %d", start+1)
+}
+
+// value parses a quoted string or a number
+func (p *filterParser) value() (any, error) {
+	p.skipSpace()
+	if p.done() {
+		return nil, fmt.Errorf("missing value at end of filter")
+	}
+	if p.input[p.pos] == '\'' || p.input[p.pos] == '"' {
+		return p.quoted()
+	}
+
+	start := p.pos
+	for !p.done() && strings.ContainsRune("+-.0123456789eE", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	number, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil {
+		return nil, fmt.Errorf("expected a quoted string or a number at position %d", start+1)
+	}
+	return number, nil
+}
+
+// quoted parses a string in single, double or back quotes
+func (p *filterParser) quoted() (string, error) {
+	quote := p.input[p.pos]
+	start := p.pos
+	p.pos++
+	end := strings.IndexByte(p.input[p.pos:], quote)
+	if end < 0 {
+		return "", fmt.Errorf("unterminated quote at position %d", start+1)
+	}
+	value := p.input[p.pos : p.pos+end]
+	p.pos += end + 1
+	return value, nil
+}
+
+// word parses a run of letters, digits and underscores after spaces
+func (p *filterParser) word() string {
+	p.skipSpace()
+	start := p.pos
+	for !p.done() && isWordChar(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// identifier parses an unquoted key, which may contain dots, dashes and slashes
+func (p *filterParser) identifier() string {
+	start := p.pos
+	for !p.done() && (isWordChar(rune(p.input[p.pos])) || strings.ContainsRune(".-/", rune(p.input[p.pos]))) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *filterParser) skipSpace() {
+	for !p.done() && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *filterParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func isWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,297 @@
+// Package index mirrors run metadata and final metrics of experiments into a
+// local SQLite database, so that runs can be searched instantly and offline.
+package index
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	// Pure Go SQLite driver, registered as "sqlite"
+	_ "modernc.org/sqlite"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// schema creates the index tables. Experiments and runs are keyed by the
+// tracking URI they were synced from, since experiment and run IDs are only
+// unique per tracking server.
+const schema = `
+CREATE TABLE IF NOT EXISTS experiments (
+	tracking_uri  TEXT NOT NULL,
+	experiment_id TEXT NOT NULL,
+	name          TEXT NOT NULL,
+	synced_at     INTEGER NOT NULL,
+	PRIMARY KEY (tracking_uri, experiment_id)
+);
+CREATE TABLE IF NOT EXISTS runs (
+	id            INTEGER PRIMARY KEY,
+	tracking_uri  TEXT NOT NULL,
+	experiment_id TEXT NOT NULL,
+	run_id        TEXT NOT NULL,
+	run_name      TEXT NOT NULL,
+	status        TEXT NOT NULL,
+	start_time    INTEGER NOT NULL,
+	end_time      INTEGER,
+	artifact_uri  TEXT NOT NULL,
+	UNIQUE (tracking_uri, run_id)
+);
+CREATE INDEX IF NOT EXISTS runs_experiment ON runs (tracking_uri, experiment_id);
+CREATE TABLE IF NOT EXISTS params (
+	run   INTEGER NOT NULL,
+	key   TEXT NOT NULL,
+	value TEXT NOT NULL,
+	PRIMARY KEY (run, key)
+);
+CREATE TABLE IF NOT EXISTS metrics (
+	run   INTEGER NOT NULL,
+	key   TEXT NOT NULL,
+	value REAL NOT NULL,
+	PRIMARY KEY (run, key)
+);
+CREATE TABLE IF NOT EXISTS tags (
+	run   INTEGER NOT NULL,
+	key   TEXT NOT NULL,
+	value TEXT NOT NULL,
+	PRIMARY KEY (run, key)
+);
+`
+
+// Index is a local database of synced experiments
+type Index struct {
+	db *sql.DB
+}
+
+// SyncedExperiment describes an experiment in the index
+type SyncedExperiment struct {
+	ExperimentID string
+	Name         string
+	SyncedAt     time.Time
+}
+
+// DefaultPath returns the default location of the index in the user cache
+// directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(dir, "mlflow-cli", "index.db"), nil
+}
+
+// Open opens the index at path, creating it if it does not exist
+func Open(path string) (*Index, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	// LIKE is case-sensitive in MLflow filters
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=case_sensitive_like(1)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index %s: %w", path, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize index %s: %w", path, err)
+	}
+	return &Index{db: db}, nil
+}
+
+// Close closes the database
+func (ix *Index) Close() error {
+	return ix.db.Close()
+}
+
+// Sync replaces the indexed runs of an experiment with runs
+func (ix *Index) Sync(ctx context.Context, trackingURI string, experiment *models.Experiment, runs []*models.RunInfo) error {
+	tx, err := ix.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := deleteRuns(ctx, tx, "tracking_uri = ? AND experiment_id = ?", trackingURI, experiment.ExperimentID); err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		var endTime any
+		if run.EndTime != nil {
+			endTime = run.EndTime.UnixMilli()
+		}
+		// Runs moved from another synced experiment are replaced
+		if err := deleteRuns(ctx, tx, "tracking_uri = ? AND run_id = ?", trackingURI, run.RunID); err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx,
+			"INSERT INTO runs (tracking_uri, experiment_id, run_id, run_name, status, start_time, end_time, artifact_uri) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			trackingURI, experiment.ExperimentID, run.RunID, run.RunName, run.Status, run.StartTime.UnixMilli(), endTime, run.ArtifactURI)
+		if err != nil {
+			return fmt.Errorf("failed to index run %s: %w", run.RunID, err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		for key, value := range run.Params {
+			if _, err := tx.ExecContext(ctx, "INSERT INTO params (run, key, value) VALUES (?, ?, ?)", id, key, value); err != nil {
+				return fmt.Errorf("failed to index params of run %s: %w", run.RunID, err)
+			}
+		}
+		for key, value := range run.Metrics {
+			if _, err := tx.ExecContext(ctx, "INSERT INTO metrics (run, key, value) VALUES (?, ?, ?)", id, key, value); err != nil {
+				return fmt.Errorf("failed to index metrics of run %s: %w", run.RunID, err)
+			}
+		}
+		for key, value := range run.Tags {
+			if _, err := tx.ExecContext(ctx, "INSERT INTO tags (run, key, value) VALUES (?, ?, ?)", id, key, value); err != nil {
+				return fmt.Errorf("failed to index tags of run %s: %w", run.RunID, err)
+			}
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT OR REPLACE INTO experiments (tracking_uri, experiment_id, name, synced_at) VALUES (?, ?, ?, ?)",
+		trackingURI, experiment.ExperimentID, experiment.Name, time.Now().UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to index experiment: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// deleteRuns deletes the runs matching an SQL condition with their params,
+// metrics and tags
+func deleteRuns(ctx context.Context, tx *sql.Tx, condition string, args ...any) error {
+	for _, table := range []string{"params", "metrics", "tags"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE run IN (SELECT id FROM runs WHERE "+condition+")", args...); err != nil {
+			return fmt.Errorf("failed to clear indexed %s: %w", table, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM runs WHERE "+condition, args...); err != nil {
+		return fmt.Errorf("failed to clear indexed runs: %w", err)
+	}
+	return nil
+}
+
+// Experiment returns a synced experiment by ID or, if byName is set, by name.
+// ok is false if the experiment was never synced.
+func (ix *Index) Experiment(ctx context.Context, trackingURI, experiment string, byName bool) (experimentInfo SyncedExperiment, ok bool, err error) {
+	column := "experiment_id"
+	if byName {
+		column = "name"
+	}
+
+	var syncedAt int64
+	err = ix.db.QueryRowContext(ctx, "SELECT experiment_id, name, synced_at FROM experiments WHERE tracking_uri = ? AND "+column+" = ?",
+		trackingURI, experiment).Scan(&experimentInfo.ExperimentID, &experimentInfo.Name, &syncedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return SyncedExperiment{}, false, nil
+	}
+	if err != nil {
+		return SyncedExperiment{}, false, err
+	}
+	experimentInfo.SyncedAt = time.UnixMilli(syncedAt)
+	return experimentInfo, true, nil
+}
+
+// SearchRuns returns the indexed runs of an experiment matching an MLflow
+// search filter, newest first, stopping after max runs (max <= 0: no limit)
+func (ix *Index) SearchRuns(ctx context.Context, trackingURI, experimentID, filter string, max int) ([]*models.RunInfo, error) {
+	condition, args, err := compileFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT r.id, r.run_id, r.run_name, r.status, r.start_time, r.end_time, r.artifact_uri FROM runs r " +
+		"WHERE r.tracking_uri = ? AND r.experiment_id = ? AND " + condition + " ORDER BY r.start_time DESC, r.run_id"
+	args = append([]any{trackingURI, experimentID}, args...)
+	if max > 0 {
+		query += " LIMIT ?"
+		args = append(args, max)
+	}
+
+	rows, err := ix.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search index: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*models.RunInfo
+	byID := make(map[int64]*models.RunInfo)
+	for rows.Next() {
+		var id, startTime int64
+		var endTime sql.NullInt64
+		run := &models.RunInfo{
+			ExperimentID: experimentID,
+			Params:       make(map[string]string),
+			Metrics:      make(map[string]float64),
+			Tags:         make(map[string]string),
+		}
+		if err := rows.Scan(&id, &run.RunID, &run.RunName, &run.Status, &startTime, &endTime, &run.ArtifactURI); err != nil {
+			return nil, err
+		}
+		run.StartTime = time.UnixMilli(startTime)
+		if endTime.Valid {
+			t := time.UnixMilli(endTime.Int64)
+			run.EndTime = &t
+		}
+		runs = append(runs, run)
+		byID[id] = run
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := ix.loadRunData(ctx, trackingURI, experimentID, byID); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// loadRunData fills the params, metrics and tags of runs by database ID
+func (ix *Index) loadRunData(ctx context.Context, trackingURI, experimentID string, runs map[int64]*models.RunInfo) error {
+	for _, table := range []string{"params", "metrics", "tags"} {
+		rows, err := ix.db.QueryContext(ctx, "SELECT d.run, d.key, d.value FROM "+table+" d JOIN runs r ON r.id = d.run WHERE r.tracking_uri = ? AND r.experiment_id = ?",
+			trackingURI, experimentID)
+		if err != nil {
+			return fmt.Errorf("failed to read indexed %s: %w", table, err)
+		}
+		for rows.Next() {
+			var id int64
+			var key string
+			var value any
+			if err := rows.Scan(&id, &key, &value); err != nil {
+				rows.Close()
+				return err
+			}
+			run, ok := runs[id]
+			if !ok {
+				continue
+			}
+			switch table {
+			case "params":
+				run.Params[key] = fmt.Sprint(value)
+			case "metrics":
+				run.Metrics[key], _ = value.(float64)
+			case "tags":
+				run.Tags[key] = fmt.Sprint(value)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	for _, run := range runs {
+		run.Description = run.Tags["mlflow.note.content"]
+		run.ParentRunID = run.Tags["mlflow.parentRunId"]
+	}
+	return nil
+}
@@ -3,15 +3,20 @@ package mlflow
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/databricks/databricks-sdk-go/httpclient"
 	"github.com/databricks/databricks-sdk-go/service/ml"
+
+	"github.com/imishinist/mlflow-cli/internal/httperr"
 )
 
 // CredentialsForWriteRequest represents the request for credentials-for-write API
@@ -42,6 +47,10 @@ type HTTPHeader struct {
 
 // UploadArtifact uploads a file as an artifact to the specified run
 func (c *Client) UploadArtifact(ctx context.Context, runID, filePath, artifactPath string) error {
+	if err := c.checkRunUnlocked(ctx, runID); err != nil {
+		return err
+	}
+
 	// Get the artifact URI from the run info
 	artifactURI, err := c.getArtifactURI(ctx, runID)
 	if err != nil {
@@ -53,17 +62,107 @@ func (c *Client) UploadArtifact(ctx context.Context, runID, filePath, artifactPa
 		artifactPath = filepath.Base(filePath)
 	}
 
-	// Upload to the appropriate storage based on artifact URI
-	return c.uploadToStorage(ctx, artifactURI, filePath, artifactPath)
+	return c.uploadFile(ctx, artifactURI, ArtifactUpload{FilePath: filePath, ArtifactPath: artifactPath}, nil)
+}
+
+// UploadArtifactFromReader uploads size bytes read from body as an artifact
+func (c *Client) UploadArtifactFromReader(ctx context.Context, runID string, body io.Reader, size int64, artifactPath string) error {
+	if err := c.checkRunUnlocked(ctx, runID); err != nil {
+		return err
+	}
+	artifactURI, err := c.getArtifactURI(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("failed to get artifact URI: %w", err)
+	}
+
+	return c.uploadToStorage(ctx, artifactURI, body, size, artifactPath)
 }
 
 // UploadArtifacts uploads multiple files as artifacts to the specified run
 func (c *Client) UploadArtifacts(ctx context.Context, runID string, files map[string]string) error {
+	uploads := make([]ArtifactUpload, 0, len(files))
 	for filePath, artifactPath := range files {
-		if err := c.UploadArtifact(ctx, runID, filePath, artifactPath); err != nil {
-			return fmt.Errorf("failed to upload %s: %w", filePath, err)
+		uploads = append(uploads, ArtifactUpload{FilePath: filePath, ArtifactPath: artifactPath})
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		return uploads[i].FilePath < uploads[j].FilePath
+	})
+
+	errs, err := c.UploadArtifactsParallel(ctx, runID, uploads, 1, nil)
+	if err != nil {
+		return err
+	}
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", uploads[i].FilePath, err)
+		}
+	}
+	return nil
+}
+
+// UploadArtifactsParallel uploads files with up to parallelism concurrent
+// uploads, resolving the run's artifact URI once. If progress is not nil, it
+// is called with the number of finished uploads after each upload. A failed
+// upload does not stop the others: the returned slice holds the error of each
+// upload, nil for uploads that succeeded. The error is only set if no upload
+// could be started.
+func (c *Client) UploadArtifactsParallel(ctx context.Context, runID string, uploads []ArtifactUpload, parallelism int, progress func(done, total int)) ([]error, error) {
+	return c.UploadArtifactsResumable(ctx, runID, uploads, parallelism, nil, progress)
+}
+
+// UploadArtifactsResumable uploads files like UploadArtifactsParallel and
+// records their progress in journal. Files larger than the journal's part
+// size are uploaded in parts to MLflow Artifacts Service stores that support
+// multipart uploads, continuing unfinished multipart uploads of the journal.
+// A nil journal uploads every file in one request.
+func (c *Client) UploadArtifactsResumable(ctx context.Context, runID string, uploads []ArtifactUpload, parallelism int, journal *UploadJournal, progress func(done, total int)) ([]error, error) {
+	if len(uploads) == 0 {
+		return nil, nil
+	}
+	if err := c.checkRunUnlocked(ctx, runID); err != nil {
+		return nil, err
+	}
+	artifactURI, err := c.getArtifactURI(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact URI: %w", err)
+	}
+
+	errs := make([]error, len(uploads))
+	runParallel(len(uploads), parallelism, progress, func(i int) {
+		errs[i] = c.uploadFile(ctx, artifactURI, uploads[i], journal)
+	})
+	if journal != nil {
+		if err := journal.flush(); err != nil {
+			return errs, err
+		}
+	}
+	return errs, nil
+}
+
+// uploadFile uploads a local file to an artifact store, recording it in
+// journal if it is not nil
+func (c *Client) uploadFile(ctx context.Context, artifactURI string, upload ArtifactUpload, journal *UploadJournal) error {
+	// Open file and get info
+	file, fileInfo, err := c.openFileWithInfo(upload.FilePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if journal != nil && c.plan == nil && strings.HasPrefix(artifactURI, "mlflow-artifacts:/") && fileInfo.Size() > journal.PartSize {
+		err := c.uploadMultipart(ctx, artifactURI, upload, file, fileInfo, journal)
+		if !errors.Is(err, errMultipartUnsupported) {
+			return err
 		}
 	}
+
+	// Upload to the appropriate storage based on artifact URI
+	if err := c.uploadToStorage(ctx, artifactURI, file, fileInfo.Size(), upload.ArtifactPath); err != nil {
+		return err
+	}
+	if journal != nil && c.plan == nil {
+		return journal.complete(upload, fileInfo)
+	}
 	return nil
 }
 
@@ -90,9 +189,11 @@ func (c *Client) createPutRequest(ctx context.Context, url string, body io.Reade
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	req.ContentLength = contentLength
 	req.Header.Set("Content-Type", "application/octet-stream")
 	req.Header.Set("Content-Length", fmt.Sprintf("%d", contentLength))
 	c.addAuthHeaders(req)
+	replayable(req, body)
 
 	return req, nil
 }
@@ -127,17 +228,16 @@ func (c *Client) getArtifactURIFromHTTP(ctx context.Context, runID string) (stri
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
+	c.addAuthHeaders(req)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("get run request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return "", fmt.Errorf("get run request failed with status %d: %s", resp.StatusCode, httperr.ReadMessage(resp.Body))
 	}
 
 	var runResponse struct {
@@ -159,67 +259,97 @@ func (c *Client) getArtifactURIFromHTTP(ctx context.Context, runID string) (stri
 	return runResponse.Run.Info.ArtifactURI, nil
 }
 
-// uploadToStorage uploads file to the appropriate storage based on URI scheme
-func (c *Client) uploadToStorage(ctx context.Context, artifactURI, filePath, artifactPath string) error {
+// uploadToStorage uploads content to the appropriate storage based on URI
+// scheme, bounded by the transfer timeout and the bandwidth limit
+func (c *Client) uploadToStorage(ctx context.Context, artifactURI string, body io.Reader, size int64, artifactPath string) error {
+	ctx, cancel := c.transferContext(ctx)
+	defer cancel()
+	body = c.limitReader(ctx, body)
+
+	var err error
 	if strings.HasPrefix(artifactURI, "mlflow-artifacts:/") {
-		return c.uploadToMLflowArtifacts(ctx, artifactURI, filePath, artifactPath)
+		err = c.uploadToMLflowArtifacts(ctx, artifactURI, body, size, artifactPath)
 	} else if strings.HasPrefix(artifactURI, "dbfs:/") {
-		return c.uploadToDBFS(ctx, artifactURI, filePath, artifactPath)
+		err = c.uploadToDBFS(ctx, artifactURI, body, size, artifactPath)
+	} else if strings.HasPrefix(artifactURI, "s3://") {
+		err = c.uploadToS3(ctx, artifactURI, body, size, artifactPath)
+	} else if strings.HasPrefix(artifactURI, "gs://") {
+		err = c.uploadToGCS(ctx, artifactURI, body, artifactPath)
+	} else if isAzureURI(artifactURI) {
+		err = c.uploadToAzure(ctx, artifactURI, body, artifactPath)
+	} else if strings.HasPrefix(artifactURI, "sftp://") {
+		err = c.uploadToSFTP(ctx, artifactURI, body, artifactPath)
 	} else if strings.HasPrefix(artifactURI, "file://") || strings.HasPrefix(artifactURI, "/") {
-		return c.uploadToLocalFS(ctx, artifactURI, filePath, artifactPath)
+		err = c.uploadToLocalFS(ctx, artifactURI, body, artifactPath)
 	} else {
 		return fmt.Errorf("unsupported artifact URI scheme: %s", artifactURI)
 	}
+	return c.transferError(ctx, err)
 }
 
 // uploadToMLflowArtifacts uploads using MLflow Artifacts Service
-func (c *Client) uploadToMLflowArtifacts(ctx context.Context, artifactURI, filePath, artifactPath string) error {
-	// Extract experiment_id and run_id from artifact URI
-	experimentID, runID, err := c.extractIDsFromArtifactURI(artifactURI)
-	if err != nil {
-		return fmt.Errorf("failed to extract IDs from artifact URI: %w", err)
-	}
-
-	// Open file and get info
-	file, fileInfo, err := c.openFileWithInfo(filePath)
+func (c *Client) uploadToMLflowArtifacts(ctx context.Context, artifactURI string, body io.Reader, size int64, artifactPath string) error {
+	url, err := c.mlflowArtifactsURL(artifactURI, artifactPath)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-
-	// Build URL: /api/2.0/mlflow-artifacts/artifacts/{experiment_id}/{run_id}/artifacts/{artifact_path}
-	baseURL := strings.TrimSuffix(c.config.TrackingURI, "/")
-	url := fmt.Sprintf("%s/api/2.0/mlflow-artifacts/artifacts/%s/%s/artifacts/%s", baseURL, experimentID, runID, artifactPath)
 
 	// Create HTTP request
-	req, err := c.createPutRequest(ctx, url, file, fileInfo.Size())
+	req, err := c.createPutRequest(ctx, url, body, size)
 	if err != nil {
 		return err
 	}
 
 	// Send request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to upload to MLflow Artifacts Service: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("MLflow Artifacts Service upload failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return fmt.Errorf("MLflow Artifacts Service upload failed with status %d: %s", resp.StatusCode, httperr.ReadMessage(resp.Body))
 	}
 
 	return nil
 }
 
-// uploadToLocalFS uploads file to local filesystem
-func (c *Client) uploadToLocalFS(ctx context.Context, artifactURI, filePath, artifactPath string) error {
+// mlflowArtifactsURL returns the MLflow Artifacts Service URL of an artifact
+func (c *Client) mlflowArtifactsURL(artifactURI, artifactPath string) (string, error) {
+	// mlflow-artifacts:/{experiment_id}/{run_id}/artifacts, or
+	// mlflow-artifacts:/{experiment_id}/models/{model_id}/artifacts for logged models
+	root := strings.Trim(strings.TrimPrefix(artifactURI, "mlflow-artifacts:"), "/")
+	if strings.Count(root, "/") < 2 {
+		return "", fmt.Errorf("invalid mlflow-artifacts URI format: %s", artifactURI)
+	}
+
+	// Artifact names may contain characters with a meaning in URLs, like the
+	// '%' and '#' of logged images
+	segments := strings.Split(artifactPath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	// Build URL: /api/2.0/mlflow-artifacts/artifacts/{root}/{artifact_path}
+	baseURL := strings.TrimSuffix(c.config.TrackingURI, "/")
+	return fmt.Sprintf("%s/api/2.0/mlflow-artifacts/artifacts/%s/%s", baseURL, root, strings.Join(segments, "/")), nil
+}
+
+// localArtifactPath returns the local filesystem path of an artifact
+func localArtifactPath(artifactURI, artifactPath string) string {
 	localPath := strings.TrimPrefix(artifactURI, "file://")
 	if !strings.HasSuffix(localPath, "/") {
 		localPath += "/"
 	}
-	localPath += artifactPath
+	return localPath + artifactPath
+}
+
+// uploadToLocalFS writes content to the local filesystem
+func (c *Client) uploadToLocalFS(ctx context.Context, artifactURI string, body io.Reader, artifactPath string) error {
+	localPath := localArtifactPath(artifactURI, artifactPath)
+	if c.plan != nil {
+		return c.plan.recordUpload("file://"+localPath, body)
+	}
 
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(localPath)
@@ -227,13 +357,6 @@ func (c *Client) uploadToLocalFS(ctx context.Context, artifactURI, filePath, art
 		return fmt.Errorf("failed to create directory %s: %w", dir, err)
 	}
 
-	// Copy file
-	sourceFile, err := os.Open(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to open source file: %w", err)
-	}
-	defer sourceFile.Close()
-
 	destFile, err := os.Create(localPath)
 	if err != nil {
 		return fmt.Errorf("failed to create destination file: %w", err)
@@ -241,7 +364,7 @@ func (c *Client) uploadToLocalFS(ctx context.Context, artifactURI, filePath, art
 	defer destFile.Close()
 
 	// Copy content
-	_, err = destFile.ReadFrom(sourceFile)
+	_, err = destFile.ReadFrom(body)
 	if err != nil {
 		return fmt.Errorf("failed to copy file content: %w", err)
 	}
@@ -283,11 +406,13 @@ func (c *Client) addAuthHeaders(req *http.Request) {
 		} else if c.config.DatabricksToken != "" {
 			req.Header.Set("Authorization", "Bearer "+c.config.DatabricksToken)
 		}
+	} else if c.config.TrackingUsername != "" {
+		req.SetBasicAuth(c.config.TrackingUsername, c.config.TrackingPassword)
 	}
 }
 
-// uploadToDBFS uploads file to DBFS using Databricks Artifacts API
-func (c *Client) uploadToDBFS(ctx context.Context, artifactURI, filePath, artifactPath string) error {
+// uploadToDBFS uploads content to DBFS using Databricks Artifacts API
+func (c *Client) uploadToDBFS(ctx context.Context, artifactURI string, body io.Reader, size int64, artifactPath string) error {
 	// Extract run_id from artifactURI
 	runID, err := c.extractRunIDFromDBFSURI(artifactURI)
 	if err != nil {
@@ -305,7 +430,7 @@ func (c *Client) uploadToDBFS(ctx context.Context, artifactURI, filePath, artifa
 	}
 
 	// Upload to signed URI (supports all credential types)
-	err = c.uploadToSignedURI(ctx, credentials[0], filePath)
+	err = c.uploadToSignedURI(ctx, credentials[0], body, size)
 	if err != nil {
 		return fmt.Errorf("failed to upload to %s signed URI: %w", credentials[0].Type, err)
 	}
@@ -365,17 +490,10 @@ func (c *Client) getCredentialsForWrite(ctx context.Context, runID string, paths
 	return nil, fmt.Errorf("non-Databricks MLflow servers not supported for DBFS artifacts")
 }
 
-// uploadToSignedURI uploads file to any type of signed URI
-func (c *Client) uploadToSignedURI(ctx context.Context, credential ArtifactCredentialInfo, filePath string) error {
-	// Open file
-	file, fileInfo, err := c.openFileWithInfo(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
+// uploadToSignedURI uploads content to any type of signed URI
+func (c *Client) uploadToSignedURI(ctx context.Context, credential ArtifactCredentialInfo, body io.Reader, size int64) error {
 	// Create request based on credential type
-	req, err := c.createSignedURIRequest(ctx, credential, file, fileInfo.Size())
+	req, err := c.createSignedURIRequest(ctx, credential, body, size)
 	if err != nil {
 		return err
 	}
@@ -394,6 +512,7 @@ func (c *Client) createSignedURIRequest(ctx context.Context, credential Artifact
 	// Set Content-Length explicitly (required by some cloud providers)
 	req.ContentLength = contentLength
 	req.Header.Set("Content-Length", fmt.Sprintf("%d", contentLength))
+	replayable(req, body)
 
 	// Set type-specific headers
 	switch credential.Type {
@@ -427,8 +546,7 @@ func (c *Client) createSignedURIRequest(ctx context.Context, credential Artifact
 
 // sendSignedURIRequest sends request and handles response
 func (c *Client) sendSignedURIRequest(req *http.Request) error {
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to upload to signed URI: %w", err)
 	}
@@ -436,8 +554,7 @@ func (c *Client) sendSignedURIRequest(req *http.Request) error {
 
 	// Check status code
 	if !c.isSuccessStatusCode(resp.StatusCode) {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("signed URI upload failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return fmt.Errorf("signed URI upload failed with status %d: %s", resp.StatusCode, httperr.ReadMessage(resp.Body))
 	}
 
 	return nil
@@ -0,0 +1,125 @@
+package mlflow
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/imishinist/mlflow-cli/internal/httperr"
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// WriteArtifactsArchive writes all files below an artifact directory of a run
+// to w as a gzip-compressed tar archive, with paths relative to the directory.
+// It returns the archived files.
+func (c *Client) WriteArtifactsArchive(ctx context.Context, runID, artifactPath string, w io.Writer) ([]models.ArtifactInfo, error) {
+	artifactPath = strings.Trim(artifactPath, "/")
+	files, err := c.ListArtifactsRecursive(ctx, runID, artifactPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no artifacts found at %q", artifactPath)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	modTime := time.Now()
+	for _, file := range files {
+		name := strings.TrimPrefix(strings.TrimPrefix(file.Path, artifactPath), "/")
+		if err := c.archiveArtifact(ctx, tw, runID, file, name, modTime); err != nil {
+			return nil, fmt.Errorf("failed to archive %s: %w", file.Path, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// archiveArtifact streams a single artifact file into a tar archive
+func (c *Client) archiveArtifact(ctx context.Context, tw *tar.Writer, runID string, file models.ArtifactInfo, name string, modTime time.Time) error {
+	body, size, err := c.OpenArtifact(ctx, runID, file.Path)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// Tar headers need the size up front
+	if size < 0 {
+		size = file.FileSize
+	}
+
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, body)
+	return err
+}
+
+// DeleteArtifact deletes an artifact file of a run. Only artifacts served by
+// the MLflow Artifacts Service or stored on the local filesystem can be deleted.
+func (c *Client) DeleteArtifact(ctx context.Context, runID, artifactPath string) error {
+	if err := c.checkRunUnlocked(ctx, runID); err != nil {
+		return err
+	}
+
+	artifactURI, err := c.getArtifactURI(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("failed to get artifact URI: %w", err)
+	}
+
+	if strings.HasPrefix(artifactURI, "mlflow-artifacts:/") {
+		return c.deleteFromMLflowArtifacts(ctx, artifactURI, artifactPath)
+	} else if strings.HasPrefix(artifactURI, "file://") || strings.HasPrefix(artifactURI, "/") {
+		localPath := localArtifactPath(artifactURI, artifactPath)
+		if c.plan != nil {
+			c.plan.add(PlannedRequest{Method: "DELETE", URL: "file://" + localPath})
+			return nil
+		}
+		return os.Remove(localPath)
+	}
+	return fmt.Errorf("deleting artifacts is not supported for artifact URI: %s", artifactURI)
+}
+
+// deleteFromMLflowArtifacts deletes using MLflow Artifacts Service
+func (c *Client) deleteFromMLflowArtifacts(ctx context.Context, artifactURI, artifactPath string) error {
+	url, err := c.mlflowArtifactsURL(artifactURI, artifactPath)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	c.addAuthHeaders(req)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete from MLflow Artifacts Service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !c.isSuccessStatusCode(resp.StatusCode) {
+		return fmt.Errorf("MLflow Artifacts Service delete failed with status %d: %s", resp.StatusCode, httperr.ReadMessage(resp.Body))
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+
+	"github.com/imishinist/mlflow-cli/internal/proxy"
+)
+
+// Environment variables selecting Azure credentials, as for the MLflow client
+const (
+	azureConnectionStringEnv = "AZURE_STORAGE_CONNECTION_STRING"
+	azureAccessKeyEnv        = "AZURE_STORAGE_ACCESS_KEY"
+)
+
+// azureBlockSize is the size of the blocks staged by uploads to Azure; files
+// larger than one block are committed from several staged blocks
+const azureBlockSize = 8 << 20
+
+// azureUploadConcurrency is the number of blocks of a file staged concurrently
+const azureUploadConcurrency = 4
+
+// azureMinBlockSize is the smallest block buffer of stream uploads
+const azureMinBlockSize = 1 << 20
+
+// isAzureURI reports whether an artifact URI is in Azure Blob Storage or ADLS Gen2
+func isAzureURI(artifactURI string) bool {
+	for _, scheme := range []string{"wasbs://", "wasb://", "abfss://", "abfs://"} {
+		if strings.HasPrefix(artifactURI, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// azureLocation is an artifact in Azure Blob Storage
+type azureLocation struct {
+	Account   string
+	Container string
+	Blob      string
+	// ServiceURL is the Blob service endpoint of the account
+	ServiceURL string
+}
+
+// parseAzureLocation returns the location of an artifact below a
+// wasbs://<container>@<account>.blob.core.windows.net/<path> or
+// abfss://<container>@<account>.dfs.core.windows.net/<path> artifact URI.
+// ADLS Gen2 accounts are accessed through their Blob service endpoint.
+func parseAzureLocation(artifactURI, artifactPath string) (azureLocation, error) {
+	u, err := url.Parse(artifactURI)
+	if err != nil || u.User == nil || u.User.Username() == "" || u.Host == "" {
+		return azureLocation{}, fmt.Errorf("invalid Azure artifact URI: %s (expected <scheme>://<container>@<account>.<endpoint>/<path>)", artifactURI)
+	}
+
+	host := strings.Replace(u.Host, ".dfs.", ".blob.", 1)
+	account, _, _ := strings.Cut(host, ".")
+	blob := strings.Trim(u.Path, "/")
+	if artifactPath != "" {
+		if blob != "" {
+			blob += "/"
+		}
+		blob += artifactPath
+	}
+	return azureLocation{
+		Account:    account,
+		Container:  u.User.Username(),
+		Blob:       blob,
+		ServiceURL: "https://" + host + "/",
+	}, nil
+}
+
+// String returns the URI of the blob
+func (l azureLocation) String() string {
+	return strings.TrimSuffix(l.ServiceURL, "/") + "/" + l.Container + "/" + l.Blob
+}
+
+// azureClient returns the Blob service client of the account of location,
+// created on first use. A connection string or access key given like to the
+// MLflow client takes precedence over DefaultAzureCredential (environment,
+// workload or managed identity, Azure CLI).
+func (c *Client) azureClient(location azureLocation) (*azblob.Client, error) {
+	c.azureClientsMu.Lock()
+	defer c.azureClientsMu.Unlock()
+
+	if client, ok := c.azureClients[location.Account]; ok {
+		return client, nil
+	}
+
+	// The SDK builds its own transport, which knows only the proxy of the
+	// environment
+	var options azcore.ClientOptions
+	if proxy.Explicit() {
+		options.Transport = &http.Client{Transport: http.DefaultTransport}
+	}
+
+	var client *azblob.Client
+	var err error
+	clientOptions := &azblob.ClientOptions{ClientOptions: options}
+	if connectionString := os.Getenv(azureConnectionStringEnv); connectionString != "" {
+		client, err = azblob.NewClientFromConnectionString(connectionString, clientOptions)
+	} else if accessKey := os.Getenv(azureAccessKeyEnv); accessKey != "" {
+		var cred *azblob.SharedKeyCredential
+		if cred, err = azblob.NewSharedKeyCredential(location.Account, accessKey); err == nil {
+			client, err = azblob.NewClientWithSharedKeyCredential(location.ServiceURL, cred, clientOptions)
+		}
+	} else {
+		var cred *azidentity.DefaultAzureCredential
+		if cred, err = azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{ClientOptions: options}); err == nil {
+			client, err = azblob.NewClient(location.ServiceURL, cred, clientOptions)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure Blob Storage client: %w", err)
+	}
+
+	if c.azureClients == nil {
+		c.azureClients = make(map[string]*azblob.Client)
+	}
+	c.azureClients[location.Account] = client
+	return client, nil
+}
+
+// uploadToAzure writes content directly to the Azure container of the
+// artifact URI as a block blob, staging blocks concurrently. Block buffers of
+// streams are part of the client's memory budget.
+func (c *Client) uploadToAzure(ctx context.Context, artifactURI string, body io.Reader, artifactPath string) error {
+	location, err := parseAzureLocation(artifactURI, artifactPath)
+	if err != nil {
+		return err
+	}
+	if c.plan != nil {
+		return c.plan.recordUpload(location.String(), body)
+	}
+
+	client, err := c.azureClient(location)
+	if err != nil {
+		return err
+	}
+
+	// Files are staged from sections of the file; streams are buffered block
+	// by block unless the memory budget does not allow a single block
+	file, _ := body.(*os.File)
+	if info, err := file.Stat(); file != nil && (err != nil || !info.Mode().IsRegular()) {
+		file = nil
+	}
+	blockSize, concurrency, buffered := c.fitBuffers(azureBlockSize, azureUploadConcurrency, azureMinBlockSize)
+	if file == nil && !buffered {
+		var cleanup func()
+		if file, cleanup, err = spillToFile(body); err != nil {
+			return err
+		}
+		defer cleanup()
+	}
+
+	if file != nil {
+		_, err = client.UploadFile(ctx, location.Container, location.Blob, file, &blockblob.UploadFileOptions{
+			BlockSize:   azureBlockSize,
+			Concurrency: azureUploadConcurrency,
+		})
+	} else {
+		var release func()
+		if release, err = c.reserveMemory(ctx, blockSize*concurrency); err != nil {
+			return err
+		}
+		defer release()
+		_, err = client.UploadStream(ctx, location.Container, location.Blob, body, &blockblob.UploadStreamOptions{
+			BlockSize:   blockSize,
+			Concurrency: int(concurrency),
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upload to %s: %w", location, err)
+	}
+	return nil
+}
+
+// openFromAzure downloads directly from the Azure container of the artifact URI
+func (c *Client) openFromAzure(ctx context.Context, artifactURI, artifactPath string) (io.ReadCloser, int64, error) {
+	location, err := parseAzureLocation(artifactURI, artifactPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	client, err := c.azureClient(location)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := client.DownloadStream(ctx, location.Container, location.Blob, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download %s: %w", location, err)
+	}
+
+	size := int64(-1)
+	if resp.ContentLength != nil {
+		size = *resp.ContentLength
+	}
+	return resp.Body, size, nil
+}
@@ -0,0 +1,58 @@
+package mlflow
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// ArtifactUpload is a local file and the artifact path it is uploaded to
+type ArtifactUpload struct {
+	FilePath     string
+	ArtifactPath string
+}
+
+// DirectoryArtifacts returns an upload for every file below a local directory,
+// keeping its path relative to dir under the artifact path prefix like
+// mlflow.log_artifacts. Symbolic links to files are followed, symbolic links
+// to directories are not. Uploads are returned in lexical order.
+func DirectoryArtifacts(dir, prefix string) ([]ArtifactUpload, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var uploads []ArtifactUpload
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Stat(p)
+			if err != nil || !target.Mode().IsRegular() {
+				return nil
+			}
+		} else if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		uploads = append(uploads, ArtifactUpload{
+			FilePath:     p,
+			ArtifactPath: path.Join(prefix, filepath.ToSlash(rel)),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uploads, nil
+}
@@ -0,0 +1,263 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+
+	"github.com/imishinist/mlflow-cli/internal/httperr"
+)
+
+// CredentialsForReadRequest represents the request for credentials-for-read API,
+// sent as query parameters
+type CredentialsForReadRequest struct {
+	RunID string   `json:"-" url:"run_id"`
+	Path  []string `json:"-" url:"path"`
+}
+
+// CredentialsForReadResponse represents the response from credentials-for-read API
+type CredentialsForReadResponse struct {
+	CredentialInfos []ArtifactCredentialInfo `json:"credential_infos"`
+}
+
+// OpenArtifact opens an artifact file of a run for streaming. The returned size
+// is -1 if the storage does not report it. The transfer timeout covers reading
+// the content until it is closed, which the bandwidth limit slows down.
+func (c *Client) OpenArtifact(ctx context.Context, runID, artifactPath string) (io.ReadCloser, int64, error) {
+	artifactURI, err := c.getArtifactURI(ctx, runID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get artifact URI: %w", err)
+	}
+
+	ctx, cancel := c.transferContext(ctx)
+	body, size, err := c.openArtifact(ctx, artifactURI, artifactPath)
+	if err != nil {
+		cancel()
+		return nil, 0, c.transferError(ctx, err)
+	}
+	return &cancelOnClose{ReadCloser: c.limitReadCloser(ctx, body), cancel: cancel}, size, nil
+}
+
+// openArtifact opens an artifact file in the storage of an artifact URI
+func (c *Client) openArtifact(ctx context.Context, artifactURI, artifactPath string) (io.ReadCloser, int64, error) {
+	if strings.HasPrefix(artifactURI, "mlflow-artifacts:/") {
+		return c.openFromMLflowArtifacts(ctx, artifactURI, artifactPath)
+	} else if strings.HasPrefix(artifactURI, "dbfs:/") {
+		return c.openFromDBFS(ctx, artifactURI, artifactPath)
+	} else if strings.HasPrefix(artifactURI, "s3://") {
+		return c.openFromS3(ctx, artifactURI, artifactPath)
+	} else if strings.HasPrefix(artifactURI, "gs://") {
+		return c.openFromGCS(ctx, artifactURI, artifactPath)
+	} else if isAzureURI(artifactURI) {
+		return c.openFromAzure(ctx, artifactURI, artifactPath)
+	} else if strings.HasPrefix(artifactURI, "sftp://") {
+		return c.openFromSFTP(ctx, artifactURI, artifactPath)
+	} else if strings.HasPrefix(artifactURI, "file://") || strings.HasPrefix(artifactURI, "/") {
+		return openFromLocalFS(artifactURI, artifactPath)
+	}
+	return nil, 0, fmt.Errorf("unsupported artifact URI scheme: %s", artifactURI)
+}
+
+// CopyArtifacts copies a file or all files below a directory from one run to
+// another and returns the number of files copied. Content is streamed from the
+// source to the destination storage; only files whose size neither the listing
+// nor the source reports are spooled to a temporary file, as uploads need it.
+func (c *Client) CopyArtifacts(ctx context.Context, fromRunID, fromPath, toRunID, toPath string) (int, error) {
+	fromPath = strings.Trim(fromPath, "/")
+	files, err := c.ListArtifactsRecursive(ctx, fromRunID, fromPath)
+	if err != nil {
+		return 0, err
+	}
+
+	// A path without children is a single file
+	if len(files) == 0 {
+		if fromPath == "" {
+			return 0, fmt.Errorf("run %s has no artifacts", fromRunID)
+		}
+		dest := toPath
+		if dest == "" || strings.HasSuffix(dest, "/") {
+			dest = path.Join(dest, path.Base(fromPath))
+		}
+		if err := c.copyArtifact(ctx, fromRunID, fromPath, -1, toRunID, dest); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	for i, file := range files {
+		rel := strings.TrimPrefix(strings.TrimPrefix(file.Path, fromPath), "/")
+		dest := path.Join(toPath, rel)
+		if err := c.copyArtifact(ctx, fromRunID, file.Path, file.FileSize, toRunID, dest); err != nil {
+			return i, err
+		}
+	}
+	return len(files), nil
+}
+
+// copyArtifact streams a single artifact file between runs
+func (c *Client) copyArtifact(ctx context.Context, fromRunID, fromPath string, size int64, toRunID, toPath string) error {
+	body, reportedSize, err := c.OpenArtifact(ctx, fromRunID, fromPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", fromPath, err)
+	}
+	defer body.Close()
+
+	var content io.Reader = body
+	if reportedSize >= 0 {
+		size = reportedSize
+	}
+	if size < 0 {
+		file, cleanup, err := spillToFile(body)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", fromPath, err)
+		}
+		defer cleanup()
+		info, err := file.Stat()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", fromPath, err)
+		}
+		content, size = file, info.Size()
+	}
+	if err := c.UploadArtifactFromReader(ctx, toRunID, content, size, toPath); err != nil {
+		return fmt.Errorf("failed to write %s: %w", toPath, err)
+	}
+	return nil
+}
+
+// openFromMLflowArtifacts downloads using MLflow Artifacts Service
+func (c *Client) openFromMLflowArtifacts(ctx context.Context, artifactURI, artifactPath string) (io.ReadCloser, int64, error) {
+	url, err := c.mlflowArtifactsURL(artifactURI, artifactPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.addAuthHeaders(req)
+
+	return c.sendDownloadRequest(req)
+}
+
+// openFromLocalFS opens an artifact stored on the local filesystem
+func openFromLocalFS(artifactURI, artifactPath string) (io.ReadCloser, int64, error) {
+	file, err := os.Open(localArtifactPath(artifactURI, artifactPath))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, 0, fmt.Errorf("%s is a directory", artifactPath)
+	}
+
+	return file, info.Size(), nil
+}
+
+// openFromDBFS downloads from DBFS using a signed URI from the Databricks Artifacts API
+func (c *Client) openFromDBFS(ctx context.Context, artifactURI, artifactPath string) (io.ReadCloser, int64, error) {
+	runID, err := c.extractRunIDFromDBFSURI(artifactURI)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to extract run ID from DBFS URI: %w", err)
+	}
+
+	credentials, err := c.getCredentialsForRead(ctx, runID, []string{artifactPath})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get read credentials: %w", err)
+	}
+	if len(credentials) == 0 {
+		return nil, 0, fmt.Errorf("no credentials returned for path: %s", artifactPath)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", credentials[0].SignedURI, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	for _, header := range credentials[0].Headers {
+		req.Header.Set(header.Name, header.Value)
+	}
+
+	return c.sendDownloadRequest(req)
+}
+
+// getCredentialsForRead gets read credentials using Databricks SDK API client
+func (c *Client) getCredentialsForRead(ctx context.Context, runID string, paths []string) ([]ArtifactCredentialInfo, error) {
+	if !c.config.IsDatabricks() || c.apiClient == nil {
+		return nil, fmt.Errorf("non-Databricks MLflow servers not supported for DBFS artifacts")
+	}
+
+	request := CredentialsForReadRequest{
+		RunID: runID,
+		Path:  paths,
+	}
+
+	var response CredentialsForReadResponse
+	err := c.apiClient.Do(ctx, "GET", "/api/2.0/mlflow/artifacts/credentials-for-read",
+		httpclient.WithRequestData(request),
+		httpclient.WithResponseUnmarshal(&response),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("credentials-for-read request failed: %w", err)
+	}
+
+	return response.CredentialInfos, nil
+}
+
+// sendDownloadRequest sends a download request and returns the response body
+func (c *Client) sendDownloadRequest(req *http.Request) (io.ReadCloser, int64, error) {
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download artifact: %w", err)
+	}
+
+	if !c.isSuccessStatusCode(resp.StatusCode) {
+		defer resp.Body.Close()
+		return nil, 0, fmt.Errorf("artifact download failed with status %d: %s", resp.StatusCode, httperr.ReadMessage(resp.Body))
+	}
+
+	return resp.Body, resp.ContentLength, nil
+}
+
+// DownloadArtifact downloads an artifact file of a run to a local file. The
+// content is written to a temporary file next to the destination and renamed
+// into place, so an interrupted download never leaves a partial file behind.
+func (c *Client) DownloadArtifact(ctx context.Context, runID, artifactPath, destPath string) error {
+	body, _, err := c.OpenArtifact(ctx, runID, artifactPath)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(destPath), "."+filepath.Base(destPath)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", destPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", destPath, err)
+	}
+
+	return os.Rename(tmp.Name(), destPath)
+}
@@ -0,0 +1,106 @@
+package mlflow
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/databricks/databricks-sdk-go/service/ml"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// ListArtifacts lists the direct children of an artifact directory, following pagination
+func (c *Client) ListArtifacts(ctx context.Context, runID, artifactPath string) ([]models.ArtifactInfo, error) {
+	files, err := c.client.Experiments.ListArtifactsAll(ctx, ml.ListArtifactsRequest{
+		RunId: runID,
+		Path:  artifactPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	artifacts := make([]models.ArtifactInfo, 0, len(files))
+	for _, file := range files {
+		artifacts = append(artifacts, models.ArtifactInfo{
+			Path:     file.Path,
+			IsDir:    file.IsDir,
+			FileSize: file.FileSize,
+		})
+	}
+
+	return artifacts, nil
+}
+
+// ArtifactExists checks whether a file exists at the given artifact path
+func (c *Client) ArtifactExists(ctx context.Context, runID, artifactPath string) (bool, error) {
+	dir := path.Dir(artifactPath)
+	if dir == "." {
+		dir = ""
+	}
+
+	artifacts, err := c.ListArtifacts(ctx, runID, dir)
+	if err != nil {
+		return false, err
+	}
+
+	for _, artifact := range artifacts {
+		if artifact.Path == artifactPath && !artifact.IsDir {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ListArtifactsRecursive lists all files below an artifact directory
+func (c *Client) ListArtifactsRecursive(ctx context.Context, runID, artifactPath string) ([]models.ArtifactInfo, error) {
+	artifacts, err := c.ListArtifacts(ctx, runID, artifactPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []models.ArtifactInfo
+	for _, artifact := range artifacts {
+		if !artifact.IsDir {
+			files = append(files, artifact)
+			continue
+		}
+		children, err := c.ListArtifactsRecursive(ctx, runID, artifact.Path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, children...)
+	}
+
+	return files, nil
+}
+
+// ArtifactListingDigest returns a SHA-256 digest over the paths and sizes of
+// all files below an artifact directory, as listed without downloading any
+// content. It changes whenever a file is added, removed, renamed or resized,
+// but not when a file is rewritten with other content of the same size, so it
+// is no checksum of the content.
+func (c *Client) ArtifactListingDigest(ctx context.Context, runID, artifactPath string) (string, error) {
+	files, err := c.ListArtifactsRecursive(ctx, runID, artifactPath)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("no artifacts found at %s", artifactPath)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	hash := sha256.New()
+	for _, file := range files {
+		fmt.Fprintf(hash, "%s\t%d\n", file.Path, file.FileSize)
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
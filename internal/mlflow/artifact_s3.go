@@ -0,0 +1,165 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/imishinist/mlflow-cli/internal/proxy"
+)
+
+// s3EndpointEnv overrides the S3 endpoint, as for the MLflow client, for
+// S3-compatible stores such as MinIO
+const s3EndpointEnv = "MLFLOW_S3_ENDPOINT_URL"
+
+// s3Location returns the bucket and object key of an artifact below an s3://
+// artifact URI
+func s3Location(artifactURI, artifactPath string) (bucket, key string, err error) {
+	rest := strings.TrimPrefix(artifactURI, "s3://")
+	bucket, prefix, _ := strings.Cut(rest, "/")
+	if bucket == "" {
+		return "", "", fmt.Errorf("invalid S3 artifact URI: %s", artifactURI)
+	}
+
+	key = strings.Trim(prefix, "/")
+	if artifactPath != "" {
+		if key != "" {
+			key += "/"
+		}
+		key += artifactPath
+	}
+	return bucket, key, nil
+}
+
+// s3Client returns the S3 client, created on first use from the standard AWS
+// credential chain: environment variables, shared config and credentials
+// files, and instance or container roles
+func (c *Client) s3Client(ctx context.Context) (*s3.Client, error) {
+	c.s3ClientMu.Lock()
+	defer c.s3ClientMu.Unlock()
+
+	if c.s3 != nil {
+		return c.s3, nil
+	}
+
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx, awsConfigOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	endpoint := os.Getenv(s3EndpointEnv)
+	c.s3 = s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+			// S3-compatible stores rarely support virtual-hosted buckets
+			o.UsePathStyle = true
+		}
+	})
+	return c.s3, nil
+}
+
+// awsConfigOptions returns the options of loading the AWS configuration. The
+// SDK builds its own transport, which knows only the proxy of the environment.
+func awsConfigOptions() []func(*awsconfig.LoadOptions) error {
+	var opts []func(*awsconfig.LoadOptions) error
+	if proxy.Explicit() {
+		opts = append(opts, awsconfig.WithHTTPClient(awshttp.NewBuildableClient().WithTransportOptions(func(t *http.Transport) {
+			t.Proxy = proxy.Func
+		})))
+	}
+	return opts
+}
+
+// uploadToS3 writes content directly to the S3 bucket of the artifact URI.
+// Large content is uploaded in parts by the SDK upload manager, which buffers
+// the parts of streams but not of files; these buffers are part of the
+// client's memory budget.
+func (c *Client) uploadToS3(ctx context.Context, artifactURI string, body io.Reader, size int64, artifactPath string) error {
+	bucket, key, err := s3Location(artifactURI, artifactPath)
+	if err != nil {
+		return err
+	}
+	if c.plan != nil {
+		return c.plan.recordUpload("s3://"+bucket+"/"+key, body)
+	}
+
+	client, err := c.s3Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	partSize, concurrency := int64(manager.DefaultUploadPartSize), int64(manager.DefaultUploadConcurrency)
+	if _, seekable := body.(interface {
+		io.ReaderAt
+		io.ReadSeeker
+	}); !seekable {
+		var buffered bool
+		partSize, concurrency, buffered = c.fitBuffers(partSize, concurrency, manager.MinUploadPartSize)
+		if !buffered {
+			file, cleanup, err := spillToFile(body)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+			body = file
+		} else {
+			release, err := c.reserveMemory(ctx, partSize*concurrency)
+			if err != nil {
+				return err
+			}
+			defer release()
+		}
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	}
+	if size >= 0 {
+		input.ContentLength = aws.Int64(size)
+	}
+	uploader := manager.NewUploader(client, func(u *manager.Uploader) {
+		u.PartSize = partSize
+		u.Concurrency = int(concurrency)
+	})
+	if _, err := uploader.Upload(ctx, input); err != nil {
+		return fmt.Errorf("failed to upload to s3://%s/%s: %w", bucket, key, err)
+	}
+	return nil
+}
+
+// openFromS3 downloads directly from the S3 bucket of the artifact URI
+func (c *Client) openFromS3(ctx context.Context, artifactURI, artifactPath string) (io.ReadCloser, int64, error) {
+	bucket, key, err := s3Location(artifactURI, artifactPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	client, err := c.s3Client(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	output, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download s3://%s/%s: %w", bucket, key, err)
+	}
+
+	size := int64(-1)
+	if output.ContentLength != nil {
+		size = *output.ContentLength
+	}
+	return output.Body, size, nil
+}
@@ -0,0 +1,249 @@
+package mlflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// Environment variables selecting SFTP keys and known hosts
+const (
+	sftpKeyFileEnv    = "MLFLOW_SFTP_KEY_FILE"
+	sftpPassphraseEnv = "MLFLOW_SFTP_KEY_PASSPHRASE"
+	sftpKnownHostsEnv = "MLFLOW_SFTP_KNOWN_HOSTS"
+)
+
+// sftpDefaultKeys are the private keys in ~/.ssh tried without MLFLOW_SFTP_KEY_FILE
+var sftpDefaultKeys = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+// sftpLocation is an artifact on an SFTP server
+type sftpLocation struct {
+	User string
+	// Addr is the host and port of the server
+	Addr string
+	Path string
+}
+
+// parseSFTPLocation returns the location of an artifact below an
+// sftp://[<user>@]<host>[:<port>]/<path> artifact URI. The user defaults to
+// the local user.
+func parseSFTPLocation(artifactURI, artifactPath string) (sftpLocation, error) {
+	u, err := url.Parse(artifactURI)
+	if err != nil || u.Host == "" {
+		return sftpLocation{}, fmt.Errorf("invalid SFTP artifact URI: %s (expected sftp://<user>@<host>/<path>)", artifactURI)
+	}
+
+	user := u.User.Username()
+	if user == "" {
+		user = os.Getenv("USER")
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "22")
+	}
+	return sftpLocation{
+		User: user,
+		Addr: addr,
+		Path: path.Join("/", u.Path, artifactPath),
+	}, nil
+}
+
+// String returns the URI of the artifact
+func (l sftpLocation) String() string {
+	return "sftp://" + l.User + "@" + l.Addr + l.Path
+}
+
+// sftpClient returns the SFTP client of the server of location, connected on
+// first use. Servers are authenticated by known_hosts, and users by the key in
+// MLFLOW_SFTP_KEY_FILE or else by the SSH agent and the default keys in ~/.ssh.
+func (c *Client) sftpClient(ctx context.Context, location sftpLocation) (*sftp.Client, error) {
+	c.sftpClientsMu.Lock()
+	defer c.sftpClientsMu.Unlock()
+
+	key := location.User + "@" + location.Addr
+	if client, ok := c.sftpClients[key]; ok {
+		return client, nil
+	}
+
+	auth, err := sftpAuthMethods()
+	if err != nil {
+		return nil, err
+	}
+	hostKeyCallback, err := sftpHostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", location.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", location.Addr, err)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, location.Addr, &ssh.ClientConfig{
+		User:            location.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open SSH session to %s: %w", key, err)
+	}
+	sshClient := ssh.NewClient(sshConn, chans, reqs)
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		sshClient.Close()
+		return nil, fmt.Errorf("failed to start SFTP on %s: %w", key, err)
+	}
+
+	if c.sftpClients == nil {
+		c.sftpClients = make(map[string]*sftp.Client)
+	}
+	c.sftpClients[key] = client
+	return client, nil
+}
+
+// sftpAuthMethods returns the public key authentication methods to try
+func sftpAuthMethods() ([]ssh.AuthMethod, error) {
+	if keyFile := os.Getenv(sftpKeyFileEnv); keyFile != "" {
+		signer, err := loadSFTPKey(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil
+	}
+
+	var methods []ssh.AuthMethod
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		if conn, err := net.Dial("unix", socket); err == nil {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	// Default keys protected by a passphrase are left to the agent
+	var signers []ssh.Signer
+	if home, err := os.UserHomeDir(); err == nil {
+		for _, name := range sftpDefaultKeys {
+			if signer, err := loadSFTPKey(filepath.Join(home, ".ssh", name)); err == nil {
+				signers = append(signers, signer)
+			}
+		}
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no SSH key found for SFTP: set %s, start an SSH agent, or create a key in ~/.ssh", sftpKeyFileEnv)
+	}
+	return methods, nil
+}
+
+// loadSFTPKey reads a private key, decrypted with MLFLOW_SFTP_KEY_PASSPHRASE
+// if it is protected
+func loadSFTPKey(keyFile string) (ssh.Signer, error) {
+	pem, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(pem)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		passphrase := os.Getenv(sftpPassphraseEnv)
+		if passphrase == "" {
+			return nil, fmt.Errorf("SSH key %s is protected: set %s or add the key to an SSH agent", keyFile, sftpPassphraseEnv)
+		}
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(pem, []byte(passphrase))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH key %s: %w", keyFile, err)
+	}
+	return signer, nil
+}
+
+// sftpHostKeyCallback verifies servers against MLFLOW_SFTP_KNOWN_HOSTS or
+// ~/.ssh/known_hosts
+func sftpHostKeyCallback() (ssh.HostKeyCallback, error) {
+	knownHostsFile := os.Getenv(sftpKnownHostsEnv)
+	if knownHostsFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate known_hosts: %w", err)
+		}
+		knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	callback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load known hosts (add the server with ssh-keyscan): %w", err)
+	}
+	return callback, nil
+}
+
+// uploadToSFTP writes content directly to the SFTP server of the artifact URI,
+// creating missing directories
+func (c *Client) uploadToSFTP(ctx context.Context, artifactURI string, body io.Reader, artifactPath string) error {
+	location, err := parseSFTPLocation(artifactURI, artifactPath)
+	if err != nil {
+		return err
+	}
+	if c.plan != nil {
+		return c.plan.recordUpload(location.String(), body)
+	}
+
+	client, err := c.sftpClient(ctx, location)
+	if err != nil {
+		return err
+	}
+
+	if err := client.MkdirAll(path.Dir(location.Path)); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", location, err)
+	}
+	file, err := client.Create(location.Path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", location, err)
+	}
+	if _, err := file.ReadFrom(body); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to upload to %s: %w", location, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to upload to %s: %w", location, err)
+	}
+	return nil
+}
+
+// openFromSFTP downloads directly from the SFTP server of the artifact URI
+func (c *Client) openFromSFTP(ctx context.Context, artifactURI, artifactPath string) (io.ReadCloser, int64, error) {
+	location, err := parseSFTPLocation(artifactURI, artifactPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	client, err := c.sftpClient(ctx, location)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	file, err := client.Open(location.Path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download %s: %w", location, err)
+	}
+
+	size := int64(-1)
+	if info, err := file.Stat(); err == nil {
+		size = info.Size()
+	}
+	return file, size, nil
+}
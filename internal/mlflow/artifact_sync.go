@@ -0,0 +1,113 @@
+package mlflow
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ArtifactChange is a local file that differs from the artifacts of a run
+type ArtifactChange struct {
+	Upload ArtifactUpload
+	// New is set if the artifact path does not exist yet
+	New bool
+}
+
+// ChangedArtifacts compares uploads against the files below artifactPath of a
+// run and returns those whose artifact is missing or has a different size, in
+// the order of uploads. With checksum, files of equal size are also compared by
+// the SHA-256 of their content, reading up to parallelism artifacts at a time.
+func (c *Client) ChangedArtifacts(ctx context.Context, runID, artifactPath string, uploads []ArtifactUpload, checksum bool, parallelism int, progress func(done, total int)) ([]ArtifactChange, error) {
+	files, err := c.ListArtifactsRecursive(ctx, runID, strings.Trim(artifactPath, "/"))
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]int64, len(files))
+	for _, file := range files {
+		sizes[file.Path] = file.FileSize
+	}
+
+	changes := make([]*ArtifactChange, len(uploads))
+	var same []int
+	for i, upload := range uploads {
+		info, err := os.Stat(upload.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		size, exists := sizes[upload.ArtifactPath]
+		switch {
+		case !exists:
+			changes[i] = &ArtifactChange{Upload: upload, New: true}
+		case size != info.Size():
+			changes[i] = &ArtifactChange{Upload: upload}
+		case checksum:
+			same = append(same, i)
+		}
+	}
+
+	errs := make([]error, len(same))
+	runParallel(len(same), parallelism, progress, func(j int) {
+		upload := uploads[same[j]]
+		equal, err := c.sameContent(ctx, runID, upload)
+		if err != nil {
+			errs[j] = fmt.Errorf("failed to compare %s: %w", upload.ArtifactPath, err)
+			return
+		}
+		if !equal {
+			changes[same[j]] = &ArtifactChange{Upload: upload}
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var changed []ArtifactChange
+	for _, change := range changes {
+		if change != nil {
+			changed = append(changed, *change)
+		}
+	}
+	return changed, nil
+}
+
+// sameContent reports whether a local file and its artifact have the same
+// SHA-256. The artifact is streamed from the artifact store.
+func (c *Client) sameContent(ctx context.Context, runID string, upload ArtifactUpload) (bool, error) {
+	file, err := os.Open(upload.FilePath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	localSum, err := sha256Sum(file)
+	if err != nil {
+		return false, err
+	}
+
+	body, _, err := c.OpenArtifact(ctx, runID, upload.ArtifactPath)
+	if err != nil {
+		return false, err
+	}
+	defer body.Close()
+	remoteSum, err := sha256Sum(body)
+	if err != nil {
+		return false, err
+	}
+
+	return localSum == remoteSum, nil
+}
+
+// sha256Sum returns the SHA-256 of everything read from r
+func sha256Sum(r io.Reader) ([sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return sum, err
+	}
+	copy(sum[:], h.Sum(nil))
+	return sum, nil
+}
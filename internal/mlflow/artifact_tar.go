@@ -0,0 +1,104 @@
+package mlflow
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// WriteArtifactsTar streams a file or all files below a directory of a run to
+// w as an uncompressed tar archive and returns the number of files written.
+// Files are named relative to the directory, or by their base name for a
+// single file, so that the archive can serve as e.g. a docker build context;
+// a non-empty prefix is prepended to the names as a directory. Entries get the
+// modification time modTime, or the current time if it is zero.
+func (c *Client) WriteArtifactsTar(ctx context.Context, w io.Writer, runID, artifactPath, prefix string, modTime time.Time) (int, error) {
+	artifactPath = strings.Trim(artifactPath, "/")
+	files, err := c.ListArtifactsRecursive(ctx, runID, artifactPath)
+	if err != nil {
+		return 0, err
+	}
+
+	// Artifacts have no modification times
+	if modTime.IsZero() {
+		modTime = time.Now()
+	}
+	modTime = modTime.Truncate(time.Second)
+	tw := tar.NewWriter(w)
+
+	// A path without children is a single file
+	if len(files) == 0 {
+		if artifactPath == "" {
+			return 0, fmt.Errorf("run %s has no artifacts", runID)
+		}
+		if err := c.addArtifactToTar(ctx, tw, runID, artifactPath, -1, path.Join(prefix, path.Base(artifactPath)), modTime); err != nil {
+			return 0, err
+		}
+		return 1, tw.Close()
+	}
+
+	for i, file := range files {
+		name := path.Join(prefix, strings.TrimPrefix(strings.TrimPrefix(file.Path, artifactPath), "/"))
+		// Servers that list no sizes omit them like those of empty files
+		size := file.FileSize
+		if size == 0 {
+			size = -1
+		}
+		if err := c.addArtifactToTar(ctx, tw, runID, file.Path, size, name, modTime); err != nil {
+			return i, err
+		}
+	}
+	return len(files), tw.Close()
+}
+
+// addArtifactToTar streams a single artifact file into a tar archive. Tar
+// headers need the size up front, so content of unknown size is spooled to a
+// temporary file first.
+func (c *Client) addArtifactToTar(ctx context.Context, tw *tar.Writer, runID, artifactPath string, size int64, name string, modTime time.Time) error {
+	body, reportedSize, err := c.OpenArtifact(ctx, runID, artifactPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", artifactPath, err)
+	}
+	defer body.Close()
+
+	var content io.Reader = body
+	if reportedSize >= 0 {
+		size = reportedSize
+	}
+	if size < 0 {
+		tmp, err := os.CreateTemp("", "mlflow-cli-artifact-*")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary file: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		if size, err = io.Copy(tmp, body); err != nil {
+			return fmt.Errorf("failed to read %s: %w", artifactPath, err)
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		content = tmp
+	}
+
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0644,
+		ModTime:  modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := io.Copy(tw, content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
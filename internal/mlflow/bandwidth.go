@@ -0,0 +1,108 @@
+package mlflow
+
+import (
+	"context"
+	"io"
+	"math"
+
+	"golang.org/x/time/rate"
+)
+
+// newBandwidthLimiter returns the limiter of artifact transfers to a rate in
+// bytes per second, or nil if the rate is 0. Up to a second's worth of bytes
+// may be transferred at once.
+func newBandwidthLimiter(bytesPerSecond int64) *rate.Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), int(min(bytesPerSecond, math.MaxInt)))
+}
+
+// limitReader bounds the reads of an artifact transfer by the bandwidth limit
+// shared by all transfers of the client. Seekable content stays seekable, so
+// that it can still be replayed by retries and uploaded in parallel parts.
+func (c *Client) limitReader(ctx context.Context, body io.Reader) io.Reader {
+	if c.bandwidth == nil {
+		return body
+	}
+	limited := &limitedReader{ctx: ctx, limiter: c.bandwidth, r: body}
+	switch body := body.(type) {
+	case interface {
+		io.ReaderAt
+		io.ReadSeeker
+	}:
+		return &limitedFile{limitedReader: limited, file: body}
+	case io.ReadSeeker:
+		return &limitedSeeker{limitedReader: limited, seeker: body}
+	}
+	return limited
+}
+
+// limitReadCloser bounds the reads of downloaded content like limitReader
+func (c *Client) limitReadCloser(ctx context.Context, body io.ReadCloser) io.ReadCloser {
+	if c.bandwidth == nil {
+		return body
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{c.limitReader(ctx, body), body}
+}
+
+// limitedReader waits for the limiter after every read for the bytes read.
+// Reads are at most a burst long, so that each can be waited for.
+type limitedReader struct {
+	ctx     context.Context
+	limiter *rate.Limiter
+	r       io.Reader
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p[:min(len(p), l.limiter.Burst())])
+	return n, l.wait(n, err)
+}
+
+// wait blocks until n bytes may be transferred, and returns err unless the
+// context ended while waiting
+func (l *limitedReader) wait(n int, err error) error {
+	if n == 0 {
+		return err
+	}
+	if waitErr := l.limiter.WaitN(l.ctx, n); waitErr != nil {
+		return waitErr
+	}
+	return err
+}
+
+type limitedSeeker struct {
+	*limitedReader
+	seeker io.Seeker
+}
+
+func (l *limitedSeeker) Seek(offset int64, whence int) (int64, error) {
+	return l.seeker.Seek(offset, whence)
+}
+
+type limitedFile struct {
+	*limitedReader
+	file interface {
+		io.ReaderAt
+		io.Seeker
+	}
+}
+
+func (l *limitedFile) Seek(offset int64, whence int) (int64, error) {
+	return l.file.Seek(offset, whence)
+}
+
+func (l *limitedFile) ReadAt(p []byte, off int64) (int, error) {
+	total := 0
+	for total < len(p) {
+		n, err := l.file.ReadAt(p[total:min(len(p), total+l.limiter.Burst())], off+int64(total))
+		total += n
+		if err := l.wait(n, err); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,279 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// BenchOptions describes the synthetic load of a benchmark
+type BenchOptions struct {
+	ExperimentID string
+	// Runs is the number of runs created
+	Runs int
+	// MetricsPerRun is the number of metric values logged to each run, in
+	// log-batch requests of up to MaxMetricsPerBatch
+	MetricsPerRun int
+	// ArtifactSize is the size of the artifact uploaded to each run; 0
+	// uploads none
+	ArtifactSize int64
+	// Parallelism is the number of runs loaded concurrently
+	Parallelism int
+	// Keep keeps the runs instead of deleting them after they ended
+	Keep bool
+}
+
+// Operations of a benchmark, in the order each run performs them
+const (
+	BenchCreateRun      = "create_run"
+	BenchLogBatch       = "log_batch"
+	BenchGetRun         = "get_run"
+	BenchUploadArtifact = "upload_artifact"
+	BenchEndRun         = "end_run"
+	BenchDeleteRun      = "delete_run"
+)
+
+var benchOperations = []string{BenchCreateRun, BenchLogBatch, BenchGetRun, BenchUploadArtifact, BenchEndRun, BenchDeleteRun}
+
+// benchMetricKeys is the number of metrics the values of a run are spread
+// over, like the losses and accuracies of a training job
+const benchMetricKeys = 10
+
+// benchArtifactPath is the artifact path of the uploaded artifact
+const benchArtifactPath = "bench/payload.bin"
+
+// BenchOperation holds the latencies of the calls of one operation.
+// Latencies include retries.
+type BenchOperation struct {
+	Name     string
+	Requests int
+	Errors   int
+	P50      time.Duration
+	P90      time.Duration
+	P99      time.Duration
+	Max      time.Duration
+}
+
+// BenchResult is the outcome of a benchmark
+type BenchResult struct {
+	// Operations are the operations that were performed, in run order
+	Operations []BenchOperation
+	// Elapsed is the wall time of the whole benchmark
+	Elapsed time.Duration
+	// Runs is the number of runs that completed without errors
+	Runs int
+	// Metrics is the number of metric values logged
+	Metrics int
+	// ArtifactBytes is the number of artifact bytes uploaded
+	ArtifactBytes int64
+	// Errors is the number of failed calls, and FirstError the first of them
+	Errors     int
+	FirstError error
+	// Abandoned are the runs that failed part-way and could not be ended as
+	// failed or deleted
+	Abandoned []string
+}
+
+// benchRecorder collects the latencies of concurrent calls
+type benchRecorder struct {
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+	errors    map[string]int
+	result    BenchResult
+}
+
+// record adds the latency of a call of an operation
+func (r *benchRecorder) record(operation string, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.latencies[operation] = append(r.latencies[operation], latency)
+	if err != nil {
+		r.errors[operation]++
+		r.result.Errors++
+		if r.result.FirstError == nil {
+			r.result.FirstError = fmt.Errorf("%s: %w", operation, err)
+		}
+	}
+}
+
+// Bench exercises the tracking server with synthetic runs. Each run is
+// created, gets its metrics in consecutive log-batch requests and its
+// artifact, is read back, ended and deleted; up to opts.Parallelism runs are
+// loaded concurrently. Failed calls are counted rather than aborting the
+// benchmark, and skip the rest of their run, which is ended as failed and
+// deleted unless kept. progress, if not nil, is called after each run.
+func (c *Client) Bench(ctx context.Context, opts BenchOptions, progress func(done, total int)) (*BenchResult, error) {
+	// The metric names must pass the naming policy like any other metrics
+	if err := c.validateMetricKeys(benchMetrics(min(opts.MetricsPerRun, benchMetricKeys), time.Now())); err != nil {
+		return nil, err
+	}
+
+	recorder := &benchRecorder{
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+	}
+	timed := func(operation string, fn func() error) error {
+		start := time.Now()
+		err := fn()
+		recorder.record(operation, time.Since(start), err)
+		return err
+	}
+
+	start := time.Now()
+	runParallel(opts.Runs, opts.Parallelism, progress, func(i int) {
+		runName := fmt.Sprintf("bench-%d", i+1)
+		var run *models.RunInfo
+		err := timed(BenchCreateRun, func() error {
+			var err error
+			run, err = c.CreateRun(ctx, &models.RunConfig{ExperimentID: &opts.ExperimentID, RunName: &runName})
+			return err
+		})
+		if err != nil {
+			return
+		}
+		ended := false
+		defer func() {
+			if !ended {
+				c.cleanupBenchRun(ctx, run.RunID, opts.Keep, recorder)
+			}
+		}()
+
+		metrics := benchMetrics(opts.MetricsPerRun, run.StartTime)
+		for offset := 0; offset < len(metrics); offset += MaxMetricsPerBatch {
+			batch := metrics[offset:min(offset+MaxMetricsPerBatch, len(metrics))]
+			if err := timed(BenchLogBatch, func() error { return c.logBatch(ctx, run.RunID, batch) }); err != nil {
+				return
+			}
+			recorder.mu.Lock()
+			recorder.result.Metrics += len(batch)
+			recorder.mu.Unlock()
+		}
+
+		var artifactURI string
+		err = timed(BenchGetRun, func() error {
+			var err error
+			artifactURI, err = c.getArtifactURI(ctx, run.RunID)
+			return err
+		})
+		if err != nil {
+			return
+		}
+		if opts.ArtifactSize > 0 {
+			err := timed(BenchUploadArtifact, func() error {
+				return c.uploadToStorage(ctx, artifactURI, benchPayload(opts.ArtifactSize), opts.ArtifactSize, benchArtifactPath)
+			})
+			if err != nil {
+				return
+			}
+			recorder.mu.Lock()
+			recorder.result.ArtifactBytes += opts.ArtifactSize
+			recorder.mu.Unlock()
+		}
+
+		if err := timed(BenchEndRun, func() error { return c.UpdateRun(ctx, run.RunID, models.RunStatusFinished) }); err != nil {
+			return
+		}
+		ended = true
+		if !opts.Keep {
+			if err := timed(BenchDeleteRun, func() error { return c.DeleteRun(ctx, run.RunID) }); err != nil {
+				return
+			}
+		}
+
+		recorder.mu.Lock()
+		recorder.result.Runs++
+		recorder.mu.Unlock()
+	})
+
+	result := recorder.result
+	result.Elapsed = time.Since(start)
+	for _, name := range benchOperations {
+		latencies := recorder.latencies[name]
+		if len(latencies) == 0 {
+			continue
+		}
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		result.Operations = append(result.Operations, BenchOperation{
+			Name:     name,
+			Requests: len(latencies),
+			Errors:   recorder.errors[name],
+			P50:      percentile(latencies, 50),
+			P90:      percentile(latencies, 90),
+			P99:      percentile(latencies, 99),
+			Max:      latencies[len(latencies)-1],
+		})
+	}
+	return &result, nil
+}
+
+// cleanupBenchRun ends a run that failed part-way as failed, and deletes it
+// unless kept, so that failed benchmarks do not leave runs running. Its calls
+// are not part of the measured load.
+func (c *Client) cleanupBenchRun(ctx context.Context, runID string, keep bool, recorder *benchRecorder) {
+	err := c.UpdateRun(ctx, runID, models.RunStatusFailed)
+	if err == nil && !keep {
+		err = c.DeleteRun(ctx, runID)
+	}
+	if err != nil {
+		recorder.mu.Lock()
+		recorder.result.Abandoned = append(recorder.result.Abandoned, runID)
+		recorder.mu.Unlock()
+	}
+}
+
+// percentile returns the nearest-rank percentile p of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+// benchMetrics returns n metric values spread over benchMetricKeys metrics,
+// one step per benchMetricKeys values, starting at start
+func benchMetrics(n int, start time.Time) []models.Metric {
+	metrics := make([]models.Metric, n)
+	for i := range metrics {
+		step := int64(i / benchMetricKeys)
+		metrics[i] = models.Metric{
+			Key:        fmt.Sprintf("bench_%d", i%benchMetricKeys),
+			Value:      math.Exp(-float64(step) / 1000),
+			Timestamp:  start.Add(time.Duration(step) * time.Millisecond),
+			Step:       step,
+			LocalClock: true,
+		}
+	}
+	return metrics
+}
+
+// benchBlock is the random data artifacts are made of, so that compression
+// on the way does not make uploads look faster than they are
+var benchBlock = sync.OnceValue(func() []byte {
+	block := make([]byte, 1<<20)
+	rand.New(rand.NewSource(1)).Read(block)
+	return block
+})
+
+// benchPayload returns an artifact of size bytes repeating benchBlock. It is
+// seekable, so that failed uploads can be retried.
+func benchPayload(size int64) io.ReadSeeker {
+	return io.NewSectionReader(benchBlockReader{}, 0, size)
+}
+
+// benchBlockReader reads benchBlock repeated endlessly
+type benchBlockReader struct{}
+
+func (benchBlockReader) ReadAt(p []byte, off int64) (int, error) {
+	block := benchBlock()
+	n := 0
+	for n < len(p) {
+		n += copy(p[n:], block[(off+int64(n))%int64(len(block)):])
+	}
+	return n, nil
+}
@@ -0,0 +1,60 @@
+package mlflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+)
+
+// capabilityProbeModelID is a logged model ID that never exists, requested to
+// tell servers without the logged model API from servers that lack the model
+const capabilityProbeModelID = "m-mlflow-cli-capability-probe"
+
+// Capabilities describes the optional APIs of the tracking server
+type Capabilities struct {
+	// LoggedModels is set for MLflow 3 servers with the logged model API.
+	// Older servers keep models as run artifacts.
+	LoggedModels bool
+}
+
+// Capabilities detects the optional APIs of the tracking server on first use
+func (c *Client) Capabilities(ctx context.Context) (Capabilities, error) {
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+
+	if c.capabilities != nil {
+		return *c.capabilities, nil
+	}
+
+	loggedModels, err := c.probeLoggedModels(ctx)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	c.capabilities = &Capabilities{LoggedModels: loggedModels}
+	return *c.capabilities, nil
+}
+
+// probeLoggedModels requests a logged model that does not exist. Servers with
+// the logged model API report the model as missing, while older servers
+// report the endpoint as missing or not allowed.
+func (c *Client) probeLoggedModels(ctx context.Context) (bool, error) {
+	_, err := c.client.Experiments.GetLoggedModelByModelId(ctx, capabilityProbeModelID)
+	if err == nil {
+		return true, nil
+	}
+
+	var apiErr *apierr.APIError
+	if !errors.As(err, &apiErr) {
+		return false, fmt.Errorf("failed to detect server capabilities: %w", err)
+	}
+	switch {
+	case apiErr.ErrorCode == "RESOURCE_DOES_NOT_EXIST":
+		return true, nil
+	case apiErr.StatusCode == http.StatusNotFound, apiErr.StatusCode == http.StatusMethodNotAllowed, apiErr.StatusCode == http.StatusNotImplemented:
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to detect server capabilities: %w", err)
+}
@@ -0,0 +1,117 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Checkpoints are stored as artifacts under CheckpointArtifactDir/<name>/. The
+// CheckpointLatestFile pointer holds the name of the most recent complete
+// checkpoint and is only updated after all of its files were uploaded.
+const (
+	CheckpointArtifactDir = "checkpoints"
+	CheckpointLatestFile  = "checkpoints/LATEST"
+)
+
+// SaveCheckpoint uploads all files below a local directory as checkpoint name
+// and points the latest pointer at it. It returns the number of uploaded files.
+func (c *Client) SaveCheckpoint(ctx context.Context, runID, dir, name string) (int, error) {
+	if err := validateCheckpointName(name); err != nil {
+		return 0, err
+	}
+
+	uploads, err := DirectoryArtifacts(dir, path.Join(CheckpointArtifactDir, name))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read checkpoint directory: %w", err)
+	}
+	if len(uploads) == 0 {
+		return 0, fmt.Errorf("checkpoint directory %s has no files", dir)
+	}
+
+	for i, upload := range uploads {
+		if err := c.UploadArtifact(ctx, runID, upload.FilePath, upload.ArtifactPath); err != nil {
+			return i, fmt.Errorf("failed to upload %s: %w", upload.FilePath, err)
+		}
+	}
+
+	pointer := strings.NewReader(name + "\n")
+	if err := c.UploadArtifactFromReader(ctx, runID, pointer, pointer.Size(), CheckpointLatestFile); err != nil {
+		return len(uploads), fmt.Errorf("failed to update latest checkpoint pointer: %w", err)
+	}
+
+	return len(uploads), nil
+}
+
+// LatestCheckpoint returns the name of the most recent checkpoint of a run, or
+// an empty string if the run has no checkpoints
+func (c *Client) LatestCheckpoint(ctx context.Context, runID string) (string, error) {
+	exists, err := c.ArtifactExists(ctx, runID, CheckpointLatestFile)
+	if err != nil || !exists {
+		return "", err
+	}
+
+	body, _, err := c.OpenArtifact(ctx, runID, CheckpointLatestFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read latest checkpoint pointer: %w", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read latest checkpoint pointer: %w", err)
+	}
+
+	name := strings.TrimSpace(string(data))
+	if err := validateCheckpointName(name); err != nil {
+		return "", fmt.Errorf("invalid latest checkpoint pointer: %w", err)
+	}
+	return name, nil
+}
+
+// RestoreCheckpoint downloads all files of checkpoint name into a local
+// directory and returns the number of downloaded files
+func (c *Client) RestoreCheckpoint(ctx context.Context, runID, name, dest string) (int, error) {
+	if err := validateCheckpointName(name); err != nil {
+		return 0, err
+	}
+
+	prefix := path.Join(CheckpointArtifactDir, name)
+	files, err := c.ListArtifactsRecursive(ctx, runID, prefix)
+	if err != nil {
+		return 0, err
+	}
+	if len(files) == 0 {
+		return 0, fmt.Errorf("checkpoint %s not found", name)
+	}
+
+	// Artifact paths come from the server and must stay below the restore
+	// directory; all are checked before anything is written
+	targets := make([]string, len(files))
+	for i, file := range files {
+		rel, ok := strings.CutPrefix(file.Path, prefix+"/")
+		if !ok || !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return 0, fmt.Errorf("refusing to restore artifact outside the checkpoint directory: %s", file.Path)
+		}
+		targets[i] = filepath.Join(dest, filepath.FromSlash(rel))
+	}
+
+	for i, file := range files {
+		if err := c.DownloadArtifact(ctx, runID, file.Path, targets[i]); err != nil {
+			return i, fmt.Errorf("failed to download %s: %w", file.Path, err)
+		}
+	}
+
+	return len(files), nil
+}
+
+// validateCheckpointName rejects names that would escape the checkpoint directory
+func validateCheckpointName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+		return fmt.Errorf("invalid checkpoint name: %q", name)
+	}
+	return nil
+}
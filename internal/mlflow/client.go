@@ -1,12 +1,26 @@
 package mlflow
 
 import (
+	"context"
 	"fmt"
-
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/databricks/databricks-sdk-go"
+	sdkconfig "github.com/databricks/databricks-sdk-go/config"
 	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/pkg/sftp"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
 
 	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/httperr"
+	"github.com/imishinist/mlflow-cli/internal/sink"
 )
 
 // Client wraps the Databricks SDK client for MLflow operations
@@ -14,6 +28,38 @@ type Client struct {
 	client    *databricks.WorkspaceClient
 	config    *config.Config
 	apiClient *httpclient.ApiClient
+	// apiClientMu guards the lazy creation of apiClient by callAPI
+	apiClientMu sync.Mutex
+	// transport is used for all requests
+	transport http.RoundTripper
+	// plan records the requests of a dry run instead of sending them
+	plan *Plan
+	// capabilities are the optional APIs of the server, detected on first use
+	capabilities   *Capabilities
+	capabilitiesMu sync.Mutex
+	// s3 is the client of s3:// artifact stores, created on first use
+	s3         *s3.Client
+	s3ClientMu sync.Mutex
+	// gcs is the client of gs:// artifact stores, created on first use
+	gcs         *storage.Client
+	gcsClientMu sync.Mutex
+	// azureClients are the clients of Azure artifact stores by storage account
+	azureClients   map[string]*azblob.Client
+	azureClientsMu sync.Mutex
+	// sftpClients are the clients of sftp:// artifact stores by user and server
+	sftpClients   map[string]*sftp.Client
+	sftpClientsMu sync.Mutex
+	// memory bounds the upload buffers of concurrent uploads to the
+	// configured memory budget; nil if unlimited
+	memory *semaphore.Weighted
+	// bandwidth bounds the bytes per second of all artifact transfers of the
+	// client; nil if unlimited
+	bandwidth *rate.Limiter
+	// skewProbe measures the clock skew once before metric timestamps are
+	// corrected
+	skewProbe sync.Once
+	// sinks are written the metrics and params logged, except in dry runs
+	sinks []sink.Sink
 }
 
 // NewClient creates a new MLflow client with appropriate configuration
@@ -27,6 +73,29 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		return nil, err
 	}
 
+	// Error responses are bounded before the SDK or anything else reads them,
+	// failed requests are retried, every attempt of an API call waits for the
+	// rate limit, has a deadline and is signed if needed, and every attempt is
+	// counted for the retry summary
+	var attempt http.RoundTripper = newTelemetryTransport(http.DefaultTransport)
+	if cfg.Auth == config.AuthSigV4 {
+		if attempt, err = newSigV4Transport(context.Background(), attempt, cfg); err != nil {
+			return nil, err
+		}
+	}
+	var transport http.RoundTripper = httperr.NewTransport(newRetryTransport(newRateLimitTransport(
+		newTimeoutTransport(attempt, cfg.Timeout), cfg.RateLimit), cfg.MaxRetries))
+	var plan *Plan
+	if cfg.DryRun {
+		plan = DryRunPlan()
+		transport = newDryRunTransport(transport, plan)
+	}
+	transport = newRunLockTransport(transport, cfg.UnlockToken)
+	if len(cfg.AllowedExperiments) > 0 {
+		transport = newExperimentGuardTransport(transport, cfg.Profile, cfg.AllowedExperiments)
+	}
+	databricksConfig.HTTPTransport = transport
+
 	client, err := databricks.NewWorkspaceClient(databricksConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create MLflow client: %w", err)
@@ -41,19 +110,68 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		}
 	}
 
+	var memory *semaphore.Weighted
+	if cfg.MaxMemory > 0 {
+		memory = semaphore.NewWeighted(cfg.MaxMemory)
+	}
+
+	// Dry runs transfer nothing
+	var bandwidth *rate.Limiter
+	if !cfg.DryRun {
+		bandwidth = newBandwidthLimiter(cfg.BandwidthLimit)
+	}
+
+	var sinks []sink.Sink
+	if !cfg.DryRun {
+		for _, sinkConfig := range cfg.Sinks {
+			s, err := sink.New(sinkConfig)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, s)
+		}
+	}
+
 	return &Client{
 		client:    client,
 		config:    cfg,
 		apiClient: apiClient,
+		transport: transport,
+		plan:      plan,
+		memory:    memory,
+		bandwidth: bandwidth,
+		sinks:     sinks,
 	}, nil
 }
 
+// httpClient returns an HTTP client for requests sent outside the SDK
+func (c *Client) httpClient() *http.Client {
+	return &http.Client{Transport: c.transport}
+}
+
 // buildDatabricksConfig creates appropriate Databricks configuration based on tracking URI
 func buildDatabricksConfig(cfg *config.Config) (*databricks.Config, error) {
+	databricksConfig := buildRegularMLflowConfig(cfg)
 	if cfg.IsDatabricks() {
-		return buildDatabricksMLflowConfig(cfg)
+		var err error
+		if databricksConfig, err = buildDatabricksMLflowConfig(cfg); err != nil {
+			return nil, err
+		}
+	}
+	// Requests are limited by the rate limit transport instead of the SDK
+	databricksConfig.RateLimitPerSecond = math.MaxInt32
+	// Requests are retried by the retry transport. The SDK would retry 429 and
+	// 504 responses again for up to RetryTimeoutSeconds, but waits more than 1s
+	// before its first retry, so with a 1s retry timeout it gives up after 1s
+	// instead.
+	databricksConfig.RetryTimeoutSeconds = 1
+	// Attempts are bounded by the timeout transport; the SDK's own timeout
+	// must cover all attempts and the backoff between them
+	databricksConfig.HTTPTimeoutSeconds = math.MaxInt32
+	if cfg.Timeout > 0 {
+		databricksConfig.HTTPTimeoutSeconds = min((cfg.MaxRetries+1)*int((cfg.Timeout+retryMaxWait)/time.Second), math.MaxInt32)
 	}
-	return buildRegularMLflowConfig(cfg), nil
+	return databricksConfig, nil
 }
 
 // buildDatabricksMLflowConfig creates configuration for Databricks MLflow
@@ -90,9 +208,47 @@ func buildDatabricksMLflowConfig(cfg *config.Config) (*databricks.Config, error)
 
 // buildRegularMLflowConfig creates configuration for regular MLflow server
 func buildRegularMLflowConfig(cfg *config.Config) *databricks.Config {
+	if cfg.TrackingUsername != "" {
+		// MLflow's built-in authentication uses basic auth
+		return &databricks.Config{
+			Host:        cfg.TrackingURI,
+			Username:    cfg.TrackingUsername,
+			Password:    cfg.TrackingPassword,
+			Credentials: sdkconfig.BasicCredentials{},
+		}
+	}
 	return &databricks.Config{
 		Host: cfg.TrackingURI,
 		// For regular MLflow server, use a dummy token to bypass authentication
 		Token: "dummy-token-for-regular-mlflow",
 	}
 }
+
+// callAPI sends a REST API request for endpoints not covered by the SDK. For
+// GET requests, query parameters are passed in query; otherwise request is
+// sent as the JSON body.
+func (c *Client) callAPI(ctx context.Context, method, path string, query map[string]any, request, response any) error {
+	c.apiClientMu.Lock()
+	if c.apiClient == nil {
+		apiClient, err := c.client.Config.NewApiClient()
+		if err != nil {
+			c.apiClientMu.Unlock()
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+		c.apiClient = apiClient
+	}
+	c.apiClientMu.Unlock()
+
+	var options []httpclient.DoOption
+	if query != nil {
+		options = append(options, httpclient.WithQueryParameters(query))
+	}
+	if request != nil {
+		options = append(options, httpclient.WithRequestData(request))
+	}
+	if response != nil {
+		options = append(options, httpclient.WithResponseUnmarshal(response))
+	}
+
+	return c.apiClient.Do(ctx, method, path, options...)
+}
@@ -0,0 +1,138 @@
+package mlflow
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ClockSkewThreshold is the difference between the local clock and the
+// server's from which the clock is taken as skewed. Date headers have a
+// resolution of one second, and measurements are off by up to half a round
+// trip.
+const ClockSkewThreshold = 5 * time.Second
+
+// Clock skew modes: warn about skewed clocks, shift metric timestamps by the
+// skew, or do neither
+const (
+	ClockSkewWarn    = "warn"
+	ClockSkewCorrect = "correct"
+	ClockSkewIgnore  = "ignore"
+)
+
+// clockSkewProbePath is requested to measure the skew before the first metric
+// is logged; any response carries a Date header
+const clockSkewProbePath = "/health"
+
+// clockSkew estimates the offset of the local clock from the servers' clocks
+// from the Date headers of responses
+type clockSkew struct {
+	mu       sync.Mutex
+	measured bool
+	skew     time.Duration
+	// roundTrip is the duration of the request of the estimate; shorter
+	// requests give more accurate estimates
+	roundTrip time.Duration
+	// corrected is set once a metric timestamp was shifted by the skew
+	corrected bool
+}
+
+// skew is shared by all clients of the process, like the retry telemetry
+var skew = &clockSkew{}
+
+// observe updates the estimate with a response received at received for a
+// request sent at sent
+func (s *clockSkew) observe(sent, received time.Time, date string) {
+	if date == "" {
+		return
+	}
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		return
+	}
+
+	// The server's clock read between sent and received, and Date truncates it
+	// to the second
+	roundTrip := received.Sub(sent)
+	local := sent.Add(roundTrip / 2)
+	estimate := local.Sub(serverTime.Add(500 * time.Millisecond))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.measured || roundTrip < s.roundTrip {
+		s.measured = true
+		s.skew = estimate
+		s.roundTrip = roundTrip
+	}
+}
+
+// get returns the estimate, and false if no response had a Date header yet
+func (s *clockSkew) get() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.skew, s.measured
+}
+
+// ClockSkew returns how far the local clock is ahead of the servers' clocks
+// (behind if negative), rounded to the second, and false if no response
+// measured it yet
+func ClockSkew() (time.Duration, bool) {
+	d, ok := skew.get()
+	return d.Round(time.Second), ok
+}
+
+// ClockSkewCorrected reports whether metric timestamps were shifted by the
+// clock skew
+func ClockSkewCorrected() bool {
+	skew.mu.Lock()
+	defer skew.mu.Unlock()
+	return skew.corrected
+}
+
+// IsClockSkewed reports whether a skew is at least ClockSkewThreshold
+func IsClockSkewed(d time.Duration) bool {
+	return d >= ClockSkewThreshold || d <= -ClockSkewThreshold
+}
+
+// metricTime returns the timestamp of a metric as sent to the server. In the
+// correct mode, timestamps read from the local clock (localClock) are shifted
+// by a significant skew, so that metrics stamped by a clock running ahead do
+// not appear in the future. Timestamps given by the input, such as those of
+// metrics files and logs, are sent as they are.
+func (c *Client) metricTime(ctx context.Context, t time.Time, localClock bool) time.Time {
+	if c.config.ClockSkew != ClockSkewCorrect || !localClock {
+		return t
+	}
+
+	c.skewProbe.Do(func() {
+		if _, ok := skew.get(); !ok {
+			c.probeClockSkew(ctx)
+		}
+	})
+	d, ok := ClockSkew()
+	if !ok || !IsClockSkewed(d) {
+		return t
+	}
+	skew.mu.Lock()
+	skew.corrected = true
+	skew.mu.Unlock()
+	return t.Add(-d)
+}
+
+// probeClockSkew sends a request to the tracking server only for the Date
+// header of its response. Failures leave the skew unmeasured.
+func (c *Client) probeClockSkew(ctx context.Context) {
+	url := strings.TrimSuffix(c.client.Config.Host, "/") + clockSkewProbePath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+	c.addAuthHeaders(req)
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,76 @@
+package mlflow
+
+import (
+	"net/http"
+	"strings"
+)
+
+// readOnlyPOSTEndpoints are the MLflow API endpoints that are sent as POST
+// requests but change nothing on the server
+var readOnlyPOSTEndpoints = map[string]bool{
+	"runs/search":                       true,
+	"experiments/search":                true,
+	"logged-models/search":              true,
+	"metrics/get-history":               true,
+	"metrics/get-history-bulk":          true,
+	"metrics/get-history-bulk-interval": true,
+	"traces/search":                     true,
+}
+
+// idempotentEndpoints are the MLflow API endpoints sent as POST or PATCH
+// requests that leave the server in the same state when sent twice, besides
+// the read-only ones. Logging metrics or inputs, or creating runs and model
+// versions, is not: a request that reached the server before failing would be
+// applied again by a retry.
+var idempotentEndpoints = map[string]bool{
+	"runs/update":                                true,
+	"runs/delete":                                true,
+	"runs/restore":                               true,
+	"runs/set-tag":                               true,
+	"runs/delete-tag":                            true,
+	"runs/log-parameter":                         true,
+	"experiments/update":                         true,
+	"experiments/delete":                         true,
+	"experiments/restore":                        true,
+	"experiments/set-experiment-tag":             true,
+	"registered-models/update":                   true,
+	"registered-models/set-tag":                  true,
+	"registered-models/alias":                    true,
+	"model-versions/update":                      true,
+	"model-versions/set-tag":                     true,
+	"model-versions/transition-stage":            true,
+	"databricks/model-versions/transition-stage": true,
+}
+
+// apiEndpoint returns the MLflow API endpoint of a request path, such as
+// runs/search, or an empty string for paths outside of the MLflow API
+func apiEndpoint(path string) string {
+	_, endpoint, ok := strings.Cut(path, "/mlflow/")
+	if !ok {
+		return ""
+	}
+	return strings.Trim(endpoint, "/")
+}
+
+// readOnlyRequest reports whether a request changes nothing on the server
+func readOnlyRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return readOnlyPOSTEndpoints[apiEndpoint(req.URL.Path)]
+	}
+	return false
+}
+
+// idempotentRequest reports whether sending a request twice has the same
+// effect as sending it once
+func idempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost, http.MethodPatch:
+		return readOnlyRequest(req) || idempotentEndpoints[apiEndpoint(req.URL.Path)]
+	}
+	return readOnlyRequest(req)
+}
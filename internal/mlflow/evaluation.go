@@ -0,0 +1,107 @@
+package mlflow
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/plot"
+)
+
+// curveNames are the artifact name and AUC metric key of each curve type, as
+// logged by mlflow.evaluate
+var curveNames = map[models.CurveType]struct{ artifact, metric string }{
+	models.CurveROC: {"roc_curve", "roc_auc"},
+	models.CurvePR:  {"precision_recall_curve", "precision_recall_auc"},
+}
+
+// EvaluationResult lists what was logged for an evaluation chart
+type EvaluationResult struct {
+	// Plot is the artifact path of the chart
+	Plot string
+	// Table is the artifact path of the data of the chart
+	Table string
+	// MetricKey and MetricValue are the metric summarizing the chart
+	MetricKey   string
+	MetricValue float64
+}
+
+// LogCurve logs a ROC or precision-recall curve under the names of
+// mlflow.evaluate, prefixed by prefix: the chart as <name>_plot.svg, which the
+// MLflow UI shows in its artifact view, the points as <name>_data.json table,
+// and the area under the curve as metric.
+func (c *Client) LogCurve(ctx context.Context, runID, prefix string, curve *models.Curve) (*EvaluationResult, error) {
+	names, ok := curveNames[curve.Type]
+	if !ok {
+		return nil, fmt.Errorf("invalid curve type: %s (valid: roc, pr)", curve.Type)
+	}
+	columns := [2]string{"fpr", "tpr"}
+	if curve.Type == models.CurvePR {
+		columns = [2]string{"recall", "precision"}
+	}
+
+	table := &models.Table{Columns: []string{columns[0], columns[1], "threshold"}}
+	for _, point := range curve.Points {
+		// JSON has no infinity, the first threshold of sklearn's ROC curves
+		var threshold interface{}
+		if point.Threshold != nil && !math.IsInf(*point.Threshold, 0) && !math.IsNaN(*point.Threshold) {
+			threshold = *point.Threshold
+		}
+		table.Data = append(table.Data, []interface{}{point.X, point.Y, threshold})
+	}
+
+	result := &EvaluationResult{
+		Plot:        prefix + names.artifact + "_plot.svg",
+		Table:       prefix + names.artifact + "_data.json",
+		MetricKey:   prefix + names.metric,
+		MetricValue: curve.AUC(),
+	}
+	if err := c.logEvaluation(ctx, runID, result, plot.Curve(curve), table); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LogConfusionMatrix logs a confusion matrix under the names of
+// mlflow.evaluate, prefixed by prefix: the chart as confusion_matrix.svg,
+// the counts as confusion_matrix.json table, and the accuracy as
+// accuracy_score metric.
+func (c *Client) LogConfusionMatrix(ctx context.Context, runID, prefix string, matrix *models.ConfusionMatrix) (*EvaluationResult, error) {
+	table := &models.Table{Columns: append([]string{"true_label"}, matrix.Labels...)}
+	for i, row := range matrix.Counts {
+		cells := []interface{}{matrix.Labels[i]}
+		for _, count := range row {
+			cells = append(cells, count)
+		}
+		table.Data = append(table.Data, cells)
+	}
+
+	result := &EvaluationResult{
+		Plot:        prefix + "confusion_matrix.svg",
+		Table:       prefix + "confusion_matrix.json",
+		MetricKey:   prefix + "accuracy_score",
+		MetricValue: matrix.Accuracy(),
+	}
+	if err := c.logEvaluation(ctx, runID, result, plot.ConfusionMatrix(matrix), table); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// logEvaluation uploads the chart and table of an evaluation and logs its
+// metric. The metric key is checked first, so that a rejected key logs
+// nothing.
+func (c *Client) logEvaluation(ctx context.Context, runID string, result *EvaluationResult, chart []byte, table *models.Table) error {
+	if err := c.config.MetricNaming.ValidateMetricKey(result.MetricKey); err != nil {
+		return err
+	}
+	if err := c.UploadArtifactFromReader(ctx, runID, bytes.NewReader(chart), int64(len(chart)), result.Plot); err != nil {
+		return fmt.Errorf("failed to upload chart: %w", err)
+	}
+	if err := c.LogTable(ctx, runID, result.Table, table); err != nil {
+		return err
+	}
+	return c.LogMetric(ctx, runID, result.MetricKey, result.MetricValue, nil, nil)
+}
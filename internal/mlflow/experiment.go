@@ -0,0 +1,114 @@
+package mlflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/ml"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// TagNoteContent is the tag holding the description (note) of runs and experiments
+const TagNoteContent = "mlflow.note.content"
+
+// GetExperimentIDByName returns the ID of the experiment with the given name,
+// or an empty string if it does not exist
+func (c *Client) GetExperimentIDByName(ctx context.Context, name string) (string, error) {
+	resp, err := c.client.Experiments.GetByName(ctx, ml.GetByNameRequest{
+		ExperimentName: name,
+	})
+	if err != nil {
+		if errors.Is(err, apierr.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get experiment %s: %w", name, err)
+	}
+
+	if resp.Experiment == nil {
+		return "", nil
+	}
+
+	return resp.Experiment.ExperimentId, nil
+}
+
+// CreateExperiment creates an experiment and returns its ID
+func (c *Client) CreateExperiment(ctx context.Context, name string) (string, error) {
+	resp, err := c.client.Experiments.CreateExperiment(ctx, ml.CreateExperiment{
+		Name: name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create experiment %s: %w", name, err)
+	}
+
+	return resp.ExperimentId, nil
+}
+
+// EnsureExperiment returns the ID of the named experiment, creating it if needed
+func (c *Client) EnsureExperiment(ctx context.Context, name string) (string, error) {
+	experimentID, err := c.GetExperimentIDByName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if experimentID != "" {
+		return experimentID, nil
+	}
+
+	return c.CreateExperiment(ctx, name)
+}
+
+// GetExperiment returns an experiment by ID
+func (c *Client) GetExperiment(ctx context.Context, experimentID string) (*models.Experiment, error) {
+	resp, err := c.client.Experiments.GetExperiment(ctx, ml.GetExperimentRequest{
+		ExperimentId: experimentID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experiment %s: %w", experimentID, err)
+	}
+	if resp.Experiment == nil {
+		return nil, fmt.Errorf("experiment %s not found", experimentID)
+	}
+
+	return convertExperiment(resp.Experiment), nil
+}
+
+// SetExperimentTag sets a tag on an experiment
+func (c *Client) SetExperimentTag(ctx context.Context, experimentID, key, value string) error {
+	err := c.client.Experiments.SetExperimentTag(ctx, ml.SetExperimentTag{
+		ExperimentId: experimentID,
+		Key:          key,
+		Value:        value,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set experiment tag %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// SetExperimentDescription sets the description shown on the experiment page
+func (c *Client) SetExperimentDescription(ctx context.Context, experimentID, description string) error {
+	return c.SetExperimentTag(ctx, experimentID, TagNoteContent, description)
+}
+
+// convertExperiment converts an SDK experiment to the CLI model
+func convertExperiment(exp *ml.Experiment) *models.Experiment {
+	tags := make(map[string]string)
+	for _, tag := range exp.Tags {
+		tags[tag.Key] = tag.Value
+	}
+
+	return &models.Experiment{
+		ExperimentID:     exp.ExperimentId,
+		Name:             exp.Name,
+		ArtifactLocation: exp.ArtifactLocation,
+		LifecycleStage:   exp.LifecycleStage,
+		Description:      tags[TagNoteContent],
+		Tags:             tags,
+		CreatedAt:        time.UnixMilli(exp.CreationTime),
+		UpdatedAt:        time.UnixMilli(exp.LastUpdateTime),
+	}
+}
@@ -0,0 +1,258 @@
+package mlflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/imishinist/mlflow-cli/internal/httperr"
+)
+
+// ExperimentNotAllowedError is returned for requests targeting an experiment
+// outside the allowed experiments of the profile
+type ExperimentNotAllowedError struct {
+	Profile      string
+	ExperimentID string
+	Allowed      []string
+}
+
+func (e *ExperimentNotAllowedError) Error() string {
+	scope := "the config"
+	if e.Profile != "" {
+		scope = "profile " + e.Profile
+	}
+	return fmt.Sprintf("experiment %s is not allowed by %s (allowed_experiments: %s)", e.ExperimentID, scope, strings.Join(e.Allowed, ", "))
+}
+
+// experimentGuardTransport rejects MLflow API requests that target experiments,
+// or runs of experiments, outside an allow-list of experiment IDs and names.
+// The experiments of runs and the names of experiments are looked up once and
+// cached.
+type experimentGuardTransport struct {
+	next    http.RoundTripper
+	profile string
+	allowed []string
+
+	mu              sync.Mutex
+	runExperiments  map[string]string
+	experimentNames map[string]string
+}
+
+func newExperimentGuardTransport(next http.RoundTripper, profile string, allowed []string) *experimentGuardTransport {
+	return &experimentGuardTransport{
+		next:            next,
+		profile:         profile,
+		allowed:         allowed,
+		runExperiments:  make(map[string]string),
+		experimentNames: make(map[string]string),
+	}
+}
+
+func (t *experimentGuardTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only MLflow tracking APIs address experiments and runs; artifact
+	// transfers are preceded by a run lookup
+	if !strings.Contains(req.URL.Path, "/mlflow/") {
+		return t.next.RoundTrip(req)
+	}
+
+	targets, err := requestTargets(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range targets.experimentNames {
+		if !t.isAllowed(name) {
+			return nil, &ExperimentNotAllowedError{Profile: t.profile, ExperimentID: name, Allowed: t.allowed}
+		}
+	}
+	for _, runID := range targets.runIDs {
+		experimentID, err := t.runExperiment(req, runID)
+		if err != nil {
+			return nil, err
+		}
+		targets.experimentIDs = append(targets.experimentIDs, experimentID)
+	}
+	for _, experimentID := range targets.experimentIDs {
+		if err := t.checkExperiment(req, experimentID); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// isAllowed reports whether an experiment ID or name is in the allow-list
+func (t *experimentGuardTransport) isAllowed(experiment string) bool {
+	for _, allowed := range t.allowed {
+		if allowed == experiment {
+			return true
+		}
+	}
+	return false
+}
+
+// checkExperiment checks an experiment ID against the allow-list, falling back
+// to the experiment's name
+func (t *experimentGuardTransport) checkExperiment(req *http.Request, experimentID string) error {
+	if experimentID == DryRunID || t.isAllowed(experimentID) {
+		return nil
+	}
+
+	name, err := t.experimentName(req, experimentID)
+	if err != nil {
+		return err
+	}
+	if name != "" && t.isAllowed(name) {
+		return nil
+	}
+	return &ExperimentNotAllowedError{Profile: t.profile, ExperimentID: experimentID, Allowed: t.allowed}
+}
+
+// runExperiment returns the experiment ID of a run
+func (t *experimentGuardTransport) runExperiment(req *http.Request, runID string) (string, error) {
+	if runID == DryRunID {
+		return DryRunID, nil
+	}
+
+	t.mu.Lock()
+	experimentID, ok := t.runExperiments[runID]
+	t.mu.Unlock()
+	if ok {
+		return experimentID, nil
+	}
+
+	var resp struct {
+		Run struct {
+			Info struct {
+				ExperimentID string `json:"experiment_id"`
+			} `json:"info"`
+		} `json:"run"`
+	}
+	if err := lookupAPI(t.next, req, "runs/get", url.Values{"run_id": {runID}}, &resp); err != nil {
+		return "", fmt.Errorf("failed to look up experiment of run %s: %w", runID, err)
+	}
+
+	t.mu.Lock()
+	t.runExperiments[runID] = resp.Run.Info.ExperimentID
+	t.mu.Unlock()
+	return resp.Run.Info.ExperimentID, nil
+}
+
+// experimentName returns the name of an experiment
+func (t *experimentGuardTransport) experimentName(req *http.Request, experimentID string) (string, error) {
+	t.mu.Lock()
+	name, ok := t.experimentNames[experimentID]
+	t.mu.Unlock()
+	if ok {
+		return name, nil
+	}
+
+	var resp struct {
+		Experiment struct {
+			Name string `json:"name"`
+		} `json:"experiment"`
+	}
+	if err := lookupAPI(t.next, req, "experiments/get", url.Values{"experiment_id": {experimentID}}, &resp); err != nil {
+		return "", fmt.Errorf("failed to look up experiment %s: %w", experimentID, err)
+	}
+
+	t.mu.Lock()
+	t.experimentNames[experimentID] = resp.Experiment.Name
+	t.mu.Unlock()
+	return resp.Experiment.Name, nil
+}
+
+// lookupAPI sends a GET request to another MLflow API endpoint with the
+// credentials of req
+func lookupAPI(next http.RoundTripper, req *http.Request, endpoint string, query url.Values, response any) error {
+	idx := strings.Index(req.URL.Path, "/mlflow/")
+	lookupURL := *req.URL
+	lookupURL.Path = req.URL.Path[:idx] + "/mlflow/" + endpoint
+	lookupURL.RawPath = ""
+	lookupURL.RawQuery = query.Encode()
+
+	lookupReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, lookupURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	lookupReq.Header = req.Header.Clone()
+	lookupReq.Header.Del("Content-Type")
+
+	resp, err := next.RoundTrip(lookupReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, httperr.ReadMessage(resp.Body))
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// guardTargets are the experiments and runs addressed by a request
+type guardTargets struct {
+	experimentIDs   []string
+	experimentNames []string
+	runIDs          []string
+}
+
+// requestTargets extracts the experiments and runs addressed by the query of
+// GET requests or the JSON body of other requests. The body is restored for
+// sending.
+func requestTargets(req *http.Request) (guardTargets, error) {
+	var targets guardTargets
+
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		query := req.URL.Query()
+		targets.experimentIDs = append(targets.experimentIDs, query["experiment_id"]...)
+		targets.experimentIDs = append(targets.experimentIDs, query["experiment_ids"]...)
+		targets.runIDs = append(targets.runIDs, query["run_id"]...)
+		targets.runIDs = append(targets.runIDs, query["run_uuid"]...)
+		return targets, nil
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return targets, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return targets, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	var payload struct {
+		ExperimentID  string   `json:"experiment_id"`
+		ExperimentIDs []string `json:"experiment_ids"`
+		RunID         string   `json:"run_id"`
+		RunUUID       string   `json:"run_uuid"`
+		Name          string   `json:"name"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return targets, nil
+	}
+
+	if payload.ExperimentID != "" {
+		targets.experimentIDs = append(targets.experimentIDs, payload.ExperimentID)
+	}
+	targets.experimentIDs = append(targets.experimentIDs, payload.ExperimentIDs...)
+	if payload.RunID != "" {
+		targets.runIDs = append(targets.runIDs, payload.RunID)
+	} else if payload.RunUUID != "" {
+		targets.runIDs = append(targets.runIDs, payload.RunUUID)
+	}
+	// New experiments are addressed by name
+	if strings.HasSuffix(req.URL.Path, "/experiments/create") && payload.Name != "" {
+		targets.experimentNames = append(targets.experimentNames, payload.Name)
+	}
+	return targets, nil
+}
@@ -0,0 +1,116 @@
+package mlflow
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"time"
+
+	// Decoders of the image formats accepted besides PNG
+	_ "image/gif"
+	_ "image/jpeg"
+
+	"github.com/google/uuid"
+)
+
+// TagLoggedImages is the run tag marking runs with images logged by key and
+// step, which the MLflow UI shows in its image comparison view
+const TagLoggedImages = "mlflow.loggedImages"
+
+// imagesArtifactDir is the artifact directory of images logged by key and step
+const imagesArtifactDir = "images"
+
+// thumbnailSize is the maximum width and height of image thumbnails
+const thumbnailSize = 256
+
+// ImageArtifactPath returns the artifact path of an image logged with key at
+// step, without its extension. MLflow parses key, step and timestamp from the
+// file name; slashes in keys are stored as '#'.
+func ImageArtifactPath(key string, step int64, timestamp time.Time, id string) string {
+	return fmt.Sprintf("%s/%s%%step%%%d%%timestamp%%%d%%%s",
+		imagesArtifactDir, strings.ReplaceAll(key, "/", "#"), step, timestamp.UnixMilli(), id)
+}
+
+// LogImage logs a PNG, JPEG or GIF image to a run under key at step like
+// mlflow.log_image: the image is stored as PNG in the images artifact
+// directory next to a thumbnail, and the run is tagged as having images. It
+// returns the artifact path of the full-size image.
+func (c *Client) LogImage(ctx context.Context, runID, key string, step int64, timestamp time.Time, data []byte) (string, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image: %w", err)
+	}
+	if format != "png" {
+		if data, err = encodePNG(img); err != nil {
+			return "", err
+		}
+	}
+	thumbnail, err := encodePNG(resizeToFit(img, thumbnailSize))
+	if err != nil {
+		return "", err
+	}
+
+	base := ImageArtifactPath(key, step, timestamp, uuid.NewString())
+	imagePath := base + ".png"
+	// The UI loads thumbnails by this name; browsers detect the PNG content
+	// regardless of the extension
+	thumbnailPath := base + "%compressed.webp"
+
+	if err := c.UploadArtifactFromReader(ctx, runID, bytes.NewReader(data), int64(len(data)), imagePath); err != nil {
+		return "", fmt.Errorf("failed to upload image: %w", err)
+	}
+	if err := c.UploadArtifactFromReader(ctx, runID, bytes.NewReader(thumbnail), int64(len(thumbnail)), thumbnailPath); err != nil {
+		return "", fmt.Errorf("failed to upload image thumbnail: %w", err)
+	}
+	if err := c.SetTag(ctx, runID, TagLoggedImages, "True"); err != nil {
+		return "", err
+	}
+	return imagePath, nil
+}
+
+// encodePNG encodes img as PNG
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// resizeToFit scales img down to fit into size x size pixels, keeping its
+// aspect ratio. Each pixel averages the source pixels it covers.
+func resizeToFit(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	dstWidth, dstHeight := size, size
+	if width > height {
+		dstHeight = max(1, height*size/width)
+	} else {
+		dstWidth = max(1, width*size/height)
+	}
+
+	dst := image.NewRGBA64(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := y*height/dstHeight, (y+1)*height/dstHeight
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := x*width/dstWidth, (x+1)*width/dstWidth
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+					r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
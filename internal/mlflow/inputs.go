@@ -0,0 +1,42 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go/service/ml"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// TagDatasetContext is the input tag recording how a dataset was used by a
+// run, e.g. training or evaluation
+const TagDatasetContext = "mlflow.data.context"
+
+// LogDatasetInput records a dataset as an input of a run with input tags in
+// key order. The dataset then appears in the run's lineage in the MLflow UI.
+func (c *Client) LogDatasetInput(ctx context.Context, runID string, dataset models.Dataset, tags map[string]string) error {
+	inputTags := make([]ml.InputTag, 0, len(tags))
+	for _, key := range sortedKeys(tags) {
+		inputTags = append(inputTags, ml.InputTag{Key: key, Value: tags[key]})
+	}
+
+	err := c.client.Experiments.LogInputs(ctx, ml.LogInputs{
+		RunId: runID,
+		Datasets: []ml.DatasetInput{{
+			Dataset: ml.Dataset{
+				Name:       dataset.Name,
+				Digest:     dataset.Digest,
+				SourceType: dataset.SourceType,
+				Source:     dataset.Source,
+				Schema:     dataset.Schema,
+				Profile:    dataset.Profile,
+			},
+			Tags: inputTags,
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to log dataset %s: %w", dataset.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package mlflow
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/databricks/databricks-sdk-go/service/ml"
+)
+
+// TagLocked marks a frozen run with the value true. Commands refuse to change
+// a frozen run without its unlock token; the tracking server and other
+// clients do not enforce it.
+const TagLocked = "locked"
+
+// TagUnlockTokenHash holds the SHA-256 of the unlock token of a frozen run
+const TagUnlockTokenHash = "mlflow-cli.unlockTokenHash"
+
+// RunLockedError is returned for requests that would change a frozen run
+type RunLockedError struct {
+	RunID string
+	// WrongToken is set if an unlock token was given but is not the run's
+	WrongToken bool
+}
+
+func (e *RunLockedError) Error() string {
+	if e.WrongToken {
+		return fmt.Sprintf("run %s is frozen and the unlock token does not match", e.RunID)
+	}
+	return fmt.Sprintf("run %s is frozen: pass its --unlock-token to change it", e.RunID)
+}
+
+// HashUnlockToken returns the hash of an unlock token stored on frozen runs
+func HashUnlockToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// runLock is the lock state of a run
+type runLock struct {
+	locked    bool
+	tokenHash string
+}
+
+func runLockFromTags(tags []ml.RunTag) runLock {
+	var lock runLock
+	for _, tag := range tags {
+		switch tag.Key {
+		case TagLocked:
+			lock.locked = strings.EqualFold(tag.Value, "true")
+		case TagUnlockTokenHash:
+			lock.tokenHash = tag.Value
+		}
+	}
+	return lock
+}
+
+// check returns a RunLockedError if the run is frozen and token is not its
+// unlock token. Runs tagged locked by hand have no token hash and are
+// unlocked by any token.
+func (l runLock) check(runID, token string) error {
+	if !l.locked {
+		return nil
+	}
+	if token == "" {
+		return &RunLockedError{RunID: runID}
+	}
+	if l.tokenHash != "" && l.tokenHash != HashUnlockToken(token) {
+		return &RunLockedError{RunID: runID, WrongToken: true}
+	}
+	return nil
+}
+
+// runLockTransport rejects MLflow API requests that change frozen runs unless
+// the unlock token of the run is given. The lock state of each run is looked
+// up once and cached, so a run frozen while a command is running is not
+// protected from it.
+type runLockTransport struct {
+	next  http.RoundTripper
+	token string
+
+	mu    sync.Mutex
+	locks map[string]runLock
+}
+
+func newRunLockTransport(next http.RoundTripper, token string) *runLockTransport {
+	return &runLockTransport{next: next, token: token, locks: make(map[string]runLock)}
+}
+
+func (t *runLockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Runs are changed by the run APIs other than search; artifact uploads
+	// check the lock of their run themselves
+	if req.Method == http.MethodGet || req.Method == http.MethodHead ||
+		!strings.Contains(req.URL.Path, "/mlflow/runs/") || strings.HasSuffix(req.URL.Path, "/runs/search") {
+		return t.next.RoundTrip(req)
+	}
+
+	targets, err := requestTargets(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, runID := range targets.runIDs {
+		lock, err := t.runLock(req, runID)
+		if err != nil {
+			return nil, err
+		}
+		if err := lock.check(runID, t.token); err != nil {
+			return nil, err
+		}
+	}
+	return t.next.RoundTrip(req)
+}
+
+// runLock returns the lock state of a run
+func (t *runLockTransport) runLock(req *http.Request, runID string) (runLock, error) {
+	if runID == DryRunID {
+		return runLock{}, nil
+	}
+
+	t.mu.Lock()
+	lock, ok := t.locks[runID]
+	t.mu.Unlock()
+	if ok {
+		return lock, nil
+	}
+
+	var resp struct {
+		Run struct {
+			Data struct {
+				Tags []ml.RunTag `json:"tags"`
+			} `json:"data"`
+		} `json:"run"`
+	}
+	if err := lookupAPI(t.next, req, "runs/get", url.Values{"run_id": {runID}}, &resp); err != nil {
+		return runLock{}, fmt.Errorf("failed to look up lock of run %s: %w", runID, err)
+	}
+	lock = runLockFromTags(resp.Run.Data.Tags)
+
+	t.mu.Lock()
+	t.locks[runID] = lock
+	t.mu.Unlock()
+	return lock, nil
+}
+
+// FreezeRun freezes a run: commands refuse to change it without token
+func (c *Client) FreezeRun(ctx context.Context, runID, token string) error {
+	lock, err := c.getRunLock(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if lock.locked {
+		return fmt.Errorf("run %s is already frozen", runID)
+	}
+
+	// The token hash is set first, so that the run is never frozen without it
+	if err := c.SetTag(ctx, runID, TagUnlockTokenHash, HashUnlockToken(token)); err != nil {
+		return err
+	}
+	return c.SetTag(ctx, runID, TagLocked, "true")
+}
+
+// UnfreezeRun unfreezes a run frozen with the configured unlock token
+func (c *Client) UnfreezeRun(ctx context.Context, runID string) error {
+	lock, err := c.getRunLock(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if !lock.locked {
+		return fmt.Errorf("run %s is not frozen", runID)
+	}
+	if err := lock.check(runID, c.config.UnlockToken); err != nil {
+		return err
+	}
+
+	if err := c.DeleteTag(ctx, runID, TagLocked); err != nil {
+		return err
+	}
+	if lock.tokenHash != "" {
+		return c.DeleteTag(ctx, runID, TagUnlockTokenHash)
+	}
+	return nil
+}
+
+// checkRunUnlocked fails if a run is frozen and the configured unlock token
+// is not its token. Artifact uploads and deletions check it first, as most
+// artifact stores are written without the tracking server.
+func (c *Client) checkRunUnlocked(ctx context.Context, runID string) error {
+	lock, err := c.getRunLock(ctx, runID)
+	if err != nil {
+		return err
+	}
+	return lock.check(runID, c.config.UnlockToken)
+}
+
+// getRunLock returns the lock state of a run
+func (c *Client) getRunLock(ctx context.Context, runID string) (runLock, error) {
+	resp, err := c.client.Experiments.GetRun(ctx, ml.GetRunRequest{RunId: runID})
+	if err != nil {
+		return runLock{}, fmt.Errorf("failed to get run: %w", err)
+	}
+	if resp.Run == nil || resp.Run.Data == nil {
+		return runLock{}, nil
+	}
+	return runLockFromTags(resp.Run.Data.Tags), nil
+}
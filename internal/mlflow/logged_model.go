@@ -0,0 +1,376 @@
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/service/ml"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// TagLogModelHistory is the run tag listing the models logged as artifacts of
+// the run, as maintained by MLflow 2 clients
+const TagLogModelHistory = "mlflow.log-model.history"
+
+// runModelScheme prefixes the IDs of models logged as run artifacts
+const runModelScheme = "runs:/"
+
+// LoggedModelOptions describes a model to log
+type LoggedModelOptions struct {
+	ExperimentID string
+	Name         string
+	ModelType    string
+	SourceRunID  string
+	Params       map[string]string
+	Tags         map[string]string
+}
+
+// logModelHistoryEntry is an entry of the mlflow.log-model.history run tag
+type logModelHistoryEntry struct {
+	RunID          string         `json:"run_id"`
+	ArtifactPath   string         `json:"artifact_path"`
+	UTCTimeCreated string         `json:"utc_time_created"`
+	Flavors        map[string]any `json:"flavors"`
+}
+
+// LogModel logs a model with the files of uploads as its artifacts, uploaded
+// with up to parallelism concurrent uploads. On servers with the logged model
+// API, the model is created pending and finalized once its files are
+// uploaded, or marked failed if an upload fails. On older servers, the files
+// are uploaded below <name>/ of the source run, and the model is recorded in
+// the run's model history.
+func (c *Client) LogModel(ctx context.Context, opts LoggedModelOptions, uploads []ArtifactUpload, parallelism int) (*models.LoggedModel, error) {
+	capabilities, err := c.Capabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !capabilities.LoggedModels {
+		return c.logRunModel(ctx, opts, uploads, parallelism)
+	}
+	// Artifacts of logged models in DBFS need credentials issued per model
+	if c.config.IsDatabricks() && len(uploads) > 0 {
+		return nil, fmt.Errorf("uploading the artifacts of logged models to Databricks is not supported")
+	}
+
+	request := ml.CreateLoggedModelRequest{
+		ExperimentId: opts.ExperimentID,
+		Name:         opts.Name,
+		ModelType:    opts.ModelType,
+		SourceRunId:  opts.SourceRunID,
+	}
+	for key, value := range opts.Params {
+		request.Params = append(request.Params, ml.LoggedModelParameter{Key: key, Value: value})
+	}
+	request.Tags = loggedModelTags(opts.Tags)
+	resp, err := c.client.Experiments.CreateLoggedModel(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logged model %s: %w", opts.Name, err)
+	}
+	if resp.Model == nil || resp.Model.Info == nil {
+		return nil, fmt.Errorf("failed to create logged model %s: no model returned", opts.Name)
+	}
+	model := convertLoggedModel(resp.Model)
+
+	if err := c.uploadModelArtifacts(ctx, model.ArtifactURI, uploads, parallelism); err != nil {
+		if _, finalizeErr := c.client.Experiments.FinalizeLoggedModel(ctx, ml.FinalizeLoggedModelRequest{
+			ModelId: model.ModelID,
+			Status:  ml.LoggedModelStatusLoggedModelUploadFailed,
+		}); finalizeErr != nil {
+			return nil, fmt.Errorf("%w (and failed to mark model %s as failed: %v)", err, model.ModelID, finalizeErr)
+		}
+		return nil, err
+	}
+
+	finalized, err := c.client.Experiments.FinalizeLoggedModel(ctx, ml.FinalizeLoggedModelRequest{
+		ModelId: model.ModelID,
+		Status:  ml.LoggedModelStatusLoggedModelReady,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to finalize logged model %s: %w", model.ModelID, err)
+	}
+	if finalized.Model != nil && finalized.Model.Info != nil {
+		model = convertLoggedModel(finalized.Model)
+	} else {
+		model.Status = models.LoggedModelStatusReady
+	}
+	return model, nil
+}
+
+// logRunModel logs a model as artifacts of its source run, like MLflow 2
+func (c *Client) logRunModel(ctx context.Context, opts LoggedModelOptions, uploads []ArtifactUpload, parallelism int) (*models.LoggedModel, error) {
+	if opts.SourceRunID == "" {
+		return nil, fmt.Errorf("the tracking server has no logged model API (MLflow 3); models are logged as artifacts of a source run, which must be given")
+	}
+	if len(opts.Params) > 0 || len(opts.Tags) > 0 || opts.ModelType != "" {
+		return nil, fmt.Errorf("the tracking server has no logged model API (MLflow 3); model params, tags and types are not supported for models logged as run artifacts")
+	}
+
+	if err := c.checkRunUnlocked(ctx, opts.SourceRunID); err != nil {
+		return nil, err
+	}
+	run, err := c.GetRun(ctx, opts.SourceRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	runUploads := make([]ArtifactUpload, len(uploads))
+	for i, upload := range uploads {
+		runUploads[i] = ArtifactUpload{FilePath: upload.FilePath, ArtifactPath: path.Join(opts.Name, upload.ArtifactPath)}
+	}
+	if err := c.uploadModelArtifacts(ctx, run.ArtifactURI, runUploads, parallelism); err != nil {
+		return nil, err
+	}
+
+	// Record the model like MLflow 2 clients so that the UI lists it
+	now := time.Now().UTC()
+	var history []logModelHistoryEntry
+	if value := run.Tags[TagLogModelHistory]; value != "" {
+		if err := json.Unmarshal([]byte(value), &history); err != nil {
+			return nil, fmt.Errorf("failed to parse %s of run %s: %w", TagLogModelHistory, run.RunID, err)
+		}
+	}
+	history = append(history, logModelHistoryEntry{
+		RunID:          run.RunID,
+		ArtifactPath:   opts.Name,
+		UTCTimeCreated: now.Format("2006-01-02 15:04:05.000000"),
+		Flavors:        map[string]any{},
+	})
+	data, err := json.Marshal(history)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.SetTag(ctx, run.RunID, TagLogModelHistory, string(data)); err != nil {
+		return nil, err
+	}
+
+	model := runModel(run, opts.Name)
+	model.CreatedAt = now
+	model.UpdatedAt = now
+	return model, nil
+}
+
+// uploadModelArtifacts uploads the files of a model, failing on the first
+// failed upload
+func (c *Client) uploadModelArtifacts(ctx context.Context, artifactURI string, uploads []ArtifactUpload, parallelism int) error {
+	errs := make([]error, len(uploads))
+	runParallel(len(uploads), parallelism, nil, func(i int) {
+		errs[i] = c.uploadFile(ctx, artifactURI, uploads[i], nil)
+	})
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", uploads[i].FilePath, err)
+		}
+	}
+	return nil
+}
+
+// GetLoggedModel returns a logged model. IDs of the form runs:/<run-id>/<path>
+// select models logged as run artifacts, which are the only models of servers
+// without the logged model API.
+func (c *Client) GetLoggedModel(ctx context.Context, modelID string) (*models.LoggedModel, error) {
+	if strings.HasPrefix(modelID, runModelScheme) {
+		runID, artifactPath, _ := strings.Cut(strings.TrimPrefix(modelID, runModelScheme), "/")
+		artifactPath = strings.Trim(artifactPath, "/")
+		if runID == "" || artifactPath == "" {
+			return nil, fmt.Errorf("invalid model URI: %s (expected runs:/<run-id>/<path>)", modelID)
+		}
+		run, err := c.GetRun(ctx, runID)
+		if err != nil {
+			return nil, err
+		}
+		for _, model := range runModels(run) {
+			if model.Name == artifactPath {
+				return model, nil
+			}
+		}
+		return nil, fmt.Errorf("run %s has no model %s", runID, artifactPath)
+	}
+
+	capabilities, err := c.Capabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !capabilities.LoggedModels {
+		return nil, fmt.Errorf("the tracking server has no logged model API (MLflow 3); select models logged as run artifacts by runs:/<run-id>/<path>")
+	}
+
+	resp, err := c.client.Experiments.GetLoggedModelByModelId(ctx, modelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logged model %s: %w", modelID, err)
+	}
+	if resp.Model == nil || resp.Model.Info == nil {
+		return nil, fmt.Errorf("logged model %s not found", modelID)
+	}
+	return convertLoggedModel(resp.Model), nil
+}
+
+// SearchLoggedModels returns the logged models of the given experiments
+// matching filter, following pagination and stopping after max models (0 for
+// no limit). On servers without the logged model API, it returns the models
+// logged as artifacts of the runs matching filter instead.
+func (c *Client) SearchLoggedModels(ctx context.Context, experimentIDs []string, filter string, max int) ([]*models.LoggedModel, error) {
+	capabilities, err := c.Capabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !capabilities.LoggedModels {
+		return c.searchRunModels(ctx, experimentIDs, filter, max)
+	}
+
+	var result []*models.LoggedModel
+	request := ml.SearchLoggedModelsRequest{
+		ExperimentIds: experimentIDs,
+		Filter:        filter,
+	}
+	for {
+		resp, err := c.client.Experiments.SearchLoggedModels(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search logged models: %w", err)
+		}
+		for i := range resp.Models {
+			result = append(result, convertLoggedModel(&resp.Models[i]))
+			if max > 0 && len(result) >= max {
+				return result, nil
+			}
+		}
+		if resp.NextPageToken == "" {
+			return result, nil
+		}
+		request.PageToken = resp.NextPageToken
+	}
+}
+
+// searchRunModels returns the models logged as artifacts of the runs matching
+// filter
+func (c *Client) searchRunModels(ctx context.Context, experimentIDs []string, filter string, max int) ([]*models.LoggedModel, error) {
+	runs, err := c.SearchRuns(ctx, experimentIDs, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*models.LoggedModel
+	for _, run := range runs {
+		for _, model := range runModels(run) {
+			result = append(result, model)
+			if max > 0 && len(result) >= max {
+				return result, nil
+			}
+		}
+	}
+	return result, nil
+}
+
+// SetLoggedModelTags sets tags on a logged model
+func (c *Client) SetLoggedModelTags(ctx context.Context, modelID string, tags map[string]string) error {
+	if strings.HasPrefix(modelID, runModelScheme) {
+		return fmt.Errorf("models logged as run artifacts have no tags; tag their run instead")
+	}
+	capabilities, err := c.Capabilities(ctx)
+	if err != nil {
+		return err
+	}
+	if !capabilities.LoggedModels {
+		return fmt.Errorf("the tracking server has no logged model API (MLflow 3); tag the runs of models instead")
+	}
+
+	err = c.client.Experiments.SetLoggedModelTags(ctx, ml.SetLoggedModelTagsRequest{
+		ModelId: modelID,
+		Tags:    loggedModelTags(tags),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set tags of logged model %s: %w", modelID, err)
+	}
+	return nil
+}
+
+// loggedModelTags converts tags to SDK logged model tags
+func loggedModelTags(tags map[string]string) []ml.LoggedModelTag {
+	result := make([]ml.LoggedModelTag, 0, len(tags))
+	for key, value := range tags {
+		result = append(result, ml.LoggedModelTag{Key: key, Value: value})
+	}
+	return result
+}
+
+// runModels returns the models recorded in the model history of a run
+func runModels(run *models.RunInfo) []*models.LoggedModel {
+	var history []logModelHistoryEntry
+	if json.Unmarshal([]byte(run.Tags[TagLogModelHistory]), &history) != nil {
+		return nil
+	}
+
+	var result []*models.LoggedModel
+	seen := make(map[string]bool)
+	for i := len(history) - 1; i >= 0; i-- {
+		entry := history[i]
+		// Logging to the same path again replaces the model
+		if entry.ArtifactPath == "" || seen[entry.ArtifactPath] {
+			continue
+		}
+		seen[entry.ArtifactPath] = true
+
+		model := runModel(run, entry.ArtifactPath)
+		if created, err := time.Parse("2006-01-02 15:04:05.999999", entry.UTCTimeCreated); err == nil {
+			model.CreatedAt = created
+			model.UpdatedAt = created
+		}
+		result = append(result, model)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// runModel returns the model logged below artifactPath of a run
+func runModel(run *models.RunInfo, artifactPath string) *models.LoggedModel {
+	return &models.LoggedModel{
+		ModelID:      runModelScheme + run.RunID + "/" + artifactPath,
+		ExperimentID: run.ExperimentID,
+		Name:         artifactPath,
+		SourceRunID:  run.RunID,
+		Status:       models.LoggedModelStatusReady,
+		ArtifactURI:  strings.TrimSuffix(run.ArtifactURI, "/") + "/" + artifactPath,
+	}
+}
+
+// convertLoggedModel converts an SDK logged model to the CLI model
+func convertLoggedModel(model *ml.LoggedModel) *models.LoggedModel {
+	info := model.Info
+	tags := make(map[string]string)
+	for _, tag := range info.Tags {
+		tags[tag.Key] = tag.Value
+	}
+	params := make(map[string]string)
+	metrics := make(map[string]float64)
+	if model.Data != nil {
+		for _, param := range model.Data.Params {
+			params[param.Key] = param.Value
+		}
+		for _, metric := range model.Data.Metrics {
+			metrics[metric.Key] = metric.Value
+		}
+	}
+
+	return &models.LoggedModel{
+		ModelID:       info.ModelId,
+		ExperimentID:  info.ExperimentId,
+		Name:          info.Name,
+		ModelType:     info.ModelType,
+		SourceRunID:   info.SourceRunId,
+		Status:        models.LoggedModelStatus(info.Status),
+		StatusMessage: info.StatusMessage,
+		ArtifactURI:   info.ArtifactUri,
+		Params:        params,
+		Tags:          tags,
+		Metrics:       metrics,
+		CreatedAt:     time.UnixMilli(info.CreationTimestampMs),
+		UpdatedAt:     time.UnixMilli(info.LastUpdatedTimestampMs),
+	}
+}
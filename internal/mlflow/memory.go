@@ -0,0 +1,64 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// fitBuffers returns the size and number of the chunk buffers of an upload,
+// reduced from size and count to fit the memory budget: the count first, then
+// the size down to minSize. ok is false if a single buffer of minSize does not
+// fit, in which case the upload should not buffer at all.
+func (c *Client) fitBuffers(size, count, minSize int64) (int64, int64, bool) {
+	limit := c.config.MaxMemory
+	if limit <= 0 || size*count <= limit {
+		return size, count, true
+	}
+	if limit < minSize {
+		return 0, 0, false
+	}
+	if n := limit / size; n >= 1 {
+		return size, n, true
+	}
+	return limit, 1, true
+}
+
+// reserveMemory blocks until size bytes of the memory budget shared by the
+// concurrent uploads of the client are free, and returns the function that
+// releases them. Without a budget, it returns at once.
+func (c *Client) reserveMemory(ctx context.Context, size int64) (func(), error) {
+	if c.memory == nil || size <= 0 {
+		return func() {}, nil
+	}
+	size = min(size, c.config.MaxMemory)
+	if err := c.memory.Acquire(ctx, size); err != nil {
+		return nil, err
+	}
+	return func() { c.memory.Release(size) }, nil
+}
+
+// spillToFile copies a stream to a temporary file, so that it can be
+// uploaded without buffering it in memory. The returned function closes and
+// removes the file.
+func spillToFile(body io.Reader) (*os.File, func(), error) {
+	file, err := os.CreateTemp("", "mlflow-cli-upload-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	cleanup := func() {
+		file.Close()
+		os.Remove(file.Name())
+	}
+
+	if _, err := io.Copy(file, body); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	return file, cleanup, nil
+}
@@ -2,29 +2,48 @@ package mlflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/ml"
 
 	"github.com/imishinist/mlflow-cli/internal/models"
 )
 
 func (c *Client) LogMetric(ctx context.Context, runID string, key string, value float64, timestamp *time.Time, step *int64) error {
+	return c.LogModelMetric(ctx, runID, "", key, value, timestamp, step)
+}
+
+// LogModelMetric logs a metric of a run linked to the logged model modelID,
+// or to no model if it is empty
+func (c *Client) LogModelMetric(ctx context.Context, runID, modelID string, key string, value float64, timestamp *time.Time, step *int64) error {
+	if err := c.config.MetricNaming.ValidateMetricKey(key); err != nil {
+		return err
+	}
+
 	logMetric := ml.LogMetric{
-		RunId: runID,
-		Key:   key,
-		Value: value,
+		RunId:   runID,
+		ModelId: modelID,
+		Key:     key,
+		Value:   value,
 	}
 
+	metric := models.Metric{Key: key, Value: value, Timestamp: time.Now(), LocalClock: true}
 	if timestamp != nil {
-		logMetric.Timestamp = timestamp.UnixMilli()
-	} else {
-		logMetric.Timestamp = time.Now().UnixMilli()
+		metric.Timestamp = *timestamp
+		metric.LocalClock = false
 	}
+	logMetric.Timestamp = c.metricTime(ctx, metric.Timestamp, metric.LocalClock).UnixMilli()
 
 	if step != nil {
 		logMetric.Step = *step
+		metric.Step = *step
 	}
 
 	err := c.client.Experiments.LogMetric(ctx, logMetric)
@@ -32,10 +51,15 @@ func (c *Client) LogMetric(ctx context.Context, runID string, key string, value
 		return fmt.Errorf("failed to log metric %s: %w", key, err)
 	}
 
+	c.forwardMetrics(ctx, runID, []models.Metric{metric})
 	return nil
 }
 
 func (c *Client) LogMetrics(ctx context.Context, runID string, metrics []models.Metric) error {
+	if err := c.validateMetricKeys(metrics); err != nil {
+		return err
+	}
+
 	for _, metric := range metrics {
 		if err := c.LogMetric(ctx, runID, metric.Key, metric.Value, &metric.Timestamp, &metric.Step); err != nil {
 			return err
@@ -45,10 +69,234 @@ func (c *Client) LogMetrics(ctx context.Context, runID string, metrics []models.
 	return nil
 }
 
+// MaxMetricsPerBatch is the maximum number of metrics in a single log-batch request
+const MaxMetricsPerBatch = 1000
+
+// LogBatchMetrics logs metrics using the log-batch API, split into chunks of
+// MaxMetricsPerBatch. A chunk rejected by the server is retried metric by
+// metric, so that servers or values the batch endpoint cannot handle still work.
 func (c *Client) LogBatchMetrics(ctx context.Context, runID string, metrics []models.Metric) error {
-	// For now, log metrics one by one to avoid batch API issues
+	return c.LogBatchMetricsParallel(ctx, runID, metrics, 1, nil)
+}
+
+// LogBatchMetricsParallel works like LogBatchMetrics but sends up to parallelism
+// chunks concurrently. If progress is not nil, it is called with the number of
+// metrics logged so far after each chunk. No new chunk is started after a
+// failure; errors of chunks already in flight are reported in chunk order.
+func (c *Client) LogBatchMetricsParallel(ctx context.Context, runID string, metrics []models.Metric, parallelism int, progress func(logged, total int)) error {
+	return c.LogMetricSource(ctx, runID, metricSlice(metrics), parallelism, progress)
+}
+
+// MetricSource yields metrics in batches, such as a spool.MetricSpool holding
+// more metrics than fit in memory
+type MetricSource interface {
+	// Len returns the number of metrics
+	Len() int
+	// Batches calls fn with consecutive batches of up to size metrics; fn may
+	// keep the batches
+	Batches(size int, fn func([]models.Metric) error) error
+}
+
+// metricSlice is a MetricSource of metrics in memory
+type metricSlice []models.Metric
+
+func (m metricSlice) Len() int { return len(m) }
+
+func (m metricSlice) Batches(size int, fn func([]models.Metric) error) error {
+	for start := 0; start < len(m); start += size {
+		if err := fn(m[start:min(start+size, len(m))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errStopBatches stops reading a MetricSource after a failed chunk
+var errStopBatches = errors.New("stop")
+
+// LogMetricSource logs the metrics of source like LogBatchMetricsParallel,
+// holding only the chunks in flight in memory
+func (c *Client) LogMetricSource(ctx context.Context, runID string, source MetricSource, parallelism int, progress func(logged, total int)) error {
+	// Validate all keys up front so that a policy violation logs nothing
+	err := source.Batches(MaxMetricsPerBatch, func(metrics []models.Metric) error {
+		return c.validateMetricKeys(metrics)
+	})
+	if err != nil {
+		return err
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	total := source.Len()
+	chunks := (total + MaxMetricsPerBatch - 1) / MaxMetricsPerBatch
+	errs := make([]error, chunks)
+
+	type chunk struct {
+		index   int
+		metrics []models.Metric
+	}
+
+	var (
+		mu     sync.Mutex
+		logged int
+		failed atomic.Bool
+		wg     sync.WaitGroup
+	)
+	queue := make(chan chunk)
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range queue {
+				start := chunk.index * MaxMetricsPerBatch
+				end := start + len(chunk.metrics)
+				if err := c.logMetricsChunk(ctx, runID, chunk.metrics); err != nil {
+					errs[chunk.index] = fmt.Errorf("metrics %d-%d: %w", start+1, end, err)
+					failed.Store(true)
+					continue
+				}
+
+				if progress != nil {
+					mu.Lock()
+					logged += end - start
+					progress(logged, total)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	index := 0
+	err = source.Batches(MaxMetricsPerBatch, func(metrics []models.Metric) error {
+		if failed.Load() {
+			return errStopBatches
+		}
+		queue <- chunk{index: index, metrics: metrics}
+		index++
+		return nil
+	})
+	close(queue)
+	wg.Wait()
+
+	if err != nil && !errors.Is(err, errStopBatches) {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// logMetricsChunk logs up to MaxMetricsPerBatch metrics in one request, falling
+// back to single requests if the server rejected the batch
+func (c *Client) logMetricsChunk(ctx context.Context, runID string, metrics []models.Metric) error {
+	batchErr := c.logBatch(ctx, runID, metrics)
+	if batchErr == nil {
+		c.forwardMetrics(ctx, runID, metrics)
+		return nil
+	}
+	if !batchRejected(batchErr) {
+		return fmt.Errorf("failed to log metrics batch: %w", batchErr)
+	}
+
 	for _, metric := range metrics {
 		if err := c.LogMetric(ctx, runID, metric.Key, metric.Value, &metric.Timestamp, &metric.Step); err != nil {
+			return fmt.Errorf("failed to log metrics batch (%v), and fallback failed: %w", batchErr, err)
+		}
+	}
+	return nil
+}
+
+// batchRejected reports whether a log-batch request failed without logging
+// anything: the server rejected the batch (400), or does not support log-batch
+// (404). Other failures, such as timeouts or server errors, may have logged
+// the batch, which single requests would log again.
+func batchRejected(err error) bool {
+	var apiErr *apierr.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusNotFound
+}
+
+// logBatch logs up to MaxMetricsPerBatch metrics in a single log-batch request
+func (c *Client) logBatch(ctx context.Context, runID string, metrics []models.Metric) error {
+	batch := make([]ml.Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		batch = append(batch, ml.Metric{
+			Key:       metric.Key,
+			Value:     metric.Value,
+			Timestamp: c.metricTime(ctx, metric.Timestamp, metric.LocalClock).UnixMilli(),
+			Step:      metric.Step,
+			// Zero values are valid metrics and must not be omitted
+			ForceSendFields: []string{"Value", "Step"},
+		})
+	}
+
+	return c.client.Experiments.LogBatch(ctx, ml.LogBatch{
+		RunId:   runID,
+		Metrics: batch,
+	})
+}
+
+// GetMetricHistory returns all values logged for a metric, following pagination,
+// ordered by step and timestamp
+func (c *Client) GetMetricHistory(ctx context.Context, runID, key string) ([]models.Metric, error) {
+	history, err := c.client.Experiments.GetHistoryAll(ctx, ml.GetHistoryRequest{
+		RunId:     runID,
+		MetricKey: key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history of metric %s: %w", key, err)
+	}
+
+	metrics := make([]models.Metric, 0, len(history))
+	for _, metric := range history {
+		metrics = append(metrics, models.Metric{
+			Key:       metric.Key,
+			Value:     metric.Value,
+			Timestamp: time.UnixMilli(metric.Timestamp),
+			Step:      metric.Step,
+		})
+	}
+
+	sort.SliceStable(metrics, func(i, j int) bool {
+		if metrics[i].Step != metrics[j].Step {
+			return metrics[i].Step < metrics[j].Step
+		}
+		return metrics[i].Timestamp.Before(metrics[j].Timestamp)
+	})
+
+	return metrics, nil
+}
+
+// MetricHistoryRequest selects the history of a metric of a run
+type MetricHistoryRequest struct {
+	RunID string
+	Key   string
+}
+
+// MetricHistoryResult is the history fetched for a MetricHistoryRequest
+type MetricHistoryResult struct {
+	Metrics []models.Metric
+	Err     error
+}
+
+// GetMetricHistories fetches metric histories with up to parallelism
+// concurrent requests, all subject to the client's rate limit. If progress is
+// not nil, it is called with the number of finished requests after each one.
+// A failed request does not stop the others; results are in request order.
+func (c *Client) GetMetricHistories(ctx context.Context, requests []MetricHistoryRequest, parallelism int, progress func(done, total int)) []MetricHistoryResult {
+	results := make([]MetricHistoryResult, len(requests))
+	runParallel(len(requests), parallelism, progress, func(i int) {
+		metrics, err := c.GetMetricHistory(ctx, requests[i].RunID, requests[i].Key)
+		results[i] = MetricHistoryResult{Metrics: metrics, Err: err}
+	})
+	return results
+}
+
+// validateMetricKeys checks every metric key against the naming policy
+func (c *Client) validateMetricKeys(metrics []models.Metric) error {
+	for _, metric := range metrics {
+		if err := c.config.MetricNaming.ValidateMetricKey(metric.Key); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,480 @@
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/databricks-sdk-go/service/ml"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// ModelVersionOptions describes a model version to create
+type ModelVersionOptions struct {
+	Name        string
+	Source      string
+	RunID       string
+	Description string
+	Tags        map[string]string
+}
+
+// CreateRegisteredModel creates a registered model with tags in key order. If
+// the model already exists, it returns false, or an error unless existOK.
+func (c *Client) CreateRegisteredModel(ctx context.Context, name, description string, tags map[string]string, existOK bool) (bool, error) {
+	modelTags := make([]ml.ModelTag, 0, len(tags))
+	for _, key := range sortedKeys(tags) {
+		modelTags = append(modelTags, ml.ModelTag{Key: key, Value: tags[key]})
+	}
+
+	_, err := c.client.ModelRegistry.CreateModel(ctx, ml.CreateModelRequest{
+		Name:        name,
+		Description: description,
+		Tags:        modelTags,
+	})
+	if err != nil {
+		if existOK && errors.Is(err, apierr.ErrResourceAlreadyExists) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create registered model %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// CreateModelVersion creates a version of a registered model from the model
+// files at a source URI, with tags in key order
+func (c *Client) CreateModelVersion(ctx context.Context, opts ModelVersionOptions) (*models.ModelVersion, error) {
+	versionTags := make([]ml.ModelVersionTag, 0, len(opts.Tags))
+	for _, key := range sortedKeys(opts.Tags) {
+		versionTags = append(versionTags, ml.ModelVersionTag{Key: key, Value: opts.Tags[key]})
+	}
+
+	resp, err := c.client.ModelRegistry.CreateModelVersion(ctx, ml.CreateModelVersionRequest{
+		Name:        opts.Name,
+		Source:      opts.Source,
+		RunId:       opts.RunID,
+		Description: opts.Description,
+		Tags:        versionTags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create version of model %s: %w", opts.Name, err)
+	}
+	if resp.ModelVersion == nil {
+		return nil, fmt.Errorf("failed to create version of model %s: empty response", opts.Name)
+	}
+
+	return convertModelVersion(resp.ModelVersion), nil
+}
+
+// GetModelVersion returns a registered model version
+func (c *Client) GetModelVersion(ctx context.Context, name, version string) (*models.ModelVersion, error) {
+	resp, err := c.client.ModelRegistry.GetModelVersion(ctx, ml.GetModelVersionRequest{
+		Name:    name,
+		Version: version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get model version %s/%s: %w", name, version, err)
+	}
+	if resp.ModelVersion == nil {
+		return nil, fmt.Errorf("model version %s/%s not found", name, version)
+	}
+
+	return convertModelVersion(resp.ModelVersion), nil
+}
+
+// GetModelVersionByAlias returns the model version an alias points to
+func (c *Client) GetModelVersionByAlias(ctx context.Context, name, alias string) (*models.ModelVersion, error) {
+	// Aliases are not covered by the SDK's model registry API
+	var resp struct {
+		ModelVersion *ml.ModelVersion `json:"model_version"`
+	}
+	err := c.callAPI(ctx, "GET", "/api/2.0/mlflow/registered-models/alias", map[string]any{
+		"name":  name,
+		"alias": alias,
+	}, nil, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get model %s@%s: %w", name, alias, err)
+	}
+	if resp.ModelVersion == nil {
+		return nil, fmt.Errorf("model %s has no alias %s", name, alias)
+	}
+
+	return convertModelVersion(resp.ModelVersion), nil
+}
+
+// SearchModelVersions returns all versions of a registered model
+func (c *Client) SearchModelVersions(ctx context.Context, name string) ([]*models.ModelVersion, error) {
+	versions, err := c.client.ModelRegistry.SearchModelVersionsAll(ctx, ml.SearchModelVersionsRequest{
+		Filter: modelNameFilter(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search versions of model %s: %w", name, err)
+	}
+
+	result := make([]*models.ModelVersion, 0, len(versions))
+	for i := range versions {
+		result = append(result, convertModelVersion(&versions[i]))
+	}
+	return result, nil
+}
+
+// registryPageSize is the number of models or versions requested per page of
+// registry searches, the maximum for registered models
+const registryPageSize = 1000
+
+// modelAlias is an alias in responses of the registry API
+type modelAlias struct {
+	Alias   string `json:"alias"`
+	Version string `json:"version"`
+}
+
+// SearchRegisteredModels returns the registered models matching filter,
+// following pagination and stopping after max models (0 for no limit)
+func (c *Client) SearchRegisteredModels(ctx context.Context, filter string, max int) ([]*models.RegisteredModel, error) {
+	query := map[string]any{"max_results": registryPageSize}
+	if filter != "" {
+		query["filter"] = filter
+	}
+
+	var result []*models.RegisteredModel
+	for {
+		// Aliases are not covered by the SDK's model registry API
+		var resp struct {
+			RegisteredModels []json.RawMessage `json:"registered_models"`
+			NextPageToken    string            `json:"next_page_token"`
+		}
+		if err := c.callAPI(ctx, "GET", "/api/2.0/mlflow/registered-models/search", query, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed to search registered models: %w", err)
+		}
+		for _, data := range resp.RegisteredModels {
+			model, err := convertRegisteredModel(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search registered models: %w", err)
+			}
+			result = append(result, model)
+			if max > 0 && len(result) >= max {
+				return result, nil
+			}
+		}
+		if resp.NextPageToken == "" {
+			return result, nil
+		}
+		query["page_token"] = resp.NextPageToken
+	}
+}
+
+// ListModelVersions returns the versions of a registered model matching
+// filter, with their aliases, following pagination and stopping after max
+// versions (0 for no limit). Versions of Unity Catalog models cannot be
+// filtered.
+func (c *Client) ListModelVersions(ctx context.Context, name, filter string, max int) ([]*models.ModelVersion, error) {
+	if c.IsUnityCatalogModel(name) {
+		if filter != "" {
+			return nil, fmt.Errorf("versions of Unity Catalog model %s cannot be filtered", name)
+		}
+		return c.listCatalogModelVersions(ctx, name, max)
+	}
+
+	conditions := modelNameFilter(name)
+	if filter != "" {
+		conditions += " AND " + filter
+	}
+	query := map[string]any{
+		"filter":      conditions,
+		"max_results": registryPageSize,
+	}
+
+	var result []*models.ModelVersion
+	for {
+		// Aliases are not covered by the SDK's model registry API
+		var resp struct {
+			ModelVersions []json.RawMessage `json:"model_versions"`
+			NextPageToken string            `json:"next_page_token"`
+		}
+		if err := c.callAPI(ctx, "GET", "/api/2.0/mlflow/model-versions/search", query, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed to search versions of model %s: %w", name, err)
+		}
+		for _, data := range resp.ModelVersions {
+			var version ml.ModelVersion
+			var aliases struct {
+				Aliases []string `json:"aliases"`
+			}
+			if err := json.Unmarshal(data, &version); err != nil {
+				return nil, fmt.Errorf("failed to search versions of model %s: %w", name, err)
+			}
+			if err := json.Unmarshal(data, &aliases); err != nil {
+				return nil, fmt.Errorf("failed to search versions of model %s: %w", name, err)
+			}
+			modelVersion := convertModelVersion(&version)
+			modelVersion.Aliases = aliases.Aliases
+			result = append(result, modelVersion)
+			if max > 0 && len(result) >= max {
+				return result, nil
+			}
+		}
+		if resp.NextPageToken == "" {
+			return result, nil
+		}
+		query["page_token"] = resp.NextPageToken
+	}
+}
+
+// listCatalogModelVersions returns the versions of a Unity Catalog model,
+// stopping after max versions (0 for no limit)
+func (c *Client) listCatalogModelVersions(ctx context.Context, name string, max int) ([]*models.ModelVersion, error) {
+	var result []*models.ModelVersion
+	versions := c.client.ModelVersions.List(ctx, catalog.ListModelVersionsRequest{FullName: name})
+	for versions.HasNext(ctx) {
+		version, err := versions.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list versions of model %s: %w", name, err)
+		}
+
+		aliases := make([]string, 0, len(version.Aliases))
+		for _, alias := range version.Aliases {
+			aliases = append(aliases, alias.AliasName)
+		}
+		result = append(result, &models.ModelVersion{
+			Name:        name,
+			Version:     strconv.Itoa(version.Version),
+			Status:      models.ModelVersionStatus(version.Status),
+			Description: version.Comment,
+			Source:      version.Source,
+			RunID:       version.RunId,
+			Aliases:     aliases,
+			CreatedAt:   time.UnixMilli(version.CreatedAt),
+			UpdatedAt:   time.UnixMilli(version.UpdatedAt),
+		})
+		if max > 0 && len(result) >= max {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetModelAliases returns the versions the aliases of a registered model point
+// to, by alias. Registries without alias support return none.
+func (c *Client) GetModelAliases(ctx context.Context, name string) (map[string]string, error) {
+	// Aliases are not covered by the SDK's model registry API
+	var resp struct {
+		RegisteredModel *struct {
+			Aliases []modelAlias `json:"aliases"`
+		} `json:"registered_model"`
+	}
+	err := c.callAPI(ctx, "GET", "/api/2.0/mlflow/registered-models/get", map[string]any{
+		"name": name,
+	}, nil, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get model %s: %w", name, err)
+	}
+	if resp.RegisteredModel == nil {
+		return nil, fmt.Errorf("model %s not found", name)
+	}
+
+	aliases := make(map[string]string, len(resp.RegisteredModel.Aliases))
+	for _, alias := range resp.RegisteredModel.Aliases {
+		aliases[alias.Alias] = alias.Version
+	}
+	return aliases, nil
+}
+
+// IsUnityCatalogModel reports whether a registered model of a Databricks
+// tracking server is in Unity Catalog, which it is if its name has the three
+// levels catalog.schema.model
+func (c *Client) IsUnityCatalogModel(name string) bool {
+	return c.config.IsDatabricks() && strings.Count(name, ".") == 2
+}
+
+// TransitionModelVersionStage moves a model version to a stage of the
+// workspace model registry. With archiveExisting, the versions in the stage
+// before are archived. Unity Catalog models have no stages.
+func (c *Client) TransitionModelVersionStage(ctx context.Context, name, version, stage string, archiveExisting bool) (*models.ModelVersion, error) {
+	if c.IsUnityCatalogModel(name) {
+		return nil, fmt.Errorf("model %s is in Unity Catalog, which has no stages: use aliases instead", name)
+	}
+
+	// The SDK only covers the transition requests of Databricks' approval
+	// workflow, not the MLflow endpoint
+	var resp struct {
+		ModelVersion *ml.ModelVersion `json:"model_version"`
+	}
+	err := c.callAPI(ctx, "POST", "/api/2.0/mlflow/model-versions/transition-stage", nil, map[string]any{
+		"name":                      name,
+		"version":                   version,
+		"stage":                     stage,
+		"archive_existing_versions": archiveExisting,
+	}, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transition model %s version %s to %s: %w", name, version, stage, err)
+	}
+	if resp.ModelVersion == nil {
+		return nil, fmt.Errorf("failed to transition model %s version %s to %s: empty response", name, version, stage)
+	}
+
+	return convertModelVersion(resp.ModelVersion), nil
+}
+
+// SetModelAlias points an alias of a registered model at a version, moving
+// it from the version it pointed at before
+func (c *Client) SetModelAlias(ctx context.Context, name, alias, version string) error {
+	if c.IsUnityCatalogModel(name) {
+		versionNum, err := strconv.Atoi(version)
+		if err != nil {
+			return fmt.Errorf("invalid version of Unity Catalog model %s: %s", name, version)
+		}
+		_, err = c.client.RegisteredModels.SetAlias(ctx, catalog.SetRegisteredModelAliasRequest{
+			FullName:   name,
+			Alias:      alias,
+			VersionNum: versionNum,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set alias %s of model %s: %w", alias, name, err)
+		}
+		return nil
+	}
+
+	// Aliases are not covered by the SDK's model registry API
+	err := c.callAPI(ctx, "POST", "/api/2.0/mlflow/registered-models/alias", nil, map[string]any{
+		"name":    name,
+		"alias":   alias,
+		"version": version,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to set alias %s of model %s: %w", alias, name, err)
+	}
+	return nil
+}
+
+// DeleteModelAlias removes an alias of a registered model
+func (c *Client) DeleteModelAlias(ctx context.Context, name, alias string) error {
+	var err error
+	if c.IsUnityCatalogModel(name) {
+		err = c.client.RegisteredModels.DeleteAlias(ctx, catalog.DeleteAliasRequest{
+			FullName: name,
+			Alias:    alias,
+		})
+	} else {
+		err = c.callAPI(ctx, "DELETE", "/api/2.0/mlflow/registered-models/alias", map[string]any{
+			"name":  name,
+			"alias": alias,
+		}, nil, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete alias %s of model %s: %w", alias, name, err)
+	}
+	return nil
+}
+
+// ErrAwaitTimeout is returned by AwaitModelVersion for versions still pending
+// after the timeout
+var ErrAwaitTimeout = errors.New("timed out")
+
+// AwaitModelVersion polls a model version until its registration is no longer
+// pending and returns the final version. It fails with ErrAwaitTimeout when
+// the version is still pending after timeout.
+func (c *Client) AwaitModelVersion(ctx context.Context, name, version string, timeout, pollInterval time.Duration) (*models.ModelVersion, error) {
+	// The deadline is kept separate from ctx so that an in-flight request is not
+	// cut short by it
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		modelVersion, err := c.GetModelVersion(ctx, name, version)
+		if err != nil {
+			return nil, err
+		}
+		if modelVersion.Status != models.ModelVersionStatusPending {
+			return modelVersion, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return nil, fmt.Errorf("%w after %s waiting for model %s version %s (status: %s)", ErrAwaitTimeout, timeout, name, version, modelVersion.Status)
+		case <-ticker.C:
+		}
+	}
+}
+
+// convertModelVersion converts an SDK model version to the CLI model
+func convertModelVersion(mv *ml.ModelVersion) *models.ModelVersion {
+	tags := make(map[string]string)
+	for _, tag := range mv.Tags {
+		tags[tag.Key] = tag.Value
+	}
+
+	return &models.ModelVersion{
+		Name:          mv.Name,
+		Version:       mv.Version,
+		Status:        models.ModelVersionStatus(mv.Status),
+		StatusMessage: mv.StatusMessage,
+		CurrentStage:  mv.CurrentStage,
+		Description:   mv.Description,
+		Source:        mv.Source,
+		RunID:         mv.RunId,
+		Tags:          tags,
+		CreatedAt:     time.UnixMilli(mv.CreationTimestamp),
+		UpdatedAt:     time.UnixMilli(mv.LastUpdatedTimestamp),
+	}
+}
+
+// convertRegisteredModel converts a registered model of a registry API
+// response, including its aliases, to the CLI model
+func convertRegisteredModel(data json.RawMessage) (*models.RegisteredModel, error) {
+	var model ml.Model
+	var aliases struct {
+		Aliases []modelAlias `json:"aliases"`
+	}
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, err
+	}
+
+	result := &models.RegisteredModel{
+		Name:           model.Name,
+		Description:    model.Description,
+		Tags:           make(map[string]string),
+		Aliases:        make(map[string]string),
+		LatestVersions: make(map[string]string),
+		CreatedAt:      time.UnixMilli(model.CreationTimestamp),
+		UpdatedAt:      time.UnixMilli(model.LastUpdatedTimestamp),
+	}
+	for _, tag := range model.Tags {
+		result.Tags[tag.Key] = tag.Value
+	}
+	for _, alias := range aliases.Aliases {
+		result.Aliases[alias.Alias] = alias.Version
+	}
+	for _, version := range model.LatestVersions {
+		result.LatestVersions[version.CurrentStage] = version.Version
+	}
+	return result, nil
+}
+
+// modelNameFilter returns the search filter selecting the versions of a
+// registered model
+func modelNameFilter(name string) string {
+	return fmt.Sprintf("name='%s'", strings.ReplaceAll(name, "'", "\\'"))
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
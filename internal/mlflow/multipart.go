@@ -0,0 +1,157 @@
+package mlflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+
+	"github.com/imishinist/mlflow-cli/internal/httperr"
+)
+
+// MultipartURL is the upload URL of one part of a multipart upload
+type MultipartURL struct {
+	PartNumber int               `json:"part_number"`
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers,omitempty"`
+}
+
+// createMultipartUploadRequest is the request of the mpu/create endpoint of
+// the MLflow Artifacts Service
+type createMultipartUploadRequest struct {
+	Path     string `json:"path"`
+	NumParts int    `json:"num_parts"`
+}
+
+// createMultipartUploadResponse is the response of the mpu/create endpoint
+type createMultipartUploadResponse struct {
+	UploadID    string         `json:"upload_id"`
+	Credentials []MultipartURL `json:"credentials"`
+}
+
+// completeMultipartUploadRequest is the request of the mpu/complete endpoint
+type completeMultipartUploadRequest struct {
+	Path     string          `json:"path"`
+	UploadID string          `json:"upload_id"`
+	Parts    []completedPart `json:"parts"`
+}
+
+// completedPart is an uploaded part of a multipart upload
+type completedPart struct {
+	PartNumber int    `json:"part_number"`
+	ETag       string `json:"etag"`
+	URL        string `json:"url"`
+}
+
+// errMultipartUnsupported means the artifact store does not support
+// multipart uploads and the file must be uploaded in one request
+var errMultipartUnsupported = errors.New("multipart uploads are not supported by the artifact store")
+
+// uploadMultipart uploads a file to the MLflow Artifacts Service in parts of
+// the journal's part size, recording every completed part in the journal. A
+// multipart upload left unfinished by an earlier attempt is continued after
+// its last completed part.
+func (c *Client) uploadMultipart(ctx context.Context, artifactURI string, upload ArtifactUpload, file *os.File, info os.FileInfo, journal *UploadJournal) error {
+	endpoint, err := c.multipartEndpoint(artifactURI, upload.ArtifactPath)
+	if err != nil {
+		return err
+	}
+	name := path.Base(upload.ArtifactPath)
+
+	state := journal.multipart(upload)
+	if state == nil {
+		numParts := int((info.Size() + journal.PartSize - 1) / journal.PartSize)
+		var response createMultipartUploadResponse
+		err := c.callAPI(ctx, "POST", "/api/2.0/mlflow-artifacts/mpu/create/"+endpoint, nil,
+			createMultipartUploadRequest{Path: name, NumParts: numParts}, &response)
+		if err != nil {
+			var apiErr *apierr.APIError
+			if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusNotImplemented) {
+				return errMultipartUnsupported
+			}
+			return fmt.Errorf("failed to create multipart upload: %w", err)
+		}
+		if len(response.Credentials) != numParts {
+			return fmt.Errorf("failed to create multipart upload: expected %d part URLs, got %d", numParts, len(response.Credentials))
+		}
+
+		state = &MultipartState{UploadID: response.UploadID, URLs: response.Credentials, ETags: make(map[int]string)}
+		if err := journal.startMultipart(upload, info, state); err != nil {
+			return err
+		}
+	}
+
+	parts := make([]completedPart, 0, len(state.URLs))
+	for i, part := range state.URLs {
+		etag, done := state.ETags[part.PartNumber]
+		if !done {
+			offset := int64(i) * journal.PartSize
+			size := min(journal.PartSize, info.Size()-offset)
+			etag, err = c.uploadPart(ctx, part, io.NewSectionReader(file, offset, size), size)
+			if err != nil {
+				return fmt.Errorf("failed to upload part %d of %d: %w", part.PartNumber, len(state.URLs), err)
+			}
+			if err := journal.completePart(upload, part.PartNumber, etag); err != nil {
+				return err
+			}
+		}
+		parts = append(parts, completedPart{PartNumber: part.PartNumber, ETag: etag, URL: part.URL})
+	}
+
+	err = c.callAPI(ctx, "POST", "/api/2.0/mlflow-artifacts/mpu/complete/"+endpoint, nil,
+		completeMultipartUploadRequest{Path: name, UploadID: state.UploadID, Parts: parts}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return journal.complete(upload, info)
+}
+
+// uploadPart uploads one part, bounded by the transfer timeout and the
+// bandwidth limit, and returns its ETag
+func (c *Client) uploadPart(ctx context.Context, part MultipartURL, body io.Reader, size int64) (string, error) {
+	ctx, cancel := c.transferContext(ctx)
+	defer cancel()
+	body = c.limitReader(ctx, body)
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", part.URL, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = size
+	for name, value := range part.Headers {
+		req.Header.Set(name, value)
+	}
+	replayable(req, body)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", c.transferError(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	if !c.isSuccessStatusCode(resp.StatusCode) {
+		return "", fmt.Errorf("part upload failed with status %d: %s", resp.StatusCode, httperr.ReadMessage(resp.Body))
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// multipartEndpoint returns the path of the directory of an artifact relative
+// to the MLflow Artifacts Service root, as expected by the mpu endpoints
+func (c *Client) multipartEndpoint(artifactURI, artifactPath string) (string, error) {
+	experimentID, runID, err := c.extractIDsFromArtifactURI(artifactURI)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract IDs from artifact URI: %w", err)
+	}
+
+	endpoint := path.Join(experimentID, runID, "artifacts")
+	if dir := path.Dir(artifactPath); dir != "." {
+		endpoint = path.Join(endpoint, dir)
+	}
+	return strings.TrimPrefix(endpoint, "/"), nil
+}
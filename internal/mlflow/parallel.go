@@ -0,0 +1,40 @@
+package mlflow
+
+import "sync"
+
+// runParallel calls fn for the indexes 0..n-1 from at most parallelism
+// goroutines and reports progress after each call
+func runParallel(n, parallelism int, progress func(done, total int), fn func(i int)) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var (
+		mu   sync.Mutex
+		done int
+		wg   sync.WaitGroup
+	)
+	indexes := make(chan int)
+	for i := 0; i < min(parallelism, n); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				fn(index)
+
+				if progress != nil {
+					mu.Lock()
+					done++
+					progress(done, n)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for index := 0; index < n; index++ {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,90 @@
+package mlflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Server limits for params (MLflow 2.x; older servers allow 500 characters)
+const (
+	MaxParamKeyLength   = 250
+	MaxParamValueLength = 6000
+)
+
+// Policies for param values longer than the server limit
+const (
+	ParamTooLongError    = "error"
+	ParamTooLongTruncate = "truncate"
+	ParamTooLongHash     = "hash"
+)
+
+// hashedParamPrefix marks values replaced by their digest
+const hashedParamPrefix = "sha256:"
+
+// ParamLengthPolicy decides how param values longer than MaxLength
+// characters are handled before anything is logged
+type ParamLengthPolicy struct {
+	OnTooLong string // error, truncate or hash
+	MaxLength int
+}
+
+// Validate checks the policy settings
+func (p ParamLengthPolicy) Validate() error {
+	switch p.OnTooLong {
+	case ParamTooLongError, ParamTooLongTruncate, ParamTooLongHash:
+	default:
+		return fmt.Errorf("invalid --on-too-long value: %s (valid: error, truncate, hash)", p.OnTooLong)
+	}
+	if p.MaxLength < 1 {
+		return fmt.Errorf("max param length must be at least 1")
+	}
+	if p.OnTooLong == ParamTooLongHash && p.MaxLength < len(hashedParamPrefix)+sha256.Size*2 {
+		return fmt.Errorf("max param length must be at least %d to hash long values", len(hashedParamPrefix)+sha256.Size*2)
+	}
+	return nil
+}
+
+// Apply checks every param against the server limits, so that an oversized
+// value fails before the first request instead of in the middle of a batch.
+// Values longer than MaxLength are rejected, truncated to MaxLength
+// characters, or replaced by sha256:<hex digest> of the full value. Apply
+// returns the params to log and the keys of changed values in key order.
+func (p ParamLengthPolicy) Apply(params map[string]string) (map[string]string, []string, error) {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make(map[string]string, len(params))
+	var changed []string
+	for _, key := range keys {
+		value := params[key]
+		if utf8.RuneCountInString(key) > MaxParamKeyLength {
+			return nil, nil, fmt.Errorf("param key %.40s... is longer than %d characters", key, MaxParamKeyLength)
+		}
+
+		length := utf8.RuneCountInString(value)
+		if length <= p.MaxLength {
+			result[key] = value
+			continue
+		}
+
+		switch p.OnTooLong {
+		case ParamTooLongTruncate:
+			value = string([]rune(value)[:p.MaxLength])
+		case ParamTooLongHash:
+			sum := sha256.Sum256([]byte(value))
+			value = hashedParamPrefix + hex.EncodeToString(sum[:])
+		default:
+			return nil, nil, fmt.Errorf("value of param %s has %d characters, more than the limit of %d (use --on-too-long truncate or hash)", key, length, p.MaxLength)
+		}
+		result[key] = value
+		changed = append(changed, key)
+	}
+
+	return result, changed, nil
+}
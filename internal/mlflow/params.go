@@ -3,6 +3,7 @@ package mlflow
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/databricks/databricks-sdk-go/service/ml"
 
@@ -19,12 +20,20 @@ func (c *Client) LogParam(ctx context.Context, runID string, key string, value s
 		return fmt.Errorf("failed to log parameter %s: %w", key, err)
 	}
 
+	c.forwardParams(ctx, runID, []models.Parameter{{Key: key, Value: value}})
 	return nil
 }
 
+// MaxParamsPerBatch is the maximum number of params in a single log-batch request
+const MaxParamsPerBatch = 100
+
+// LogParams logs params using the log-batch API, split into chunks of
+// MaxParamsPerBatch. A chunk rejected by the server is retried param by param,
+// so that the failing key is reported.
 func (c *Client) LogParams(ctx context.Context, runID string, params []models.Parameter) error {
-	for _, param := range params {
-		if err := c.LogParam(ctx, runID, param.Key, param.Value); err != nil {
+	for start := 0; start < len(params); start += MaxParamsPerBatch {
+		end := min(start+MaxParamsPerBatch, len(params))
+		if err := c.logParamsChunk(ctx, runID, params[start:end]); err != nil {
 			return err
 		}
 	}
@@ -32,12 +41,50 @@ func (c *Client) LogParams(ctx context.Context, runID string, params []models.Pa
 	return nil
 }
 
+// LogParamsFromMap logs params in key order using the log-batch API
 func (c *Client) LogParamsFromMap(ctx context.Context, runID string, params map[string]string) error {
-	for key, value := range params {
-		if err := c.LogParam(ctx, runID, key, value); err != nil {
-			return err
-		}
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
+	list := make([]models.Parameter, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, models.Parameter{Key: key, Value: params[key]})
+	}
+	return c.LogParams(ctx, runID, list)
+}
+
+// logParamsChunk logs up to MaxParamsPerBatch params in one request, falling
+// back to single requests if the server rejected the batch
+func (c *Client) logParamsChunk(ctx context.Context, runID string, params []models.Parameter) error {
+	batch := make([]ml.Param, 0, len(params))
+	for _, param := range params {
+		batch = append(batch, ml.Param{
+			Key:   param.Key,
+			Value: param.Value,
+			// Empty values are valid params and must not be omitted
+			ForceSendFields: []string{"Value"},
+		})
+	}
+
+	batchErr := c.client.Experiments.LogBatch(ctx, ml.LogBatch{
+		RunId:  runID,
+		Params: batch,
+	})
+	if batchErr == nil {
+		c.forwardParams(ctx, runID, params)
+		return nil
+	}
+	if !batchRejected(batchErr) {
+		return fmt.Errorf("failed to log params batch: %w", batchErr)
+	}
+
+	for _, param := range params {
+		if err := c.LogParam(ctx, runID, param.Key, param.Value); err != nil {
+			return fmt.Errorf("failed to log params batch (%v), and fallback failed: %w", batchErr, err)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,270 @@
+package mlflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// DryRunID is the ID of runs created during a dry run
+const DryRunID = "dry-run"
+
+// maxPlannedPayload is the largest request body included in a plan
+const maxPlannedPayload = 1024 * 1024
+
+// PlannedRequest is a request that a dry run recorded instead of sending
+type PlannedRequest struct {
+	Method       string          `json:"method"`
+	URL          string          `json:"url"`
+	PayloadBytes int64           `json:"payload_bytes"`
+	Payload      json.RawMessage `json:"payload,omitempty"`
+}
+
+// Plan collects the requests that a dry run would have sent
+type Plan struct {
+	mu       sync.Mutex
+	requests []PlannedRequest
+}
+
+// dryRunPlan is shared by all clients of the process, so that commands using
+// several clients produce a single plan
+var dryRunPlan = &Plan{}
+
+// DryRunPlan returns the plan recorded by dry-run clients
+func DryRunPlan() *Plan {
+	return dryRunPlan
+}
+
+// Requests returns the recorded requests in the order they were made
+func (p *Plan) Requests() []PlannedRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedRequest{}, p.requests...)
+}
+
+func (p *Plan) add(request PlannedRequest) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, request)
+}
+
+// recordUpload records an upload that does not go through HTTP
+func (p *Plan) recordUpload(target string, body io.Reader) error {
+	size, err := io.Copy(io.Discard, body)
+	if err != nil {
+		return err
+	}
+	p.add(PlannedRequest{Method: "PUT", URL: target, PayloadBytes: size})
+	return nil
+}
+
+// dryRunTransport passes read-only requests, including the searches sent as
+// POST requests, through and records all other requests in a plan, answering
+// them with a minimal successful response
+type dryRunTransport struct {
+	next http.RoundTripper
+	plan *Plan
+
+	mu           sync.Mutex
+	experimentID string
+}
+
+func newDryRunTransport(next http.RoundTripper, plan *Plan) *dryRunTransport {
+	return &dryRunTransport{next: next, plan: plan, experimentID: "0"}
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	readOnly := readOnlyRequest(req)
+	if readOnly && req.Method == http.MethodPost {
+		return t.next.RoundTrip(req)
+	}
+	if readOnly && req.URL.Query().Get("run_id") != DryRunID {
+		return t.next.RoundTrip(req)
+	}
+
+	if readOnly {
+		// Runs created by the dry run do not exist on the server
+		if strings.HasSuffix(req.URL.Path, "/runs/get") {
+			return t.respond(req, t.dryRunRun())
+		}
+		return t.respond(req, "{}")
+	}
+
+	planned, err := plannedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	t.plan.add(planned)
+
+	if strings.HasSuffix(req.URL.Path, "/runs/create") {
+		var create struct {
+			ExperimentID string `json:"experiment_id"`
+		}
+		if json.Unmarshal(planned.Payload, &create) == nil && create.ExperimentID != "" {
+			t.mu.Lock()
+			t.experimentID = create.ExperimentID
+			t.mu.Unlock()
+		}
+		return t.respond(req, t.dryRunRun())
+	}
+	if strings.HasSuffix(req.URL.Path, "/experiments/create") {
+		return t.respond(req, `{"experiment_id":"`+DryRunID+`"}`)
+	}
+	if strings.HasSuffix(req.URL.Path, "/mlflow/logged-models") {
+		return t.respond(req, dryRunLoggedModel(planned.Payload))
+	}
+	if strings.HasSuffix(req.URL.Path, "/model-versions/create") {
+		return t.respond(req, dryRunModelVersion(planned.Payload))
+	}
+	if strings.HasSuffix(req.URL.Path, "/model-versions/transition-stage") {
+		return t.respond(req, dryRunTransitionedVersion(planned.Payload))
+	}
+	return t.respond(req, "{}")
+}
+
+// dryRunModelVersion returns the model version returned for versions created
+// during the dry run. It is ready, as there is nothing to wait for.
+func dryRunModelVersion(payload json.RawMessage) string {
+	var create struct {
+		Name   string `json:"name"`
+		Source string `json:"source"`
+		RunID  string `json:"run_id"`
+	}
+	json.Unmarshal(payload, &create)
+	modelVersion := map[string]any{
+		"model_version": map[string]any{
+			"name":    create.Name,
+			"version": DryRunID,
+			"source":  create.Source,
+			"run_id":  create.RunID,
+			"status":  "READY",
+		},
+	}
+	data, _ := json.Marshal(modelVersion)
+	return string(data)
+}
+
+// dryRunTransitionedVersion returns the model version returned for stage
+// transitions during the dry run
+func dryRunTransitionedVersion(payload json.RawMessage) string {
+	var transition struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		Stage   string `json:"stage"`
+	}
+	json.Unmarshal(payload, &transition)
+	modelVersion := map[string]any{
+		"model_version": map[string]any{
+			"name":          transition.Name,
+			"version":       transition.Version,
+			"current_stage": transition.Stage,
+			"status":        "READY",
+		},
+	}
+	data, _ := json.Marshal(modelVersion)
+	return string(data)
+}
+
+// dryRunLoggedModel returns the logged model returned for models created
+// during the dry run
+func dryRunLoggedModel(payload json.RawMessage) string {
+	var create struct {
+		ExperimentID string `json:"experiment_id"`
+		Name         string `json:"name"`
+	}
+	json.Unmarshal(payload, &create)
+	model := map[string]any{
+		"model": map[string]any{
+			"info": map[string]any{
+				"model_id":      DryRunID,
+				"experiment_id": create.ExperimentID,
+				"name":          create.Name,
+				"status":        "LOGGED_MODEL_PENDING",
+				"artifact_uri":  "mlflow-artifacts:/" + create.ExperimentID + "/models/" + DryRunID + "/artifacts",
+			},
+		},
+	}
+	data, _ := json.Marshal(model)
+	return string(data)
+}
+
+// dryRunRun returns the run returned for runs created during the dry run
+func (t *dryRunTransport) dryRunRun() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	run := map[string]any{
+		"run": map[string]any{
+			"info": map[string]any{
+				"run_id":        DryRunID,
+				"experiment_id": t.experimentID,
+				"status":        "RUNNING",
+				"artifact_uri":  "mlflow-artifacts:/" + t.experimentID + "/" + DryRunID + "/artifacts",
+			},
+		},
+	}
+	data, _ := json.Marshal(run)
+	return string(data)
+}
+
+// respond builds a successful JSON response
+func (t *dryRunTransport) respond(req *http.Request, body string) (*http.Response, error) {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// plannedRequest describes a request without sending it. Artifact content is
+// only measured; JSON API payloads are included in the plan.
+func plannedRequest(req *http.Request) (PlannedRequest, error) {
+	planned := PlannedRequest{
+		Method: req.Method,
+		URL:    redactURL(req.URL),
+	}
+	if req.Body == nil {
+		return planned, nil
+	}
+	defer req.Body.Close()
+
+	if req.Header.Get("Content-Type") == "application/octet-stream" && req.ContentLength >= 0 {
+		planned.PayloadBytes = req.ContentLength
+		return planned, nil
+	}
+
+	var head bytes.Buffer
+	size, err := io.Copy(&head, io.LimitReader(req.Body, maxPlannedPayload+1))
+	if err != nil {
+		return planned, err
+	}
+	rest, err := io.Copy(io.Discard, req.Body)
+	if err != nil {
+		return planned, err
+	}
+	planned.PayloadBytes = size + rest
+
+	if planned.PayloadBytes <= maxPlannedPayload && json.Valid(head.Bytes()) {
+		planned.Payload = json.RawMessage(head.Bytes())
+	}
+	return planned, nil
+}
+
+// redactURL drops the query of signed URLs, which carries credentials
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	if strings.Contains(redacted.RawQuery, "Signature") || strings.Contains(redacted.RawQuery, "sig=") {
+		redacted.RawQuery = ""
+	}
+	return redacted.String()
+}
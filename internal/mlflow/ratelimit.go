@@ -0,0 +1,40 @@
+package mlflow
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// defaultRateLimit is the API requests per second of clients without a
+// configured rate limit, the default of the Databricks SDK
+const defaultRateLimit = 15
+
+// rateLimitTransport delays every attempt of an API call to the rate limit
+// shared by all concurrent requests of a client, and records the time waited
+// in the retry summary. Artifact transfers are not limited. It replaces the
+// SDK's limiter, which is not shared with the requests sent outside the SDK
+// and whose waits cannot be measured.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func newRateLimitTransport(next http.RoundTripper, requestsPerSecond int) *rateLimitTransport {
+	if requestsPerSecond <= 0 {
+		requestsPerSecond = defaultRateLimit
+	}
+	return &rateLimitTransport{next: next, limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), 1)}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Context().Value(transferKey{}) == nil {
+		start := time.Now()
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		telemetry.throttled(time.Since(start))
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,196 @@
+package mlflow
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+)
+
+// Backoff between attempts: exponential from retryBaseWait up to retryMaxWait,
+// with jitter. Retry-After of the server is honored up to retryMaxWait.
+const (
+	retryBaseWait = 500 * time.Millisecond
+	retryMaxWait  = 20 * time.Second
+)
+
+// noRetryKey marks the contexts of requests that their caller retries itself
+type noRetryKey struct{}
+
+// retryTransport retries requests that failed with a connection error, were
+// rate limited (429) or hit an unavailable server (502, 503, 504), up to
+// maxRetries times. It retries the requests of the SDK and the artifact
+// requests sent outside of it alike. Requests that are not idempotent, such as
+// logging metrics, are only retried if they cannot have been applied: the
+// connection could not be established, or the server refused them with 429 or
+// 503. Requests whose body cannot be sent again or whose context is marked
+// with noRetryKey are attempted once.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int) *retryTransport {
+	return &retryTransport{next: next, maxRetries: maxRetries}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	canReplay := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	if req.Context().Value(noRetryKey{}) != nil {
+		canReplay = false
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.maxRetries || !canReplay || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait := retryBackoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+				if after > retryMaxWait {
+					// Waiting longer would outlast the SDK's timeouts
+					return resp, err
+				}
+				wait = after
+			}
+			// Failed responses are small; draining them keeps the connection
+			io.CopyN(io.Discard, resp.Body, 4096)
+			resp.Body.Close()
+		}
+
+		if err := waitRetry(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// waitRetry waits for the backoff before a retry and records the retry
+func waitRetry(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return ctx.Err()
+	case <-timer.C:
+	}
+	telemetry.retried(wait)
+	return nil
+}
+
+// retryable reports whether an attempt failed in a way that may succeed later,
+// and that a request that is not idempotent was not applied then
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	idempotent := idempotentRequest(req)
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		if !idempotent {
+			return notSentError(err)
+		}
+		return connectionError(err)
+	}
+	if !idempotent {
+		return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+	}
+	return retryableStatus(resp.StatusCode)
+}
+
+// notSentError reports whether a request failed before it reached the server:
+// its connection could not be established
+func notSentError(err error) bool {
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.As(err, &dnsErr) ||
+		(errors.As(err, &opErr) && opErr.Op == "dial")
+}
+
+// retryableError reports whether an API call of the SDK failed in a way that
+// the transport would have retried
+func retryableError(ctx context.Context, err error) bool {
+	var apiErr *apierr.APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.StatusCode)
+	}
+	return ctx.Err() == nil && connectionError(err)
+}
+
+// connectionError reports whether err is a dropped, refused or timed out
+// connection
+func connectionError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		(errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// retryableStatus reports whether a status means rate limited or unavailable
+func retryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryBackoff returns the wait before the retry of a failed attempt: the
+// exponential backoff of the attempt, half of it randomized
+func retryBackoff(attempt int) time.Duration {
+	wait := retryMaxWait
+	if attempt < 10 {
+		wait = min(retryBaseWait<<attempt, retryMaxWait)
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// retryAfter parses a Retry-After header, given in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// replayable lets the body of a request be sent again by retries if it can
+// seek back to where it starts. The body is not closed by the transport then;
+// closing it stays with the caller.
+func replayable(req *http.Request, body io.Reader) {
+	seeker, ok := body.(io.ReadSeeker)
+	if !ok || req.GetBody != nil {
+		return
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return
+	}
+	req.Body = io.NopCloser(seeker)
+	req.GetBody = func() (io.ReadCloser, error) {
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return io.NopCloser(seeker), nil
+	}
+}
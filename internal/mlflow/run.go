@@ -3,13 +3,19 @@ package mlflow
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/databricks/databricks-sdk-go/service/ml"
+	"github.com/google/uuid"
 
 	"github.com/imishinist/mlflow-cli/internal/models"
 )
 
+// TagRequestToken identifies the creation of a run, so that a retried creation
+// finds the run instead of creating another one
+const TagRequestToken = "mlflow-cli.requestToken"
+
 func (c *Client) CreateRun(ctx context.Context, config *models.RunConfig) (*models.RunInfo, error) {
 	var experimentID string
 
@@ -46,25 +52,48 @@ func (c *Client) CreateRun(ctx context.Context, config *models.RunConfig) (*mode
 	// Add description as tag if provided
 	if config.Description != nil {
 		tags = append(tags, ml.RunTag{
-			Key:   "mlflow.note.content",
+			Key:   TagNoteContent,
 			Value: *config.Description,
 		})
 	}
 
+	// Add parent run ID as tag for nested runs
+	if config.ParentRunID != nil {
+		tags = append(tags, ml.RunTag{
+			Key:   "mlflow.parentRunId",
+			Value: *config.ParentRunID,
+		})
+	}
+
+	// A run created with a given request token before, e.g. by a retried CI
+	// job, is returned instead of creating another one
+	requestToken := uuid.NewString()
+	if config.RequestToken != nil {
+		requestToken = *config.RequestToken
+		run, err := c.findRunByRequestToken(ctx, experimentID, requestToken)
+		if err != nil || run != nil {
+			return run, err
+		}
+	}
+	tags = append(tags, ml.RunTag{
+		Key:   TagRequestToken,
+		Value: requestToken,
+	})
+
 	// Create run
 	startTime := time.Now()
-	resp, err := c.client.Experiments.CreateRun(ctx, ml.CreateRun{
+	runID, err := c.createRun(ctx, ml.CreateRun{
 		ExperimentId: experimentID,
 		RunName:      runName,
 		StartTime:    startTime.UnixMilli(),
 		Tags:         tags,
-	})
+	}, requestToken)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create run: %w", err)
 	}
 
 	return &models.RunInfo{
-		RunID:        resp.Run.Info.RunId,
+		RunID:        runID,
 		ExperimentID: experimentID,
 		RunName:      runName,
 		Status:       string(models.RunStatusRunning),
@@ -76,9 +105,54 @@ func (c *Client) CreateRun(ctx context.Context, config *models.RunConfig) (*mode
 			}
 			return ""
 		}(),
+		ParentRunID: func() string {
+			if config.ParentRunID != nil {
+				return *config.ParentRunID
+			}
+			return ""
+		}(),
 	}, nil
 }
 
+// createRun sends a CreateRun request and returns the ID of the run. It is
+// retried here instead of in the transport: an attempt that failed may still
+// have created the run, so the run of the request token is searched for
+// before each retry.
+func (c *Client) createRun(ctx context.Context, request ml.CreateRun, requestToken string) (string, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.client.Experiments.CreateRun(context.WithValue(ctx, noRetryKey{}, true), request)
+		if err == nil {
+			return resp.Run.Info.RunId, nil
+		}
+		if attempt >= c.config.MaxRetries || !retryableError(ctx, err) {
+			return "", err
+		}
+		wait := retryBackoff(attempt)
+		if err := waitRetry(ctx, wait); err != nil {
+			return "", err
+		}
+
+		run, err := c.findRunByRequestToken(ctx, request.ExperimentId, requestToken)
+		if err != nil {
+			return "", err
+		}
+		if run != nil {
+			return run.RunID, nil
+		}
+	}
+}
+
+// findRunByRequestToken returns the active run of an experiment created with
+// a request token, or nil if there is none
+func (c *Client) findRunByRequestToken(ctx context.Context, experimentID, requestToken string) (*models.RunInfo, error) {
+	filter := fmt.Sprintf("tags.`%s` = '%s'", TagRequestToken, strings.ReplaceAll(requestToken, "'", "\\'"))
+	runs, err := c.SearchRunsUpTo(ctx, []string{experimentID}, filter, 1)
+	if err != nil || len(runs) == 0 {
+		return nil, err
+	}
+	return runs[0], nil
+}
+
 func (c *Client) UpdateRun(ctx context.Context, runID string, status models.RunStatus) error {
 	// Convert status to MLflow status type
 	var mlStatus ml.UpdateRunStatus
@@ -121,18 +195,127 @@ func (c *Client) GetRun(ctx context.Context, runID string) (*models.RunInfo, err
 		return nil, fmt.Errorf("failed to get run: %w", err)
 	}
 
-	run := resp.Run
+	return convertRun(resp.Run), nil
+}
+
+// GetRuns fetches runs with up to parallelism concurrent requests and returns
+// them in the order of runIDs
+func (c *Client) GetRuns(ctx context.Context, runIDs []string, parallelism int) ([]*models.RunInfo, error) {
+	runs := make([]*models.RunInfo, len(runIDs))
+	errs := make([]error, len(runIDs))
+	runParallel(len(runIDs), parallelism, nil, func(i int) {
+		runs[i], errs[i] = c.GetRun(ctx, runIDs[i])
+	})
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("run %s: %w", runIDs[i], err)
+		}
+	}
+	return runs, nil
+}
+
+// EndRuns sets the status of runs with up to parallelism concurrent requests
+// and returns the error of each run in the order of runIDs, nil for the runs
+// ended
+func (c *Client) EndRuns(ctx context.Context, runIDs []string, status models.RunStatus, parallelism int) []error {
+	errs := make([]error, len(runIDs))
+	runParallel(len(runIDs), parallelism, nil, func(i int) {
+		errs[i] = c.UpdateRun(ctx, runIDs[i], status)
+	})
+	return errs
+}
+
+// RunURL returns the URL of the run page in the tracking server UI
+func (c *Client) RunURL(experimentID, runID string) string {
+	if c.config.IsDatabricks() {
+		host := strings.TrimSuffix(c.client.Config.Host, "/")
+		return fmt.Sprintf("%s/ml/experiments/%s/runs/%s", host, experimentID, runID)
+	}
+	host := strings.TrimSuffix(c.config.TrackingURI, "/")
+	return fmt.Sprintf("%s/#/experiments/%s/runs/%s", host, experimentID, runID)
+}
+
+// SearchRuns returns all active runs of the given experiments matching filter,
+// following pagination
+func (c *Client) SearchRuns(ctx context.Context, experimentIDs []string, filter string) ([]*models.RunInfo, error) {
+	return c.SearchRunsUpTo(ctx, experimentIDs, filter, 0)
+}
+
+// SearchRunsUpTo works like SearchRuns but stops after max runs, so that
+// callers can detect more than max-1 matches without fetching all of them.
+// max <= 0 means no limit.
+func (c *Client) SearchRunsUpTo(ctx context.Context, experimentIDs []string, filter string, max int) ([]*models.RunInfo, error) {
+	iterator := c.client.Experiments.SearchRuns(ctx, ml.SearchRuns{
+		ExperimentIds: experimentIDs,
+		Filter:        filter,
+	})
+
+	var result []*models.RunInfo
+	for iterator.HasNext(ctx) && (max <= 0 || len(result) < max) {
+		run, err := iterator.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search runs: %w", err)
+		}
+		result = append(result, convertRun(&run))
+	}
+	return result, nil
+}
+
+// RecentRuns returns up to max active runs of an experiment, latest started
+// first
+func (c *Client) RecentRuns(ctx context.Context, experimentID string, max int) ([]*models.RunInfo, error) {
+	iterator := c.client.Experiments.SearchRuns(ctx, ml.SearchRuns{
+		ExperimentIds: []string{experimentID},
+		OrderBy:       []string{"attributes.start_time DESC"},
+		MaxResults:    max,
+	})
+
+	var result []*models.RunInfo
+	for iterator.HasNext(ctx) && len(result) < max {
+		run, err := iterator.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search runs: %w", err)
+		}
+		result = append(result, convertRun(&run))
+	}
+	return result, nil
+}
+
+// DeleteRun marks a run as deleted
+func (c *Client) DeleteRun(ctx context.Context, runID string) error {
+	if err := c.client.Experiments.DeleteRun(ctx, ml.DeleteRun{RunId: runID}); err != nil {
+		return fmt.Errorf("failed to delete run: %w", err)
+	}
+	return nil
+}
+
+// convertRun converts an SDK run to RunInfo
+func convertRun(run *ml.Run) *models.RunInfo {
 	tags := make(map[string]string)
 	for _, tag := range run.Data.Tags {
 		tags[tag.Key] = tag.Value
 	}
 
+	params := make(map[string]string)
+	for _, param := range run.Data.Params {
+		params[param.Key] = param.Value
+	}
+
+	// Data.Metrics holds the latest value of each metric
+	metrics := make(map[string]float64)
+	for _, metric := range run.Data.Metrics {
+		metrics[metric.Key] = metric.Value
+	}
+
 	runInfo := &models.RunInfo{
 		RunID:        run.Info.RunId,
 		ExperimentID: run.Info.ExperimentId,
 		Status:       string(run.Info.Status),
 		StartTime:    time.Unix(run.Info.StartTime/1000, 0),
 		Tags:         tags,
+		ArtifactURI:  run.Info.ArtifactUri,
+		Params:       params,
+		Metrics:      metrics,
 	}
 
 	if run.Info.EndTime != 0 {
@@ -144,9 +327,13 @@ func (c *Client) GetRun(ctx context.Context, runID string) (*models.RunInfo, err
 		runInfo.RunName = runName
 	}
 
-	if description, exists := tags["mlflow.note.content"]; exists {
+	if description, exists := tags[TagNoteContent]; exists {
 		runInfo.Description = description
 	}
 
-	return runInfo, nil
+	if parentRunID, exists := tags["mlflow.parentRunId"]; exists {
+		runInfo.ParentRunID = parentRunID
+	}
+
+	return runInfo
 }
@@ -0,0 +1,85 @@
+package mlflow
+
+import (
+	"context"
+	"sync"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/sink"
+)
+
+// SinkFailure describes an output sink that was disabled by a failed write
+type SinkFailure struct {
+	// Sink describes the sink
+	Sink string
+	// Err is the error of the failed write
+	Err error
+	// Skipped is the number of writes left out after the failure
+	Skipped int
+}
+
+// sinkTelemetry collects the failed writes of the output sinks of all clients
+// of the process. Sinks only mirror what is logged to MLflow, so their
+// failures are reported once at the end instead of failing the logging. A
+// sink that failed is not written to again, so that an unreachable collector
+// delays logging by a single timeout, not one per batch.
+type sinkTelemetry struct {
+	mu       sync.Mutex
+	failures []SinkFailure
+}
+
+// sinkFailures is shared by all clients of the process, like the retry
+// telemetry
+var sinkFailures = &sinkTelemetry{}
+
+// SinkFailures returns the sinks that failed to write so far
+func SinkFailures() []SinkFailure {
+	sinkFailures.mu.Lock()
+	defer sinkFailures.mu.Unlock()
+	return append([]SinkFailure(nil), sinkFailures.failures...)
+}
+
+// write writes to a sink unless an earlier write to it failed
+func (t *sinkTelemetry) write(s sink.Sink, write func() error) {
+	if t.skip(s) {
+		return
+	}
+	if err := write(); err != nil {
+		t.failed(s, err)
+	}
+}
+
+// skip reports whether a sink failed before, counting the skipped write
+func (t *sinkTelemetry) skip(s sink.Sink) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range t.failures {
+		if t.failures[i].Sink == s.String() {
+			t.failures[i].Skipped++
+			return true
+		}
+	}
+	return false
+}
+
+// failed records the failed write of a sink
+func (t *sinkTelemetry) failed(s sink.Sink, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failures = append(t.failures, SinkFailure{Sink: s.String(), Err: err})
+}
+
+// forwardMetrics writes metrics logged to a run to the output sinks
+func (c *Client) forwardMetrics(ctx context.Context, runID string, metrics []models.Metric) {
+	for _, s := range c.sinks {
+		sinkFailures.write(s, func() error { return s.WriteMetrics(ctx, runID, metrics) })
+	}
+}
+
+// forwardParams writes params logged to a run to the output sinks
+func (c *Client) forwardParams(ctx context.Context, runID string, params []models.Parameter) {
+	for _, s := range c.sinks {
+		sinkFailures.write(s, func() error { return s.WriteParams(ctx, runID, params) })
+	}
+}
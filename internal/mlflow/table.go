@@ -0,0 +1,56 @@
+package mlflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// TagLoggedArtifacts is the run tag listing artifacts with a special meaning
+// for the MLflow UI, such as tables shown in its evaluation view
+const TagLoggedArtifacts = "mlflow.loggedArtifacts"
+
+// loggedArtifact is an entry of the mlflow.loggedArtifacts run tag
+type loggedArtifact struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+// LogTable uploads a table as a JSON artifact like mlflow.log_table and
+// records it in the mlflow.loggedArtifacts tag of the run, so that the UI
+// renders it as a table. Logging to the same path again replaces the table.
+func (c *Client) LogTable(ctx context.Context, runID, artifactFile string, table *models.Table) error {
+	data, err := json.Marshal(table)
+	if err != nil {
+		return fmt.Errorf("failed to encode table: %w", err)
+	}
+	if err := c.UploadArtifactFromReader(ctx, runID, bytes.NewReader(data), int64(len(data)), artifactFile); err != nil {
+		return fmt.Errorf("failed to upload table: %w", err)
+	}
+
+	run, err := c.GetRun(ctx, runID)
+	if err != nil {
+		return err
+	}
+	var artifacts []loggedArtifact
+	if value := run.Tags[TagLoggedArtifacts]; value != "" {
+		if err := json.Unmarshal([]byte(value), &artifacts); err != nil {
+			return fmt.Errorf("failed to parse %s of run %s: %w", TagLoggedArtifacts, runID, err)
+		}
+	}
+
+	entry := loggedArtifact{Path: artifactFile, Type: "table"}
+	for _, artifact := range artifacts {
+		if artifact == entry {
+			return nil
+		}
+	}
+	data, err = json.Marshal(append(artifacts, entry))
+	if err != nil {
+		return err
+	}
+	return c.SetTag(ctx, runID, TagLoggedArtifacts, string(data))
+}
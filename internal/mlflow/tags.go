@@ -0,0 +1,43 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go/service/ml"
+)
+
+func (c *Client) SetTag(ctx context.Context, runID string, key string, value string) error {
+	err := c.client.Experiments.SetTag(ctx, ml.SetTag{
+		RunId: runID,
+		Key:   key,
+		Value: value,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set tag %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (c *Client) SetTagsFromMap(ctx context.Context, runID string, tags map[string]string) error {
+	for key, value := range tags {
+		if err := c.SetTag(ctx, runID, key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteTag(ctx context.Context, runID string, key string) error {
+	err := c.client.Experiments.DeleteTag(ctx, ml.DeleteTag{
+		RunId: runID,
+		Key:   key,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete tag %s: %w", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package mlflow
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RetrySummary describes the retries of the requests sent by a process
+type RetrySummary struct {
+	// Requests is the number of attempts sent, including retries
+	Requests int `json:"requests"`
+	// Retries is the number of attempts that repeated a failed one
+	Retries int `json:"retries"`
+	// RateLimited is the number of attempts rejected with 429 Too Many Requests
+	RateLimited int `json:"rate_limited"`
+	// ServerErrors is the number of attempts failed with 5xx or connection errors
+	ServerErrors int `json:"server_errors"`
+	// Backoff is the total time between failed attempts and their retries
+	Backoff time.Duration `json:"-"`
+	// BackoffSeconds is Backoff for JSON output
+	BackoffSeconds float64 `json:"backoff_seconds"`
+	// Throttled is the total time attempts waited for the client-side rate
+	// limit
+	Throttled time.Duration `json:"-"`
+	// ThrottledSeconds is Throttled for JSON output
+	ThrottledSeconds float64 `json:"throttled_seconds"`
+}
+
+// retryTelemetry counts the attempts of all clients of the process, and the
+// retries the retry transport makes
+type retryTelemetry struct {
+	mu      sync.Mutex
+	summary RetrySummary
+}
+
+// telemetry is shared by all clients of the process, like the dry-run plan
+var telemetry = &retryTelemetry{}
+
+// RetryTelemetry returns the retry summary of all requests sent so far
+func RetryTelemetry() RetrySummary {
+	telemetry.mu.Lock()
+	defer telemetry.mu.Unlock()
+
+	summary := telemetry.summary
+	summary.BackoffSeconds = summary.Backoff.Seconds()
+	summary.ThrottledSeconds = summary.Throttled.Seconds()
+	return summary
+}
+
+// started records the start of an attempt
+func (t *retryTelemetry) started() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.summary.Requests++
+}
+
+// retried records a retry of a failed attempt after waiting for backoff
+func (t *retryTelemetry) retried(backoff time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.summary.Retries++
+	t.summary.Backoff += backoff
+}
+
+// throttled records the wait of an attempt for the client-side rate limit
+func (t *retryTelemetry) throttled(wait time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.summary.Throttled += wait
+}
+
+// finished records the outcome of an attempt
+func (t *retryTelemetry) finished(statusCode int, err error) {
+	rateLimited := err == nil && statusCode == http.StatusTooManyRequests
+	serverError := err != nil || statusCode >= 500
+	if !rateLimited && !serverError {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if rateLimited {
+		t.summary.RateLimited++
+	} else {
+		t.summary.ServerErrors++
+	}
+}
+
+// telemetryTransport records every attempt in the process telemetry, and
+// the clock skew of every response
+type telemetryTransport struct {
+	next http.RoundTripper
+}
+
+func newTelemetryTransport(next http.RoundTripper) *telemetryTransport {
+	return &telemetryTransport{next: next}
+}
+
+func (t *telemetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sent := time.Now()
+	telemetry.started()
+
+	resp, err := t.next.RoundTrip(req)
+	received := time.Now()
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+		skew.observe(sent, received, resp.Header.Get("Date"))
+	}
+	telemetry.finished(statusCode, err)
+	return resp, err
+}
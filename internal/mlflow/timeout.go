@@ -0,0 +1,75 @@
+package mlflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// transferKey marks the contexts of artifact transfers, which are bounded by
+// the transfer timeout instead of the timeout of API calls
+type transferKey struct{}
+
+// transferContext returns the context of an artifact transfer, with the
+// deadline of the configured transfer timeout if there is one
+func (c *Client) transferContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, transferKey{}, true)
+	if c.config.TransferTimeout > 0 {
+		return context.WithTimeout(ctx, c.config.TransferTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// transferError explains errors of transfers that ran out of their time
+func (c *Client) transferError(ctx context.Context, err error) error {
+	if err != nil && c.config.TransferTimeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("transfer timed out after %s (use --transfer-timeout to change): %w", c.config.TransferTimeout, err)
+	}
+	return err
+}
+
+// timeoutTransport bounds every attempt of an API call by a deadline, which
+// covers reading the response body. Artifact transfers are left to their own
+// deadline. A timeout of 0 means no deadline.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func newTimeoutTransport(next http.RoundTripper, timeout time.Duration) *timeoutTransport {
+	return &timeoutTransport{next: next, timeout: timeout}
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 || req.Context().Value(transferKey{}) != nil {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil {
+			// The deadline error is kept, so that the attempt is retried
+			return nil, fmt.Errorf("request timed out after %s (use --timeout to change): %w", t.timeout, ctx.Err())
+		}
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases the context of a response once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,220 @@
+package mlflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultPartSize is the size of the parts of multipart uploads
+const DefaultPartSize = 100 << 20
+
+// UploadJournal records the progress of artifact uploads of a run in a local
+// file, so that an interrupted upload can be resumed: completed files are
+// skipped and multipart uploads continue after their last completed part.
+// It is safe for concurrent use.
+type UploadJournal struct {
+	path string
+	mu   sync.Mutex
+	// savedAt and dirty throttle the saves of completed files
+	savedAt time.Time
+	dirty   bool
+
+	RunID string `json:"run_id"`
+	// PartSize is the part size of all multipart uploads in the journal
+	PartSize int64 `json:"part_size"`
+	// Files is the state of each upload by artifact path
+	Files map[string]*JournalEntry `json:"files"`
+}
+
+// JournalEntry is the state of the upload of one file
+type JournalEntry struct {
+	FilePath string    `json:"file_path"`
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"mod_time"`
+	Done     bool      `json:"done"`
+	// Multipart is set while a multipart upload is in progress
+	Multipart *MultipartState `json:"multipart,omitempty"`
+}
+
+// MultipartState is an unfinished multipart upload
+type MultipartState struct {
+	UploadID string `json:"upload_id"`
+	// URLs are the upload URLs of the parts, in part number order
+	URLs []MultipartURL `json:"urls"`
+	// ETags are the ETags of completed parts by part number
+	ETags map[int]string `json:"etags"`
+}
+
+// DefaultUploadJournalPath returns the default journal location of uploads to
+// a run in the user cache directory
+func DefaultUploadJournalPath(runID string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(dir, "mlflow-cli", "uploads", runID+".json"), nil
+}
+
+// NewUploadJournal creates an empty journal for uploads to a run, stored at
+// path. Nothing is written until the first upload is recorded.
+func NewUploadJournal(path, runID string, partSize int64) *UploadJournal {
+	return &UploadJournal{
+		path:     path,
+		RunID:    runID,
+		PartSize: partSize,
+		Files:    make(map[string]*JournalEntry),
+	}
+}
+
+// LoadUploadJournal reads the journal at path. ok is false if there is none.
+func LoadUploadJournal(path, runID string) (journal *UploadJournal, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read upload journal: %w", err)
+	}
+
+	journal = &UploadJournal{path: path}
+	if err := json.Unmarshal(data, journal); err != nil {
+		return nil, false, fmt.Errorf("failed to parse upload journal %s: %w", path, err)
+	}
+	if journal.RunID != runID {
+		return nil, false, fmt.Errorf("upload journal %s belongs to run %s, not %s", path, journal.RunID, runID)
+	}
+	if journal.Files == nil {
+		journal.Files = make(map[string]*JournalEntry)
+	}
+	return journal, true, nil
+}
+
+// Path returns the location of the journal file
+func (j *UploadJournal) Path() string {
+	return j.path
+}
+
+// Completed reports whether upload finished in an earlier attempt and the
+// local file is unchanged since
+func (j *UploadJournal) Completed(upload ArtifactUpload) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, ok := j.Files[upload.ArtifactPath]
+	return ok && entry.Done && entry.unchanged(upload.FilePath)
+}
+
+// Remove deletes the journal file
+func (j *UploadJournal) Remove() error {
+	if err := os.Remove(j.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// multipart returns the unfinished multipart upload of a file, or nil if the
+// upload must start over because there is none or the file changed
+func (j *UploadJournal) multipart(upload ArtifactUpload) *MultipartState {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, ok := j.Files[upload.ArtifactPath]
+	if !ok || entry.Done || entry.Multipart == nil || !entry.unchanged(upload.FilePath) {
+		return nil
+	}
+	return entry.Multipart
+}
+
+// startMultipart records a new multipart upload of a file
+func (j *UploadJournal) startMultipart(upload ArtifactUpload, info os.FileInfo, state *MultipartState) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.Files[upload.ArtifactPath] = &JournalEntry{
+		FilePath:  upload.FilePath,
+		Size:      info.Size(),
+		ModTime:   info.ModTime(),
+		Multipart: state,
+	}
+	return j.save()
+}
+
+// completePart records a completed part of a multipart upload
+func (j *UploadJournal) completePart(upload ArtifactUpload, partNumber int, etag string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, ok := j.Files[upload.ArtifactPath]
+	if !ok || entry.Multipart == nil {
+		return nil
+	}
+	entry.Multipart.ETags[partNumber] = etag
+	return j.save()
+}
+
+// complete records a finished upload of a file. The journal is saved at most
+// once per second for completed files, so that directories of many small
+// files are not slowed down; a crash forgets at most the last second of
+// completions, whose files are uploaded again.
+func (j *UploadJournal) complete(upload ArtifactUpload, info os.FileInfo) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.Files[upload.ArtifactPath] = &JournalEntry{
+		FilePath: upload.FilePath,
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		Done:     true,
+	}
+	if time.Since(j.savedAt) < time.Second {
+		j.dirty = true
+		return nil
+	}
+	return j.save()
+}
+
+// flush saves completions not saved yet
+func (j *UploadJournal) flush() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.dirty {
+		return nil
+	}
+	return j.save()
+}
+
+// save writes the journal to a temporary file and renames it into place, so
+// that a crash never leaves a truncated journal. The caller holds j.mu.
+func (j *UploadJournal) save() error {
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write upload journal: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return fmt.Errorf("failed to write upload journal: %w", err)
+	}
+	j.savedAt = time.Now()
+	j.dirty = false
+	return nil
+}
+
+// unchanged reports whether the local file still has the recorded size and
+// modification time
+func (e *JournalEntry) unchanged(filePath string) bool {
+	info, err := os.Stat(filePath)
+	return err == nil && e.FilePath == filePath && info.Size() == e.Size && info.ModTime().Equal(e.ModTime)
+}
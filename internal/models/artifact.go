@@ -0,0 +1,7 @@
+package models
+
+type ArtifactInfo struct {
+	Path     string `json:"path"`
+	IsDir    bool   `json:"is_dir"`
+	FileSize int64  `json:"file_size,omitempty"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// Dataset describes a dataset used as an input of a run. Source, Schema and
+// Profile are JSON documents in the formats of MLflow's dataset tracking.
+type Dataset struct {
+	Name       string `json:"name"`
+	Digest     string `json:"digest"`
+	SourceType string `json:"source_type"`
+	Source     string `json:"source"`
+	Schema     string `json:"schema,omitempty"`
+	Profile    string `json:"profile,omitempty"`
+}
@@ -0,0 +1,88 @@
+package models
+
+import "sort"
+
+// CurveType is the kind of a classifier evaluation curve
+type CurveType string
+
+const (
+	// CurveROC is a ROC curve of true over false positive rates
+	CurveROC CurveType = "roc"
+	// CurvePR is a precision-recall curve of precision over recall
+	CurvePR CurveType = "pr"
+)
+
+// CurvePoint is a point of an evaluation curve
+type CurvePoint struct {
+	X float64
+	Y float64
+	// Threshold is the decision threshold of the point, if known
+	Threshold *float64
+}
+
+// Curve is a ROC or precision-recall curve
+type Curve struct {
+	Type   CurveType
+	Points []CurvePoint
+}
+
+// SortedPoints returns the points ordered by increasing X. Curves given in
+// decreasing X order, such as the precision-recall curves of sklearn, are
+// reversed, so that points of the same X stay in the order of the steps of the
+// curve; the points of other curves are sorted by X, keeping the order of
+// points of the same X.
+func (c *Curve) SortedPoints() []CurvePoint {
+	points := append([]CurvePoint(nil), c.Points...)
+	decreasing := true
+	for i := 1; i < len(points); i++ {
+		if points[i].X > points[i-1].X {
+			decreasing = false
+			break
+		}
+	}
+	if decreasing {
+		for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+			points[i], points[j] = points[j], points[i]
+		}
+		return points
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].X < points[j].X
+	})
+	return points
+}
+
+// AUC returns the area under the curve by the trapezoidal rule, like
+// sklearn.metrics.auc
+func (c *Curve) AUC() float64 {
+	points := c.SortedPoints()
+	var area float64
+	for i := 1; i < len(points); i++ {
+		area += (points[i].X - points[i-1].X) * (points[i].Y + points[i-1].Y) / 2
+	}
+	return area
+}
+
+// ConfusionMatrix counts the samples of each true label (rows) by predicted
+// label (columns), in the order of Labels
+type ConfusionMatrix struct {
+	Labels []string
+	Counts [][]float64
+}
+
+// Accuracy returns the share of samples on the diagonal
+func (m *ConfusionMatrix) Accuracy() float64 {
+	var correct, total float64
+	for i, row := range m.Counts {
+		for j, count := range row {
+			total += count
+			if i == j {
+				correct += count
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return correct / total
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type Experiment struct {
+	ExperimentID     string            `json:"experiment_id"`
+	Name             string            `json:"name"`
+	ArtifactLocation string            `json:"artifact_location,omitempty"`
+	LifecycleStage   string            `json:"lifecycle_stage,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	Tags             map[string]string `json:"tags,omitempty"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+}
@@ -2,17 +2,24 @@ package models
 
 import "time"
 
+// MetricPoint is a single data point of a metrics file. Every numeric field
+// other than timestamp and step becomes a metric keyed by its field name.
+// Offset is set instead of Timestamp for timestamps relative to a base time.
+// Naive marks timestamps without a zone, which are parsed as UTC and taken as
+// local time in TimeConfig.Location when processed.
 type MetricPoint struct {
-	Timestamp     *time.Time `json:"timestamp,omitempty"`
-	Step          *int64     `json:"step,omitempty"`
-	ExecutionTime float64    `json:"execution_time,omitempty"`
-	SuccessRate   float64    `json:"success_rate,omitempty"`
-	ErrorCount    float64    `json:"error_count,omitempty"`
-	// Add more fields as needed
+	Timestamp *time.Time         `json:"timestamp,omitempty"`
+	Offset    *time.Duration     `json:"offset,omitempty"`
+	Naive     bool               `json:"-"`
+	Step      *int64             `json:"step,omitempty"`
+	Values    map[string]float64 `json:"values"`
 }
 
 type MetricsFile struct {
-	Metrics []MetricPoint `json:"metrics"`
+	Version int `json:"version,omitempty"`
+	// Mapping renames metric fields (field -> metric key); an empty key drops the field
+	Mapping map[string]string `json:"mapping,omitempty"`
+	Metrics []MetricPoint     `json:"metrics"`
 }
 
 type Metric struct {
@@ -20,10 +27,19 @@ type Metric struct {
 	Value     float64   `json:"value"`
 	Timestamp time.Time `json:"timestamp"`
 	Step      int64     `json:"step"`
+	// LocalClock is set if Timestamp was read from the local clock when the
+	// metric was logged instead of given by the input; only such timestamps
+	// are corrected for clock skew
+	LocalClock bool `json:"-"`
 }
 
 type TimeConfig struct {
-	Resolution string // 1m, 5m, 1h
+	Resolution string // duration (10s, 5m, 1h, 1d) or none
 	Alignment  string // floor, ceil, round
 	StepMode   string // auto, timestamp, sequence
+	Aggregate  string // none, mean, max, min, last
+	StepOffset int64  // added to every step
+	StepStride int64  // every step is multiplied by this (0 means 1)
+	// Location is the time zone of naive timestamps and bucket boundaries (nil means UTC)
+	Location *time.Location
 }
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+type ModelVersion struct {
+	Name          string             `json:"name"`
+	Version       string             `json:"version"`
+	Status        ModelVersionStatus `json:"status"`
+	StatusMessage string             `json:"status_message,omitempty"`
+	CurrentStage  string             `json:"current_stage,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	Source        string             `json:"source,omitempty"`
+	RunID         string             `json:"run_id,omitempty"`
+	Tags          map[string]string  `json:"tags,omitempty"`
+	Aliases       []string           `json:"aliases,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// RegisteredModel is a model of the model registry, with the versions its
+// aliases point to and the latest version of each stage
+type RegisteredModel struct {
+	Name           string            `json:"name"`
+	Description    string            `json:"description,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+	Aliases        map[string]string `json:"aliases,omitempty"`
+	LatestVersions map[string]string `json:"latest_versions,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+type ModelVersionStatus string
+
+const (
+	ModelVersionStatusPending ModelVersionStatus = "PENDING_REGISTRATION"
+	ModelVersionStatusReady   ModelVersionStatus = "READY"
+	ModelVersionStatusFailed  ModelVersionStatus = "FAILED_REGISTRATION"
+)
+
+// LoggedModel is a model logged to an experiment. On MLflow 3 servers it is a
+// logged model entity; on older servers it is a directory of run artifacts
+// identified by its runs:/<run-id>/<path> URI.
+type LoggedModel struct {
+	ModelID       string             `json:"model_id"`
+	ExperimentID  string             `json:"experiment_id"`
+	Name          string             `json:"name"`
+	ModelType     string             `json:"model_type,omitempty"`
+	SourceRunID   string             `json:"source_run_id,omitempty"`
+	Status        LoggedModelStatus  `json:"status"`
+	StatusMessage string             `json:"status_message,omitempty"`
+	ArtifactURI   string             `json:"artifact_uri,omitempty"`
+	Params        map[string]string  `json:"params,omitempty"`
+	Tags          map[string]string  `json:"tags,omitempty"`
+	Metrics       map[string]float64 `json:"metrics,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+type LoggedModelStatus string
+
+const (
+	LoggedModelStatusPending      LoggedModelStatus = "LOGGED_MODEL_PENDING"
+	LoggedModelStatusReady        LoggedModelStatus = "LOGGED_MODEL_READY"
+	LoggedModelStatusUploadFailed LoggedModelStatus = "LOGGED_MODEL_UPLOAD_FAILED"
+)
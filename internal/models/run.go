@@ -7,17 +7,23 @@ type RunConfig struct {
 	RunName      *string           `json:"run_name,omitempty"`
 	Tags         map[string]string `json:"tags,omitempty"`
 	Description  *string           `json:"description,omitempty"`
+	ParentRunID  *string           `json:"parent_run_id,omitempty"`
+	RequestToken *string           `json:"request_token,omitempty"`
 }
 
 type RunInfo struct {
-	RunID        string            `json:"run_id"`
-	ExperimentID string            `json:"experiment_id"`
-	RunName      string            `json:"run_name"`
-	Status       string            `json:"status"`
-	StartTime    time.Time         `json:"start_time"`
-	EndTime      *time.Time        `json:"end_time,omitempty"`
-	Tags         map[string]string `json:"tags,omitempty"`
-	Description  string            `json:"description,omitempty"`
+	RunID        string             `json:"run_id"`
+	ExperimentID string             `json:"experiment_id"`
+	RunName      string             `json:"run_name"`
+	Status       string             `json:"status"`
+	StartTime    time.Time          `json:"start_time"`
+	EndTime      *time.Time         `json:"end_time,omitempty"`
+	Tags         map[string]string  `json:"tags,omitempty"`
+	Description  string             `json:"description,omitempty"`
+	ParentRunID  string             `json:"parent_run_id,omitempty"`
+	ArtifactURI  string             `json:"artifact_uri,omitempty"`
+	Params       map[string]string  `json:"params,omitempty"`
+	Metrics      map[string]float64 `json:"metrics,omitempty"`
 }
 
 type RunStatus string
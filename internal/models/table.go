@@ -0,0 +1,8 @@
+package models
+
+// Table is a table in MLflow's table artifact format, the "split" orientation
+// of pandas: column names and rows of cell values
+type Table struct {
+	Columns []string        `json:"columns"`
+	Data    [][]interface{} `json:"data"`
+}
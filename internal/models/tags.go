@@ -0,0 +1,7 @@
+package models
+
+// TagsFile is a tags file with a top-level tags mapping. Nested mappings are
+// flattened into dotted tag keys.
+type TagsFile struct {
+	Tags map[string]interface{} `json:"tags" yaml:"tags"`
+}
@@ -0,0 +1,245 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/imishinist/mlflow-cli/internal/httperr"
+)
+
+// authenticator authorizes requests to a registry with basic auth or a bearer
+// token for the repository, as the registry asks for on its /v2/ endpoint
+type authenticator struct {
+	httpClient *http.Client
+	ref        Reference
+	opts       Options
+
+	once  sync.Once
+	err   error
+	basic bool
+	token string
+	// username and password are resolved on login
+	username string
+	password string
+}
+
+func newAuthenticator(httpClient *http.Client, ref Reference, opts Options) *authenticator {
+	return &authenticator{httpClient: httpClient, ref: ref, opts: opts}
+}
+
+// login asks the registry how to authenticate and gets a token if needed
+func (a *authenticator) login(ctx context.Context, baseURL string) error {
+	a.once.Do(func() {
+		a.err = a.loginOnce(ctx, baseURL)
+	})
+	return a.err
+}
+
+func (a *authenticator) loginOnce(ctx context.Context, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/v2/", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to registry %s: %w", a.ref.Registry, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		return nil
+	}
+
+	a.username, a.password = a.opts.Username, a.opts.Password
+	if a.username == "" && a.password == "" {
+		if a.username, a.password, err = dockerCredentials(a.ref.Registry); err != nil {
+			return err
+		}
+	}
+
+	scheme, params := parseChallenge(resp.Header.Get("WWW-Authenticate"))
+	switch scheme {
+	case "basic":
+		if a.username == "" && a.password == "" {
+			return fmt.Errorf("registry %s requires credentials (use docker login or --username)", a.ref.Registry)
+		}
+		a.basic = true
+		return nil
+	case "bearer":
+		a.token, err = a.fetchToken(ctx, params)
+		return err
+	}
+	return fmt.Errorf("registry %s requires unsupported authentication: %s", a.ref.Registry, resp.Header.Get("WWW-Authenticate"))
+}
+
+// fetchToken gets a bearer token for pushing to the repository from the
+// token service named in the challenge
+func (a *authenticator) fetchToken(ctx context.Context, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("registry %s sent no valid token realm", a.ref.Registry)
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", "repository:"+a.ref.Repository+":pull,push")
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	if a.username != "" || a.password != "" {
+		req.SetBasicAuth(a.username, a.password)
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get registry token: status %d: %s", resp.StatusCode, httperr.ReadMessage(resp.Body))
+	}
+
+	var response struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("failed to decode registry token: %w", err)
+	}
+	if response.Token != "" {
+		return response.Token, nil
+	}
+	return response.AccessToken, nil
+}
+
+// authorize adds the credentials of the login to a request
+func (a *authenticator) authorize(req *http.Request) {
+	switch {
+	case a.token != "":
+		req.Header.Set("Authorization", "Bearer "+a.token)
+	case a.basic:
+		req.SetBasicAuth(a.username, a.password)
+	}
+}
+
+// parseChallenge splits a WWW-Authenticate header into its lowercase scheme
+// and parameters
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	return strings.ToLower(scheme), params
+}
+
+// dockerConfig is the part of the docker CLI's config.json holding
+// credentials
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// dockerCredentials returns the credentials docker login stored for a
+// registry, in the config file or a credential helper. No credentials is not
+// an error; anonymous pushes are up to the registry.
+func dockerCredentials(registry string) (string, string, error) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", "", nil
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read docker config: %w", err)
+	}
+	var config dockerConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", "", fmt.Errorf("failed to parse docker config: %w", err)
+	}
+
+	// Docker Hub credentials are stored under the URL of its index
+	key := registry
+	if registry == "docker.io" {
+		key = "https://index.docker.io/v1/"
+	}
+	if helper := config.CredHelpers[key]; helper != "" {
+		return helperCredentials(helper, key)
+	}
+	for name, auth := range config.Auths {
+		if name != key && strings.TrimPrefix(strings.TrimPrefix(name, "https://"), "http://") != key {
+			continue
+		}
+		if auth.Auth == "" {
+			return auth.Username, auth.Password, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid credentials of %s in docker config", name)
+		}
+		username, password, _ := strings.Cut(string(decoded), ":")
+		return username, password, nil
+	}
+	if config.CredsStore != "" {
+		return helperCredentials(config.CredsStore, key)
+	}
+	return "", "", nil
+}
+
+// helperCredentials gets the credentials of a registry from a docker
+// credential helper such as docker-credential-osxkeychain
+func helperCredentials(helper, registry string) (string, string, error) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(registry)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		// Helpers report registries without credentials on stdout
+		if strings.Contains(string(output), "credentials not found") {
+			return "", "", nil
+		}
+		return "", "", fmt.Errorf("docker credential helper %s failed: %w: %s", helper, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var credentials struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(output, &credentials); err != nil {
+		return "", "", fmt.Errorf("invalid output of docker credential helper %s: %w", helper, err)
+	}
+	return credentials.Username, credentials.Secret, nil
+}
@@ -0,0 +1,244 @@
+// Package oci pushes artifacts to container registries through the OCI
+// distribution API, so that models can be distributed like images
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/imishinist/mlflow-cli/internal/httperr"
+)
+
+// Media types of OCI artifacts
+const (
+	MediaTypeManifest   = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeEmpty      = "application/vnd.oci.empty.v1+json"
+	MediaTypeLayerTarGz = "application/vnd.oci.image.layer.v1.tar+gzip"
+)
+
+// Annotations ORAS uses for files and directories in layers, and predefined
+// annotations of manifests
+const (
+	AnnotationTitle         = "org.opencontainers.image.title"
+	AnnotationCreated       = "org.opencontainers.image.created"
+	AnnotationDescription   = "org.opencontainers.image.description"
+	AnnotationUnpack        = "io.deis.oras.content.unpack"
+	AnnotationContentDigest = "io.deis.oras.content.digest"
+)
+
+// EmptyConfig is the config blob of artifacts that have no config
+var EmptyConfig = []byte("{}")
+
+// Descriptor describes a blob or manifest
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an OCI image manifest describing an artifact
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// Reference names a tag of a repository in a registry, e.g.
+// ghcr.io/org/models/foo:3
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+}
+
+var (
+	repositoryPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	tagPattern        = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+)
+
+// ParseReference parses a reference <registry>/<repository>[:<tag>]. The tag
+// defaults to latest; Docker Hub references need the docker.io registry.
+func ParseReference(ref string) (Reference, error) {
+	invalid := fmt.Errorf("invalid reference: %s (expected <registry>/<repository>:<tag>)", ref)
+
+	registry, rest, ok := strings.Cut(ref, "/")
+	if !ok || rest == "" || !strings.ContainsAny(registry, ".:") && registry != "localhost" {
+		return Reference{}, invalid
+	}
+	if strings.Contains(rest, "@") {
+		return Reference{}, fmt.Errorf("invalid reference: %s (pushes need a tag, not a digest)", ref)
+	}
+
+	repository, tag := rest, "latest"
+	if i := strings.LastIndex(rest, ":"); i >= 0 {
+		repository, tag = rest[:i], rest[i+1:]
+	}
+	if !repositoryPattern.MatchString(repository) || !tagPattern.MatchString(tag) {
+		return Reference{}, invalid
+	}
+	if registry == "docker.io" && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+	return Reference{Registry: registry, Repository: repository, Tag: tag}, nil
+}
+
+func (r Reference) String() string {
+	return r.Registry + "/" + r.Repository + ":" + r.Tag
+}
+
+// Digest returns the digest of content in the form registries use
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Options configure the connection to a registry
+type Options struct {
+	// Username and Password authenticate to the registry; without them, the
+	// credentials of docker login are used
+	Username string
+	Password string
+	// PlainHTTP connects without TLS, e.g. to a local test registry
+	PlainHTTP bool
+}
+
+// Client pushes to a repository of a registry
+type Client struct {
+	ref        Reference
+	baseURL    string
+	httpClient *http.Client
+	auth       *authenticator
+}
+
+// NewClient returns a client of the repository of ref. The registry is
+// authenticated to when the first request is sent.
+func NewClient(ref Reference, opts Options) *Client {
+	host := ref.Registry
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	scheme := "https"
+	if opts.PlainHTTP {
+		scheme = "http"
+	}
+	httpClient := &http.Client{Transport: httperr.NewTransport(http.DefaultTransport)}
+	return &Client{
+		ref:        ref,
+		baseURL:    scheme + "://" + host,
+		httpClient: httpClient,
+		auth:       newAuthenticator(httpClient, ref, opts),
+	}
+}
+
+// do sends a request to the registry, authenticating first if needed
+func (c *Client) do(ctx context.Context, method, rawURL string, body io.Reader, size int64, header http.Header) (*http.Response, error) {
+	if err := c.auth.login(ctx, c.baseURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	c.auth.authorize(req)
+	return c.httpClient.Do(req)
+}
+
+// repositoryURL returns the URL of an API path below the repository
+func (c *Client) repositoryURL(path string) string {
+	return c.baseURL + "/v2/" + c.ref.Repository + path
+}
+
+// BlobExists reports whether the repository holds a blob
+func (c *Client) BlobExists(ctx context.Context, digest string) (bool, error) {
+	resp, err := c.do(ctx, http.MethodHead, c.repositoryURL("/blobs/"+digest), nil, 0, nil)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to check blob %s: status %d", digest, resp.StatusCode)
+}
+
+// PushBlob uploads a blob of the given digest and size in a single request,
+// unless the repository already holds it. It returns whether it was uploaded.
+func (c *Client) PushBlob(ctx context.Context, digest string, size int64, content io.Reader) (bool, error) {
+	exists, err := c.BlobExists(ctx, digest)
+	if err != nil || exists {
+		return false, err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, c.repositoryURL("/blobs/uploads/"), nil, 0, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return false, fmt.Errorf("failed to start blob upload: status %d: %s", resp.StatusCode, httperr.ReadMessage(resp.Body))
+	}
+
+	// The upload location may be relative and carry query parameters
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return false, fmt.Errorf("registry returned no upload location")
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	header := http.Header{"Content-Type": {"application/octet-stream"}}
+	put, err := c.do(ctx, http.MethodPut, location.String(), content, size, header)
+	if err != nil {
+		return false, err
+	}
+	defer put.Body.Close()
+	if put.StatusCode != http.StatusCreated {
+		return false, fmt.Errorf("failed to upload blob %s: status %d: %s", digest, put.StatusCode, httperr.ReadMessage(put.Body))
+	}
+	return true, nil
+}
+
+// PushManifest uploads a manifest under the tag of the reference and returns
+// its digest
+func (c *Client) PushManifest(ctx context.Context, manifest *Manifest) (string, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+
+	header := http.Header{"Content-Type": {manifest.MediaType}}
+	resp, err := c.do(ctx, http.MethodPut, c.repositoryURL("/manifests/"+url.PathEscape(c.ref.Tag)), bytes.NewReader(data), int64(len(data)), header)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to push manifest: status %d: %s", resp.StatusCode, httperr.ReadMessage(resp.Body))
+	}
+	return Digest(data), nil
+}
@@ -0,0 +1,41 @@
+package output
+
+// Color enables colored status cells in text tables. It is set for terminals
+// unless colors are turned off with --no-color or NO_COLOR.
+var Color bool
+
+// ANSI escape sequences of the status colors
+const (
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+)
+
+// statusColors maps the values of status columns to their color: runs and
+// logged models in progress are yellow, finished ones green, failed ones red
+var statusColors = map[string]string{
+	"RUNNING":                    colorYellow,
+	"SCHEDULED":                  colorYellow,
+	"FINISHED":                   colorGreen,
+	"FAILED":                     colorRed,
+	"KILLED":                     colorRed,
+	"LOGGED_MODEL_PENDING":       colorYellow,
+	"LOGGED_MODEL_READY":         colorGreen,
+	"LOGGED_MODEL_UPLOAD_FAILED": colorRed,
+	// Regression check results
+	"ok":        colorGreen,
+	"regressed": colorRed,
+	"missing":   colorYellow,
+}
+
+// colorize wraps the text of a status cell in its color
+func colorize(column, text string) string {
+	if !Color || column != "status" {
+		return text
+	}
+	if color, ok := statusColors[text]; ok {
+		return color + text + colorReset
+	}
+	return text
+}
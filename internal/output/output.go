@@ -0,0 +1,306 @@
+// Package output renders tabular command results as text tables, CSV, JSON or
+// JSON Lines.
+package output
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Output formats
+const (
+	FormatTable = "table"
+	FormatCSV   = "csv"
+	FormatJSON  = "json"
+	FormatJSONL = "jsonl"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatTable, FormatCSV, FormatJSON, FormatJSONL}
+
+// Location is the time zone timestamps are displayed in. Nil keeps the time
+// zone of each timestamp, the system time zone for times read from servers.
+var Location *time.Location
+
+// DisplayTime returns t in the display time zone
+func DisplayTime(t time.Time) time.Time {
+	if Location == nil {
+		return t
+	}
+	return t.In(Location)
+}
+
+// Table is a list of rows with named columns. Cell values keep their type so
+// that JSON output contains numbers as numbers.
+type Table struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// NewTable creates an empty table with the given columns
+func NewTable(columns ...string) *Table {
+	return &Table{Columns: columns}
+}
+
+// Append adds a row; values must be in column order
+func (t *Table) Append(values ...any) {
+	t.Rows = append(t.Rows, values)
+}
+
+// Select returns a table with the given columns of t in the given order.
+// Columns t does not have are empty.
+func (t *Table) Select(columns ...string) *Table {
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		indexes[i] = t.columnIndex(column)
+	}
+
+	selected := NewTable(columns...)
+	for _, row := range t.Rows {
+		values := make([]any, len(columns))
+		for i, index := range indexes {
+			if index >= 0 && index < len(row) {
+				values[i] = row[index]
+			}
+		}
+		selected.Append(values...)
+	}
+	return selected
+}
+
+// Sort sorts the rows by a column, keeping the order of equal rows. Numbers
+// and times are compared by value, other values as text; empty cells sort
+// last in both directions.
+func (t *Table) Sort(column string, descending bool) error {
+	index := t.columnIndex(column)
+	if index < 0 {
+		return fmt.Errorf("unknown column: %s", column)
+	}
+
+	sort.SliceStable(t.Rows, func(i, j int) bool {
+		a, b := cell(t.Rows[i], index), cell(t.Rows[j], index)
+		if a == nil || b == nil {
+			return a != nil
+		}
+		if descending {
+			return compareValues(b, a) < 0
+		}
+		return compareValues(a, b) < 0
+	})
+	return nil
+}
+
+// columnIndex returns the index of a column, or -1
+func (t *Table) columnIndex(column string) int {
+	for i, name := range t.Columns {
+		if name == column {
+			return i
+		}
+	}
+	return -1
+}
+
+// cell returns a cell value, or nil for empty cells
+func cell(row []any, index int) any {
+	if index >= len(row) {
+		return nil
+	}
+	switch v := row[index].(type) {
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+	}
+	return row[index]
+}
+
+// compareValues compares two non-empty cell values
+func compareValues(a, b any) int {
+	if x, ok := number(a); ok {
+		if y, ok := number(b); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	if x, ok := a.(time.Time); ok {
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y)
+		}
+	}
+	return strings.Compare(FormatValue(a), FormatValue(b))
+}
+
+// number returns the value of numeric cells
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// ValidateFormat returns an error for unsupported formats
+func ValidateFormat(format string) error {
+	for _, supported := range Formats {
+		if format == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported output format: %s (supported: %s)", format, strings.Join(Formats, ", "))
+}
+
+// Write renders the table in the given format
+func Write(w io.Writer, format string, table *Table) error {
+	switch format {
+	case FormatTable:
+		return writeText(w, table)
+	case FormatCSV:
+		return writeCSV(w, table)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records(table))
+	case FormatJSONL:
+		encoder := json.NewEncoder(w)
+		for _, record := range records(table) {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ValidateFormat(format)
+}
+
+// writeText renders an aligned text table. Columns are padded to their widest
+// cell plus two spaces; colors of status cells do not count towards widths.
+func writeText(w io.Writer, table *Table) error {
+	header := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		header[i] = strings.ToUpper(column)
+	}
+	lines := [][]string{header}
+	for _, row := range table.Rows {
+		lines = append(lines, formatRow(row))
+	}
+
+	widths := make([]int, len(table.Columns))
+	for _, cells := range lines {
+		for i, text := range cells {
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(text))
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for n, cells := range lines {
+		for i, text := range cells {
+			if n > 0 && i < len(table.Columns) {
+				bw.WriteString(colorize(table.Columns[i], text))
+			} else {
+				bw.WriteString(text)
+			}
+			if i < len(cells)-1 {
+				bw.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(text)+2))
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// writeCSV renders the table as CSV with a header row
+func writeCSV(w io.Writer, table *Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Columns); err != nil {
+		return err
+	}
+	for _, row := range table.Rows {
+		if err := writer.Write(formatRow(row)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// records converts rows to column-keyed records
+func records(table *Table) []map[string]any {
+	result := make([]map[string]any, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		record := make(map[string]any, len(table.Columns))
+		for i, column := range table.Columns {
+			if i < len(row) {
+				record[column] = displayValue(row[i])
+			}
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+// displayValue returns time cells in the display time zone and other cells
+// unchanged
+func displayValue(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return DisplayTime(v)
+	case *time.Time:
+		if v != nil {
+			t := DisplayTime(*v)
+			return &t
+		}
+	}
+	return value
+}
+
+// formatRow formats every cell of a row as text
+func formatRow(row []any) []string {
+	cells := make([]string, len(row))
+	for i, value := range row {
+		cells[i] = FormatValue(value)
+	}
+	return cells
+}
+
+// FormatValue formats a cell value as text
+func FormatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return DisplayTime(v).Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return FormatValue(*v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
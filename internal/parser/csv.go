@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// ParseCSVMetrics parses a CSV file whose header row contains metric names and
+// optional timestamp (ISO8601, Unix epoch or +offset) and step columns. Empty cells are skipped.
+func ParseCSVMetrics(reader io.Reader) (*models.MetricsFile, error) {
+	data := &models.MetricsFile{}
+	err := ReadCSVMetrics(reader, func(point models.MetricPoint) error {
+		data.Metrics = append(data.Metrics, point)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ReadCSVMetrics parses a CSV metrics file like ParseCSVMetrics, passing each
+// point to fn as it is read. Errors of fn are returned as they are.
+func ReadCSVMetrics(reader io.Reader, fn func(models.MetricPoint) error) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to parse CSV metrics: missing header row")
+		}
+		return fmt.Errorf("failed to parse CSV metrics: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse CSV metrics: %w", err)
+		}
+
+		line, _ := csvReader.FieldPos(0)
+		point, err := parseCSVRecord(header, record)
+		if err != nil {
+			return fmt.Errorf("failed to parse CSV metrics at line %d: %w", line, err)
+		}
+		if err := fn(point); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseCSVRecord converts a CSV record into a metric point using the header
+func parseCSVRecord(header []string, record []string) (models.MetricPoint, error) {
+	point := models.MetricPoint{Values: make(map[string]float64)}
+
+	for i, column := range header {
+		cell := strings.TrimSpace(record[i])
+		if cell == "" || column == "" {
+			continue
+		}
+
+		switch strings.ToLower(column) {
+		case timestampField:
+			if err := setTimestamp(&point, cell); err != nil {
+				return point, err
+			}
+		case stepField:
+			step, err := strconv.ParseInt(cell, 10, 64)
+			if err != nil {
+				return point, fmt.Errorf("invalid step: %s", cell)
+			}
+			point.Step = &step
+		default:
+			value, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				return point, fmt.Errorf("invalid value for %s: %s", column, cell)
+			}
+			point.Values[column] = value
+		}
+	}
+
+	return point, nil
+}
+
+// ParseCSVHeader parses a CSV header line into column names
+func ParseCSVHeader(line string) ([]string, error) {
+	header, err := csv.NewReader(strings.NewReader(line)).Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	return header, nil
+}
+
+// ParseCSVMetricLine parses a single CSV data line using the given header
+func ParseCSVMetricLine(header []string, line string) (models.MetricPoint, error) {
+	csvReader := csv.NewReader(strings.NewReader(line))
+	csvReader.TrimLeadingSpace = true
+	csvReader.FieldsPerRecord = len(header)
+
+	record, err := csvReader.Read()
+	if err != nil {
+		return models.MetricPoint{}, fmt.Errorf("failed to parse CSV line: %w", err)
+	}
+
+	return parseCSVRecord(header, record)
+}
@@ -0,0 +1,148 @@
+package parser
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// curveColumns are the columns of the X and Y values of each curve type
+var curveColumns = map[models.CurveType][2]string{
+	models.CurveROC: {"fpr", "tpr"},
+	models.CurvePR:  {"recall", "precision"},
+}
+
+// ParseCSVCurve parses the points of a curve from a CSV file with a header
+// row: fpr and tpr columns for ROC curves, recall and precision columns for
+// precision-recall curves, and optionally a threshold column, as returned by
+// sklearn.metrics.roc_curve and precision_recall_curve. Other columns are
+// ignored.
+func ParseCSVCurve(reader io.Reader, curveType models.CurveType) (*models.Curve, error) {
+	columns, ok := curveColumns[curveType]
+	if !ok {
+		return nil, fmt.Errorf("invalid curve type: %s (valid: roc, pr)", curveType)
+	}
+
+	csvReader := csv.NewReader(reader)
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to parse CSV curve: missing header row")
+		}
+		return nil, fmt.Errorf("failed to parse CSV curve: %w", err)
+	}
+
+	xColumn, yColumn, thresholdColumn := -1, -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case columns[0]:
+			xColumn = i
+		case columns[1]:
+			yColumn = i
+		case "threshold", "thresholds":
+			thresholdColumn = i
+		}
+	}
+	if xColumn < 0 || yColumn < 0 {
+		return nil, fmt.Errorf("failed to parse CSV curve: %s curves need %s and %s columns", curveType, columns[0], columns[1])
+	}
+
+	curve := &models.Curve{Type: curveType}
+	for line := 2; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV curve: %w", err)
+		}
+
+		var point models.CurvePoint
+		if point.X, err = parseRate(record[xColumn]); err != nil {
+			return nil, fmt.Errorf("failed to parse CSV curve: line %d: invalid %s: %w", line, columns[0], err)
+		}
+		if point.Y, err = parseRate(record[yColumn]); err != nil {
+			return nil, fmt.Errorf("failed to parse CSV curve: line %d: invalid %s: %w", line, columns[1], err)
+		}
+		// sklearn's first ROC threshold is inf, and empty cells are unknown
+		if thresholdColumn >= 0 && strings.TrimSpace(record[thresholdColumn]) != "" {
+			threshold, err := strconv.ParseFloat(strings.TrimSpace(record[thresholdColumn]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse CSV curve: line %d: invalid threshold: %s", line, record[thresholdColumn])
+			}
+			point.Threshold = &threshold
+		}
+		curve.Points = append(curve.Points, point)
+	}
+
+	if len(curve.Points) < 2 {
+		return nil, fmt.Errorf("failed to parse CSV curve: a curve needs at least 2 points")
+	}
+	return curve, nil
+}
+
+// parseRate parses a value between 0 and 1
+func parseRate(cell string) (float64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", cell)
+	}
+	if !(value >= 0 && value <= 1) {
+		return 0, fmt.Errorf("%s is not between 0 and 1", cell)
+	}
+	return value, nil
+}
+
+// ParseCSVConfusionMatrix parses a confusion matrix from a CSV file whose
+// header row holds the predicted labels after a corner cell, and whose rows
+// hold a true label and its counts, e.g.
+//
+//	,cat,dog
+//	cat,50,3
+//	dog,5,42
+//
+// Rows and columns must have the same labels in the same order.
+func ParseCSVConfusionMatrix(reader io.Reader) (*models.ConfusionMatrix, error) {
+	records, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV confusion matrix: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("failed to parse CSV confusion matrix: missing header row")
+	}
+
+	header := records[0]
+	matrix := &models.ConfusionMatrix{}
+	for _, label := range header[1:] {
+		matrix.Labels = append(matrix.Labels, strings.TrimSpace(label))
+	}
+	if len(matrix.Labels) == 0 {
+		return nil, fmt.Errorf("failed to parse CSV confusion matrix: the header row has no labels")
+	}
+	if len(records)-1 != len(matrix.Labels) {
+		return nil, fmt.Errorf("failed to parse CSV confusion matrix: %d labels but %d rows", len(matrix.Labels), len(records)-1)
+	}
+
+	for i, record := range records[1:] {
+		line := i + 2
+		if label := strings.TrimSpace(record[0]); label != matrix.Labels[i] {
+			return nil, fmt.Errorf("failed to parse CSV confusion matrix: line %d: label %s does not match column %s", line, label, matrix.Labels[i])
+		}
+		row := make([]float64, len(matrix.Labels))
+		for j, cell := range record[1:] {
+			count, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+			if err != nil || !(count >= 0) || math.IsInf(count, 0) {
+				return nil, fmt.Errorf("failed to parse CSV confusion matrix: line %d: invalid count: %q", line, cell)
+			}
+			row[j] = count
+		}
+		matrix.Counts = append(matrix.Counts, row)
+	}
+	return matrix, nil
+}
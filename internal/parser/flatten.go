@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FlattenOptions controls how nested parameter files become parameter keys
+type FlattenOptions struct {
+	// Separator joins the keys of nested tables (optimizer.lr)
+	Separator string
+	// MaxDepth is the number of key levels to flatten; deeper values are JSON
+	// encoded (0 means no limit)
+	MaxDepth int
+}
+
+// DefaultFlattenOptions flattens all levels with dotted keys
+var DefaultFlattenOptions = FlattenOptions{Separator: "."}
+
+// FlattenParams flattens a nested document into parameters. Nested mappings
+// become joined keys; lists and mappings beyond the depth limit are JSON
+// encoded, and null values become "null".
+func FlattenParams(doc map[string]interface{}, opts FlattenOptions) (map[string]string, error) {
+	params := make(map[string]string)
+	if err := flattenInto(params, "", doc, 1, opts); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// ParseJSONParamsFlattened parses a nested JSON config into parameters. The
+// parameters object is used if there is one, otherwise the whole document.
+func ParseJSONParamsFlattened(reader io.Reader, opts FlattenOptions) (map[string]string, error) {
+	var doc map[string]interface{}
+	if err := json.NewDecoder(reader).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON parameters: %w", err)
+	}
+	return flattenParamsDocument(doc, opts)
+}
+
+// ParseYAMLParamsFlattened parses a nested YAML config (e.g. a Hydra config)
+// into parameters. The parameters mapping is used if there is one, otherwise
+// the whole document.
+func ParseYAMLParamsFlattened(reader io.Reader, opts FlattenOptions) (map[string]string, error) {
+	var doc map[string]interface{}
+	if err := yaml.NewDecoder(reader).Decode(&doc); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse YAML parameters: %w", err)
+	}
+	return flattenParamsDocument(doc, opts)
+}
+
+// flattenParamsDocument flattens the parameters section of a document, or the
+// whole document if it has none
+func flattenParamsDocument(doc map[string]interface{}, opts FlattenOptions) (map[string]string, error) {
+	if parameters, ok := doc["parameters"].(map[string]interface{}); ok {
+		doc = parameters
+	}
+	params, err := FlattenParams(doc, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to flatten parameters: %w", err)
+	}
+	return params, nil
+}
+
+func flattenInto(params map[string]string, prefix string, table map[string]interface{}, depth int, opts FlattenOptions) error {
+	separator := opts.Separator
+	if separator == "" {
+		separator = DefaultFlattenOptions.Separator
+	}
+
+	// Sorted for deterministic duplicate key errors
+	names := make([]string, 0, len(table))
+	for name := range table {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := name
+		if prefix != "" {
+			key = prefix + separator + name
+		}
+
+		value := table[name]
+		if nested, ok := value.(map[string]interface{}); ok && (opts.MaxDepth == 0 || depth < opts.MaxDepth) {
+			if err := flattenInto(params, key, nested, depth+1, opts); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, exists := params[key]; exists {
+			return fmt.Errorf("duplicate parameter key %s", key)
+		}
+		formatted, err := formatParamValue(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		params[key] = formatted
+	}
+	return nil
+}
+
+// formatParamValue converts a decoded value to a parameter value
+func formatParamValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return v, nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case []interface{}, map[string]interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	case fmt.Stringer:
+		// TOML local dates and times
+		return v.String(), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
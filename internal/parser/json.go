@@ -20,12 +20,46 @@ func ParseJSONParams(reader io.Reader) (map[string]string, error) {
 }
 
 func ParseJSONMetrics(reader io.Reader) (*models.MetricsFile, error) {
-	var data models.MetricsFile
+	var data rawMetricsFile
 	decoder := json.NewDecoder(reader)
 
 	if err := decoder.Decode(&data); err != nil {
 		return nil, fmt.Errorf("failed to parse JSON metrics: %w", err)
 	}
 
-	return &data, nil
+	return convertMetricsFile(data)
+}
+
+func ParseJSONTags(reader io.Reader) (map[string]string, error) {
+	var data models.TagsFile
+	decoder := json.NewDecoder(reader)
+
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON tags: %w", err)
+	}
+
+	return convertTagsFile(data)
+}
+
+// ParseJSONLMetricLine parses a single JSON Lines record. A record is either a
+// metric point ({"timestamp": ..., "step": ..., "loss": 0.5}) or a single
+// metric ({"key": "loss", "value": 0.5, "step": 1}).
+func ParseJSONLMetricLine(line []byte) (models.MetricPoint, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return models.MetricPoint{}, fmt.Errorf("failed to parse JSON line: %w", err)
+	}
+
+	// Single metric form
+	if key, ok := fields["key"].(string); ok {
+		value, ok := toFloat(fields["value"])
+		if !ok {
+			return models.MetricPoint{}, fmt.Errorf("invalid value for %s: %v", key, fields["value"])
+		}
+		delete(fields, "key")
+		delete(fields, "value")
+		fields[key] = value
+	}
+
+	return convertMetricPoint(fields)
 }
@@ -0,0 +1,328 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// maxLineSize is the longest log line or journal entry that is read
+const maxLineSize = 1024 * 1024
+
+// LineExtractor turns log lines into metric points using regular expressions
+// with named groups. A group named step sets the step, a group named
+// timestamp the timestamp; every other group is a metric keyed by its name.
+type LineExtractor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewLineExtractor compiles extraction patterns. Every pattern needs at
+// least one named group for a metric value.
+func NewLineExtractor(patterns []string) (*LineExtractor, error) {
+	extractor := &LineExtractor{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+
+		hasMetric := false
+		for _, name := range re.SubexpNames() {
+			if name != "" && name != stepField && name != timestampField {
+				hasMetric = true
+			}
+		}
+		if !hasMetric {
+			return nil, fmt.Errorf("pattern %q has no named group for a metric, e.g. (?P<loss>[0-9.]+)", pattern)
+		}
+		extractor.patterns = append(extractor.patterns, re)
+	}
+	return extractor, nil
+}
+
+// Extract returns the metric point of a line. Values of all matching
+// patterns are merged, skipping nan and inf; ok is false if no pattern yields
+// a metric value.
+func (e *LineExtractor) Extract(line string) (point models.MetricPoint, ok bool, err error) {
+	point = models.MetricPoint{Values: make(map[string]float64)}
+
+	for _, re := range e.patterns {
+		match := re.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+
+		for i, name := range re.SubexpNames() {
+			if name == "" || match[2*i] < 0 {
+				continue
+			}
+			value := line[match[2*i]:match[2*i+1]]
+
+			switch name {
+			case stepField:
+				step, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return point, false, fmt.Errorf("invalid step: %s", value)
+				}
+				point.Step = &step
+			case timestampField:
+				if err := setTimestamp(&point, value); err != nil {
+					return point, false, err
+				}
+			default:
+				v, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return point, false, fmt.Errorf("invalid value for %s: %s", name, value)
+				}
+				// Diverged training logs print nan/inf, which MLflow cannot store
+				if math.IsNaN(v) || math.IsInf(v, 0) {
+					continue
+				}
+				point.Values[name] = v
+			}
+		}
+	}
+
+	return point, len(point.Values) > 0, nil
+}
+
+// extractMessage extracts a metric point from a log message, using the
+// extractor if given and parsing JSON object messages otherwise
+func extractMessage(message string, extractor *LineExtractor) (models.MetricPoint, bool, error) {
+	if extractor != nil {
+		return extractor.Extract(message)
+	}
+
+	message = strings.TrimSpace(message)
+	if !strings.HasPrefix(message, "{") {
+		return models.MetricPoint{}, false, nil
+	}
+	point, err := ParseJSONLMetricLine([]byte(message))
+	if err != nil {
+		// Not every JSON-looking message is a metrics record
+		return models.MetricPoint{}, false, nil
+	}
+	return point, len(point.Values) > 0, nil
+}
+
+// ReadLogMetrics extracts metric points from the lines of a free-form log
+// such as a training log and passes them to fn as they are read. Lines no
+// pattern matches are skipped; errors of fn are returned as they are.
+func ReadLogMetrics(reader io.Reader, extractor *LineExtractor, fn func(models.MetricPoint) error) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		point, ok, err := extractor.Extract(scanner.Text())
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if !ok {
+			continue
+		}
+		if err := fn(point); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read log: %w", err)
+	}
+
+	return nil
+}
+
+// journalEntry is an entry of `journalctl --output=json`
+type journalEntry struct {
+	RealtimeTimestamp string          `json:"__REALTIME_TIMESTAMP"`
+	Message           json.RawMessage `json:"MESSAGE"`
+}
+
+// message returns the entry's message. journald exports messages that are
+// not valid UTF-8 as arrays of bytes.
+func (e journalEntry) message() (string, bool) {
+	var text string
+	if json.Unmarshal(e.Message, &text) == nil {
+		return text, true
+	}
+	var raw []byte
+	var values []int
+	if json.Unmarshal(e.Message, &values) != nil {
+		return "", false
+	}
+	for _, v := range values {
+		raw = append(raw, byte(v))
+	}
+	return string(raw), true
+}
+
+// ReadJournalMetrics extracts metric points from the output of
+// `journalctl --output=json` and passes them to fn as they are read. Points
+// are stamped with the entry's time unless a pattern captures a timestamp.
+// Without an extractor, messages that are JSON metric records (see
+// ParseJSONLMetricLine) are used. Errors of fn are returned as they are.
+func ReadJournalMetrics(reader io.Reader, extractor *LineExtractor, fn func(models.MetricPoint) error) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		var entry journalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("failed to parse journal entry %d: %w", lineNumber, err)
+		}
+		message, ok := entry.message()
+		if !ok {
+			continue
+		}
+
+		point, ok, err := extractMessage(message, extractor)
+		if err != nil {
+			return fmt.Errorf("journal entry %d: %w", lineNumber, err)
+		}
+		if !ok {
+			continue
+		}
+
+		if point.Timestamp == nil && point.Offset == nil {
+			usec, err := strconv.ParseInt(entry.RealtimeTimestamp, 10, 64)
+			if err != nil {
+				return fmt.Errorf("journal entry %d: invalid timestamp: %s", lineNumber, entry.RealtimeTimestamp)
+			}
+			t := time.UnixMicro(usec).UTC()
+			point.Timestamp = &t
+		}
+		if err := fn(point); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	return nil
+}
+
+var (
+	// RFC 5424 (<34>1 2025-06-07T14:01:00.123Z host app ...) or RFC 3339 timestamps
+	// as written by rsyslog's high precision format
+	rfc5424Pattern = regexp.MustCompile(`^(<\d+>\d+ )?(\d{4}-\d{2}-\d{2}T\S+) (.*)$`)
+	// RFC 3164 (<34>Jun  7 14:01:00 host app: ...)
+	rfc3164Pattern = regexp.MustCompile(`^(?:<\d+>)?([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}) (.*)$`)
+
+	// RFC 5424 header after the timestamp: host app procid msgid, structured
+	// data (- or [...] elements) and the message
+	rfc5424HeaderPattern = regexp.MustCompile(`^\S+ \S+ \S+ \S+ (?:-|(?:\[(?:[^\]\\]|\\.)*\])+)(?: (?:\x{FEFF})?(.*))?$`)
+	// Traditional header after the timestamp: host app[pid]: message
+	syslogTagPattern = regexp.MustCompile(`^\S+ [^\s:\[]+(?:\[[^\]]*\])?: ?(.*)$`)
+)
+
+// ReadSyslogMetrics extracts metric points from syslog lines in RFC 5424,
+// RFC 3339 prefixed or traditional RFC 3164 format. RFC 3164 timestamps have
+// no year or zone: they are taken in loc and in the year before now if they
+// would otherwise lie more than a day in the future. Lines without a
+// recognized timestamp are skipped. Patterns of the extractor are matched
+// against the line after the timestamp; without an extractor, the message
+// after the host, app and pid header must be a JSON metric record. Points are
+// passed to fn as they are read; errors of fn are returned as they are. A log
+// with lines but no metrics is an error, as it is most likely not in a
+// recognized format.
+func ReadSyslogMetrics(reader io.Reader, extractor *LineExtractor, loc *time.Location, now time.Time, fn func(models.MetricPoint) error) error {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	lineNumber := 0
+
+	matched, unstamped := 0, 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+
+		timestamp, message, structured, ok := syslogTimestamp(line, loc, now)
+		if !ok {
+			unstamped++
+			continue
+		}
+		if extractor == nil {
+			message = syslogMessage(message, structured)
+		}
+
+		point, ok, err := extractMessage(message, extractor)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if !ok {
+			continue
+		}
+		if point.Timestamp == nil && point.Offset == nil {
+			point.Timestamp = &timestamp
+		}
+		matched++
+		if err := fn(point); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read syslog: %w", err)
+	}
+
+	if matched == 0 && lineNumber > 0 {
+		return fmt.Errorf("no metrics found in %d lines (%d without a recognized syslog timestamp)", lineNumber, unstamped)
+	}
+	return nil
+}
+
+// syslogMessage returns the message of the part of a syslog line after the
+// timestamp, without the host, app and pid header, or the part unchanged if
+// it has no recognized header. structured selects the RFC 5424 header.
+func syslogMessage(rest string, structured bool) string {
+	pattern := syslogTagPattern
+	if structured {
+		pattern = rfc5424HeaderPattern
+	}
+	if match := pattern.FindStringSubmatch(rest); match != nil {
+		return match[1]
+	}
+	return rest
+}
+
+// syslogTimestamp splits a syslog line into its timestamp and the rest;
+// structured is true for RFC 5424 lines, whose header differs
+func syslogTimestamp(line string, loc *time.Location, now time.Time) (t time.Time, rest string, structured, ok bool) {
+	if match := rfc5424Pattern.FindStringSubmatch(line); match != nil {
+		t, err := time.Parse(time.RFC3339Nano, match[2])
+		if err != nil {
+			return time.Time{}, "", false, false
+		}
+		return t, match[3], match[1] != "", true
+	}
+
+	if match := rfc3164Pattern.FindStringSubmatch(line); match != nil {
+		stamp, err := time.Parse(time.Stamp, match[1])
+		if err != nil {
+			return time.Time{}, "", false, false
+		}
+		now = now.In(loc)
+		t := time.Date(now.Year(), stamp.Month(), stamp.Day(), stamp.Hour(), stamp.Minute(), stamp.Second(), 0, loc)
+		if t.Sub(now) > 24*time.Hour {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t, match[2], false, true
+	}
+
+	return time.Time{}, "", false, false
+}
@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+	timeutils "github.com/imishinist/mlflow-cli/internal/time"
+	"github.com/imishinist/mlflow-cli/internal/units"
+)
+
+// Reserved metric point fields that are not treated as metric names
+const (
+	timestampField = "timestamp"
+	stepField      = "step"
+)
+
+// rawMetricsFile is the schema-less representation of JSON/YAML metrics files
+type rawMetricsFile struct {
+	Version int                      `json:"version" yaml:"version"`
+	Mapping map[string]string        `json:"mapping" yaml:"mapping"`
+	Metrics []map[string]interface{} `json:"metrics" yaml:"metrics"`
+}
+
+// convertMetricsFile converts decoded fields into metric points
+func convertMetricsFile(raw rawMetricsFile) (*models.MetricsFile, error) {
+	data := &models.MetricsFile{
+		Version: raw.Version,
+		Mapping: raw.Mapping,
+	}
+
+	for i, fields := range raw.Metrics {
+		point, err := convertMetricPoint(fields)
+		if err != nil {
+			return nil, fmt.Errorf("metrics[%d]: %w", i, err)
+		}
+		data.Metrics = append(data.Metrics, point)
+	}
+
+	ApplyMetricMapping(data, raw.Mapping)
+	return data, nil
+}
+
+// convertMetricPoint builds a metric point from decoded fields. Non-numeric
+// fields other than timestamp and step are ignored.
+func convertMetricPoint(fields map[string]interface{}) (models.MetricPoint, error) {
+	point := models.MetricPoint{Values: make(map[string]float64)}
+
+	for name, value := range fields {
+		switch name {
+		case timestampField:
+			if err := setTimestamp(&point, value); err != nil {
+				return point, err
+			}
+		case stepField:
+			step, ok := toFloat(value)
+			if !ok {
+				return point, fmt.Errorf("invalid step: %v", value)
+			}
+			s := int64(step)
+			point.Step = &s
+		default:
+			if v, ok := toFloat(value); ok {
+				point.Values[name] = v
+			}
+		}
+	}
+
+	return point, nil
+}
+
+// ApplyMetricMapping renames metric fields of every point according to
+// mapping (field -> metric key). Fields mapped to an empty key are dropped.
+func ApplyMetricMapping(data *models.MetricsFile, mapping map[string]string) {
+	if len(mapping) == 0 {
+		return
+	}
+
+	for i := range data.Metrics {
+		data.Metrics[i] = MapMetricPoint(data.Metrics[i], mapping)
+	}
+}
+
+// MapMetricPoint returns the point with its fields renamed like
+// ApplyMetricMapping does
+func MapMetricPoint(point models.MetricPoint, mapping map[string]string) models.MetricPoint {
+	if len(mapping) == 0 {
+		return point
+	}
+
+	values := make(map[string]float64, len(point.Values))
+	for name, value := range point.Values {
+		key, mapped := mapping[name]
+		if !mapped {
+			key = name
+		}
+		if key == "" {
+			continue
+		}
+		values[key] = value
+	}
+	point.Values = values
+	return point
+}
+
+// setTimestamp sets the timestamp of a point from a decoded value: an ISO8601
+// string or YAML timestamp, Unix epoch seconds/milliseconds, or an offset like
+// "+30s" relative to the base time
+func setTimestamp(point *models.MetricPoint, value interface{}) error {
+	switch v := value.(type) {
+	case time.Time:
+		point.Timestamp = &v
+		return nil
+	case string:
+		if strings.HasPrefix(v, "+") {
+			offset, err := units.ParseDuration(v[1:])
+			if err != nil {
+				return fmt.Errorf("invalid relative timestamp: %s (expected e.g. +30s)", v)
+			}
+			point.Offset = &offset
+			return nil
+		}
+		t, err := timeutils.ParseTime(v)
+		if err != nil {
+			return err
+		}
+		point.Timestamp = &t
+		point.Naive = timeutils.IsNaiveTime(v)
+		return nil
+	}
+
+	if epoch, ok := toFloat(value); ok {
+		t := timeutils.FromEpoch(epoch)
+		point.Timestamp = &t
+		return nil
+	}
+	return fmt.Errorf("invalid timestamp: %v", value)
+}
+
+// toFloat converts decoded JSON/YAML numbers to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LatestMetricsVersion is the current metrics file schema version.
+//
+// Versions:
+//
+//	1: fixed execution_time, success_rate and error_count fields (no version key)
+//	2: every numeric field is a metric, optional mapping section
+const LatestMetricsVersion = 2
+
+// metricsMigrations upgrade a decoded metrics file from the keyed version to
+// the next one. They return notes about changes that alter what is logged.
+var metricsMigrations = map[int]func(doc map[string]interface{}) ([]string, error){
+	1: migrateMetricsV1ToV2,
+}
+
+// ParseSchemaVersion parses a schema version such as v2 or 2
+func ParseSchemaVersion(value string) (int, error) {
+	version, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(value), "v"))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid schema version: %s (expected e.g. v1)", value)
+	}
+	return version, nil
+}
+
+// MigrateMetrics converts a decoded JSON/YAML metrics file from one schema
+// version to a later one in place, applying each intermediate migration
+func MigrateMetrics(doc map[string]interface{}, from, to int) ([]string, error) {
+	if to > LatestMetricsVersion {
+		return nil, fmt.Errorf("unknown metrics schema version v%d (latest: v%d)", to, LatestMetricsVersion)
+	}
+	if from > to {
+		return nil, fmt.Errorf("cannot migrate from v%d down to v%d", from, to)
+	}
+
+	// Version 1 files have no version key
+	if declared, ok := toFloat(doc["version"]); ok && int(declared) != from {
+		return nil, fmt.Errorf("file declares version %d, not v%d", int(declared), from)
+	}
+
+	var notes []string
+	for version := from; version < to; version++ {
+		migrate, ok := metricsMigrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from metrics schema v%d", version)
+		}
+		migrationNotes, err := migrate(doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate from v%d to v%d: %w", version, version+1, err)
+		}
+		notes = append(notes, migrationNotes...)
+	}
+	return notes, nil
+}
+
+// migrateMetricsV1ToV2 keeps what version 1 logged: execution_time and
+// success_rate only when non-zero, error_count always (0 when missing), and
+// no other fields
+func migrateMetricsV1ToV2(doc map[string]interface{}) ([]string, error) {
+	points, ok := doc["metrics"].([]interface{})
+	if !ok && doc["metrics"] != nil {
+		return nil, fmt.Errorf("metrics must be a list")
+	}
+
+	dropped := make(map[string]bool)
+	for i, item := range points {
+		point, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("metrics[%d]: expected a mapping", i)
+		}
+
+		for name, value := range point {
+			switch name {
+			case timestampField, stepField, "error_count":
+			case "execution_time", "success_rate":
+				if v, ok := toFloat(value); !ok || v == 0 {
+					delete(point, name)
+				}
+			default:
+				dropped[name] = true
+				delete(point, name)
+			}
+		}
+		if _, ok := point["error_count"]; !ok {
+			point["error_count"] = 0
+		}
+	}
+
+	doc["version"] = 2
+
+	var notes []string
+	if len(dropped) > 0 {
+		names := make([]string, 0, len(dropped))
+		for name := range dropped {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		notes = append(notes, fmt.Sprintf("dropped fields ignored by v1 (v2 would log them): %s", strings.Join(names, ", ")))
+	}
+	return notes, nil
+}
@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// ParseCSVTable parses a CSV file whose header row contains the column names.
+// Numeric and boolean cells become numbers and booleans, empty cells null.
+func ParseCSVTable(reader io.Reader) (*models.Table, error) {
+	csvReader := csv.NewReader(reader)
+
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to parse CSV table: missing header row")
+		}
+		return nil, fmt.Errorf("failed to parse CSV table: %w", err)
+	}
+
+	table := &models.Table{Columns: header, Data: [][]interface{}{}}
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV table: %w", err)
+		}
+
+		row := make([]interface{}, len(record))
+		for i, cell := range record {
+			row[i] = csvCellValue(cell)
+		}
+		table.Data = append(table.Data, row)
+	}
+
+	return table, nil
+}
+
+// csvCellValue returns the JSON value of a CSV cell
+func csvCellValue(cell string) interface{} {
+	trimmed := strings.TrimSpace(cell)
+	if trimmed == "" {
+		return nil
+	}
+	// JSON has no NaN or infinities, and accepts fewer number forms than Go
+	if _, err := strconv.ParseFloat(trimmed, 64); err == nil && json.Valid([]byte(trimmed)) {
+		return json.Number(trimmed)
+	}
+	switch strings.ToLower(trimmed) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return cell
+}
+
+// ParseJSONTable parses a table given as {"columns": [...], "data": [[...]]},
+// as written by pandas with orient="split", or as an array of records. The
+// columns of records are ordered by first appearance; missing cells are null.
+func ParseJSONTable(reader io.Reader) (*models.Table, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON table: %w", err)
+	}
+	content = bytes.TrimSpace(content)
+
+	if !bytes.HasPrefix(content, []byte("[")) {
+		var table models.Table
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		if err := decoder.Decode(&table); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON table: %w", err)
+		}
+		if len(table.Columns) == 0 {
+			return nil, fmt.Errorf("failed to parse JSON table: missing columns")
+		}
+		for i, row := range table.Data {
+			if len(row) != len(table.Columns) {
+				return nil, fmt.Errorf("failed to parse JSON table: row %d has %d cells, expected %d", i+1, len(row), len(table.Columns))
+			}
+		}
+		if table.Data == nil {
+			table.Data = [][]interface{}{}
+		}
+		return &table, nil
+	}
+
+	var records []json.RawMessage
+	if err := json.Unmarshal(content, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON table: %w", err)
+	}
+
+	table := &models.Table{Data: [][]interface{}{}}
+	columnIndex := make(map[string]int)
+	rows := make([]map[string]interface{}, 0, len(records))
+	for i, record := range records {
+		keys, err := objectKeys(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JSON table: record %d: %w", i+1, err)
+		}
+		for _, key := range keys {
+			if _, ok := columnIndex[key]; !ok {
+				columnIndex[key] = len(table.Columns)
+				table.Columns = append(table.Columns, key)
+			}
+		}
+
+		var row map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(record))
+		decoder.UseNumber()
+		if err := decoder.Decode(&row); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON table: record %d: %w", i+1, err)
+		}
+		rows = append(rows, row)
+	}
+
+	for _, row := range rows {
+		cells := make([]interface{}, len(table.Columns))
+		for key, value := range row {
+			cells[columnIndex[key]] = value
+		}
+		table.Data = append(table.Data, cells)
+	}
+	return table, nil
+}
+
+// objectKeys returns the keys of a JSON object in document order
+func objectKeys(object json.RawMessage) ([]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(object))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("expected an object")
+	}
+
+	var keys []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, token.(string))
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// envReferencePattern matches ${VAR} references in tag values
+var envReferencePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// convertTagsFile flattens the tags of a tags file into dotted keys and
+// expands ${VAR} environment variable references in their values
+func convertTagsFile(data models.TagsFile) (map[string]string, error) {
+	tags, err := FlattenParams(data.Tags, DefaultFlattenOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to flatten tags: %w", err)
+	}
+	if err := ExpandEnvValues(tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// ExpandEnvValues replaces ${VAR} references in the values of m with the
+// values of environment variables. A reference to an unset variable is an
+// error, so that a typo never ends up in a tag; other $ characters are kept.
+func ExpandEnvValues(m map[string]string) error {
+	// Sorted for deterministic errors
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var missing string
+		m[key] = envReferencePattern.ReplaceAllStringFunc(m[key], func(reference string) string {
+			name := envReferencePattern.FindStringSubmatch(reference)[1]
+			value, ok := os.LookupEnv(name)
+			if !ok && missing == "" {
+				missing = name
+			}
+			return value
+		})
+		if missing != "" {
+			return fmt.Errorf("value of %s references unset environment variable %s", key, missing)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ParseTOMLParams parses a TOML file into parameters. Parameters are read from
+// the [parameters] table if there is one, otherwise from the whole document.
+// Nested tables are flattened according to opts.
+func ParseTOMLParams(reader io.Reader, opts FlattenOptions) (map[string]string, error) {
+	var doc map[string]interface{}
+	decoder := toml.NewDecoder(reader)
+
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse TOML parameters: %w", err)
+	}
+
+	return flattenParamsDocument(doc, opts)
+}
@@ -21,12 +21,23 @@ func ParseYAMLParams(reader io.Reader) (map[string]string, error) {
 }
 
 func ParseYAMLMetrics(reader io.Reader) (*models.MetricsFile, error) {
-	var data models.MetricsFile
+	var data rawMetricsFile
 	decoder := yaml.NewDecoder(reader)
 
 	if err := decoder.Decode(&data); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML metrics: %w", err)
 	}
 
-	return &data, nil
+	return convertMetricsFile(data)
+}
+
+func ParseYAMLTags(reader io.Reader) (map[string]string, error) {
+	var data models.TagsFile
+	decoder := yaml.NewDecoder(reader)
+
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML tags: %w", err)
+	}
+
+	return convertTagsFile(data)
 }
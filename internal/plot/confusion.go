@@ -0,0 +1,117 @@
+package plot
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// Layout of confusion matrices: cells shrink with more labels to keep the
+// matrix about 360 pixels wide, and labels are shortened to maxLabelLength
+// characters
+const (
+	matrixSize     = 360
+	minCellSize    = 24
+	maxCellSize    = 80
+	maxLabelLength = 16
+	// charWidth approximates the width of a label character
+	charWidth = 7
+)
+
+// ConfusionMatrix renders a confusion matrix as a heatmap with the count of
+// each cell, true labels as rows and predicted labels as columns, like
+// sklearn's ConfusionMatrixDisplay
+func ConfusionMatrix(matrix *models.ConfusionMatrix) []byte {
+	n := len(matrix.Labels)
+	cell := math.Min(maxCellSize, math.Max(minCellSize, matrixSize/float64(n)))
+
+	labels := make([]string, n)
+	labelWidth := 0.0
+	for i, label := range matrix.Labels {
+		labels[i] = shorten(label, maxLabelLength)
+		labelWidth = math.Max(labelWidth, float64(len([]rune(labels[i])))*charWidth)
+	}
+	// Predicted labels wider than their column are turned
+	turned := labelWidth > cell-4
+
+	left := 36 + labelWidth + 8
+	top := 40.0
+	bottom := 16 + 40.0
+	if turned {
+		bottom = 16 + labelWidth*0.75 + 28
+	}
+	width := left + cell*float64(n) + 24
+	height := top + cell*float64(n) + bottom
+
+	var maxCount float64
+	for _, row := range matrix.Counts {
+		for _, count := range row {
+			maxCount = math.Max(maxCount, count)
+		}
+	}
+
+	s := newSVG(width, height)
+	s.text(width/2, 24, 14, "middle", `font-weight="bold"`, "Confusion matrix")
+	for i, row := range matrix.Counts {
+		for j, count := range row {
+			x, y := left+float64(j)*cell, top+float64(i)*cell
+			shade := 0.0
+			if maxCount > 0 {
+				shade = count / maxCount
+			}
+			s.rect(x, y, cell, cell, fmt.Sprintf(`fill="%s" stroke="white"`, blues(shade)))
+			textColor := "#000000"
+			if shade > 0.5 {
+				textColor = "#ffffff"
+			}
+			s.text(x+cell/2, y+cell/2+4, fontSize, "middle", fmt.Sprintf(`fill="%s"`, textColor), formatCount(count))
+		}
+	}
+
+	bottomEdge := top + cell*float64(n)
+	for i, label := range labels {
+		center := float64(i)*cell + cell/2
+		s.text(left-6, top+center+4, fontSize, "end", "", label)
+		if turned {
+			x, y := left+center+4, bottomEdge+14
+			s.text(x, y, fontSize, "end", fmt.Sprintf(`transform="rotate(-45 %s %s)"`, num(x), num(y)), label)
+		} else {
+			s.text(left+center, bottomEdge+16, fontSize, "middle", "", label)
+		}
+	}
+	s.text(left+cell*float64(n)/2, height-12, fontSize, "middle", "", "Predicted label")
+	s.rotatedText(18, top+cell*float64(n)/2, "True label")
+	return s.bytes()
+}
+
+// blues returns the color of a shade between 0 and 1 on the white to dark
+// blue scale of matplotlib's Blues colormap
+func blues(shade float64) string {
+	light := [3]float64{0xf7, 0xfb, 0xff}
+	dark := [3]float64{0x08, 0x30, 0x6b}
+	var rgb [3]int
+	for i := range rgb {
+		rgb[i] = int(math.Round(light[i] + (dark[i]-light[i])*shade))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+}
+
+// formatCount formats whole counts as integers and others, such as
+// normalized rates, with 2 decimals
+func formatCount(count float64) string {
+	if count == math.Trunc(count) && count < 1e15 {
+		return strconv.FormatFloat(count, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(count, 'f', 2, 64)
+}
+
+// shorten cuts text longer than length characters, ending it with an ellipsis
+func shorten(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-1]) + "…"
+}
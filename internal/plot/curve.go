@@ -0,0 +1,58 @@
+package plot
+
+import (
+	"fmt"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// Layout of curve charts: a square plot area with axis labels left of and
+// below it
+const (
+	curveLeft   = 64
+	curveTop    = 40
+	curveSize   = 360
+	curveRight  = 24
+	curveBottom = 52
+)
+
+// curveLabels are the title and axis titles of each curve type
+var curveLabels = map[models.CurveType]struct{ title, x, y string }{
+	models.CurveROC: {"ROC curve", "False positive rate", "True positive rate"},
+	models.CurvePR:  {"Precision-recall curve", "Recall", "Precision"},
+}
+
+// Curve renders a ROC or precision-recall curve with the area under the curve
+// in its title. ROC curves are drawn with the diagonal of a random classifier.
+func Curve(curve *models.Curve) []byte {
+	labels := curveLabels[curve.Type]
+	width := float64(curveLeft + curveSize + curveRight)
+	height := float64(curveTop + curveSize + curveBottom)
+	px := func(x float64) float64 { return curveLeft + x*curveSize }
+	py := func(y float64) float64 { return curveTop + (1-y)*curveSize }
+
+	s := newSVG(width, height)
+	s.text(width/2, 24, 14, "middle", `font-weight="bold"`, fmt.Sprintf("%s (AUC = %.4f)", labels.title, curve.AUC()))
+
+	// Grid and ticks every 0.2
+	for i := 0; i <= 5; i++ {
+		value := float64(i) / 5
+		s.line(px(value), py(0), px(value), py(1), `stroke="#e0e0e0"`)
+		s.line(px(0), py(value), px(1), py(value), `stroke="#e0e0e0"`)
+		s.text(px(value), py(0)+16, fontSize, "middle", "", fmt.Sprintf("%.1f", value))
+		s.text(px(0)-6, py(value)+4, fontSize, "end", "", fmt.Sprintf("%.1f", value))
+	}
+	s.rect(px(0), py(1), curveSize, curveSize, `fill="none" stroke="#333333"`)
+	s.text(px(0.5), py(0)+40, fontSize, "middle", "", labels.x)
+	s.rotatedText(18, py(0.5), labels.y)
+
+	if curve.Type == models.CurveROC {
+		s.line(px(0), py(0), px(1), py(1), `stroke="#999999" stroke-dasharray="6 4"`)
+	}
+	var points [][2]float64
+	for _, point := range curve.SortedPoints() {
+		points = append(points, [2]float64{px(point.X), py(point.Y)})
+	}
+	s.polyline(points, `fill="none" stroke="#1f77b4" stroke-width="2"`)
+	return s.bytes()
+}
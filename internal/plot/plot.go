@@ -0,0 +1,76 @@
+// Package plot renders classifier evaluation charts, such as ROC curves and
+// confusion matrices, as SVG images, which the artifact view of the MLflow UI
+// displays like the PNG charts of mlflow.evaluate.
+package plot
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+)
+
+// fontSize is the size of labels; titles are larger
+const fontSize = 12
+
+// svg accumulates the elements of an SVG image
+type svg struct {
+	buf bytes.Buffer
+}
+
+// newSVG starts an image of width x height pixels on a white background
+func newSVG(width, height float64) *svg {
+	s := &svg{}
+	fmt.Fprintf(&s.buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s">`+"\n",
+		num(width), num(height), num(width), num(height))
+	s.rect(0, 0, width, height, `fill="white"`)
+	return s
+}
+
+func (s *svg) rect(x, y, width, height float64, attrs string) {
+	fmt.Fprintf(&s.buf, `<rect x="%s" y="%s" width="%s" height="%s" %s/>`+"\n", num(x), num(y), num(width), num(height), attrs)
+}
+
+func (s *svg) line(x1, y1, x2, y2 float64, attrs string) {
+	fmt.Fprintf(&s.buf, `<line x1="%s" y1="%s" x2="%s" y2="%s" %s/>`+"\n", num(x1), num(y1), num(x2), num(y2), attrs)
+}
+
+// polyline connects points given as x, y pairs
+func (s *svg) polyline(points [][2]float64, attrs string) {
+	s.buf.WriteString(`<polyline points="`)
+	for i, point := range points {
+		if i > 0 {
+			s.buf.WriteByte(' ')
+		}
+		s.buf.WriteString(num(point[0]) + "," + num(point[1]))
+	}
+	fmt.Fprintf(&s.buf, `" %s/>`+"\n", attrs)
+}
+
+// text writes text of a font size anchored at x, y: anchor is start, middle
+// or end
+func (s *svg) text(x, y, size float64, anchor, attrs, text string) {
+	if attrs != "" {
+		attrs = " " + attrs
+	}
+	fmt.Fprintf(&s.buf, `<text x="%s" y="%s" font-family="sans-serif" font-size="%s" text-anchor="%s"%s>%s</text>`+"\n",
+		num(x), num(y), num(size), anchor, attrs, html.EscapeString(text))
+}
+
+// rotatedText writes text turned counterclockwise by 90 degrees, centered at
+// x, y, such as the title of a vertical axis
+func (s *svg) rotatedText(x, y float64, text string) {
+	s.text(x, y, fontSize, "middle", fmt.Sprintf(`transform="rotate(-90 %s %s)"`, num(x), num(y)), text)
+}
+
+// bytes ends the image and returns it
+func (s *svg) bytes() []byte {
+	s.buf.WriteString("</svg>\n")
+	return s.buf.Bytes()
+}
+
+// num formats a coordinate with at most 2 decimals
+func num(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
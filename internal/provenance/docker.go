@@ -0,0 +1,115 @@
+package provenance
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Docker tag keys, matching the tags recorded by MLflow Projects
+const (
+	TagDockerImageURI    = "mlflow.docker.image.uri"
+	TagDockerImageDigest = "mlflow.docker.image.digest"
+	TagDockerContainerID = "mlflow.docker.container.id"
+)
+
+// imageEnvVars are environment variables commonly used to pass the image
+// reference into a container, in order of preference
+var imageEnvVars = []string{
+	"MLFLOW_DOCKER_IMAGE",
+	"DOCKER_IMAGE",
+	"CONTAINER_IMAGE",
+	"IMAGE_NAME",
+	"IMAGE",
+}
+
+// digestEnvVars are environment variables commonly used to pass the image digest
+var digestEnvVars = []string{
+	"MLFLOW_DOCKER_IMAGE_DIGEST",
+	"DOCKER_IMAGE_DIGEST",
+	"IMAGE_DIGEST",
+}
+
+var (
+	digestPattern      = regexp.MustCompile(`sha256:[0-9a-f]{64}`)
+	containerIDPattern = regexp.MustCompile(`[0-9a-f]{64}`)
+)
+
+// DockerTags detects whether the CLI runs inside a container and returns the
+// image reference, digest and container ID as run tags. It returns an empty
+// map outside a container.
+func DockerTags() map[string]string {
+	tags := make(map[string]string)
+	if !inContainer() {
+		return tags
+	}
+
+	image := firstEnv(imageEnvVars)
+	if image != "" {
+		tags[TagDockerImageURI] = image
+	}
+
+	// Prefer an explicit digest, then a digest pinned in the image reference
+	if digest := firstEnv(digestEnvVars); digest != "" {
+		tags[TagDockerImageDigest] = digest
+	} else if digest := digestPattern.FindString(image); digest != "" {
+		tags[TagDockerImageDigest] = digest
+	}
+
+	if id := containerID(); id != "" {
+		tags[TagDockerContainerID] = id
+	}
+
+	return tags
+}
+
+// inContainer reports whether the process runs inside a Docker/OCI container
+func inContainer() bool {
+	if _, err := os.Stat("/.dockerenv"); err == nil {
+		return true
+	}
+	if _, err := os.Stat("/run/.containerenv"); err == nil {
+		return true
+	}
+
+	data, err := os.ReadFile("/proc/1/cgroup")
+	if err != nil {
+		return false
+	}
+	cgroup := string(data)
+	return strings.Contains(cgroup, "docker") ||
+		strings.Contains(cgroup, "containerd") ||
+		strings.Contains(cgroup, "kubepods")
+}
+
+// containerID extracts the container ID from /proc cgroup or mount information
+func containerID() string {
+	for _, path := range []string{"/proc/self/cgroup", "/proc/self/mountinfo"} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			// Only consider lines referring to container runtimes to avoid
+			// matching unrelated hashes
+			if !strings.Contains(line, "docker") && !strings.Contains(line, "containerd") &&
+				!strings.Contains(line, "kubepods") && !strings.Contains(line, "containers") {
+				continue
+			}
+			if id := containerIDPattern.FindString(line); id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// firstEnv returns the first non-empty value among the environment variables
+func firstEnv(names []string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package provenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+)
+
+// EnvironmentArtifactDir is the artifact directory used for environment snapshots
+const EnvironmentArtifactDir = "environment"
+
+// SystemSnapshot describes the OS and the build of the CLI itself
+type SystemSnapshot struct {
+	OS        string            `json:"os"`
+	Arch      string            `json:"arch"`
+	Hostname  string            `json:"hostname,omitempty"`
+	NumCPU    int               `json:"num_cpu"`
+	GoVersion string            `json:"go_version"`
+	Module    string            `json:"module,omitempty"`
+	Version   string            `json:"version,omitempty"`
+	Settings  map[string]string `json:"build_settings,omitempty"`
+}
+
+// snapshotCommand is an external tool whose output is captured into a file
+type snapshotCommand struct {
+	fileName string
+	name     string
+	args     []string
+}
+
+var snapshotCommands = []snapshotCommand{
+	{fileName: "requirements.txt", name: "pip", args: []string{"freeze"}},
+	{fileName: "conda.yaml", name: "conda", args: []string{"env", "export"}},
+}
+
+// CaptureEnvironment writes environment snapshot files into dir and returns
+// their paths. pip and conda snapshots are included when the tools are on PATH;
+// a system.json snapshot is always written.
+func CaptureEnvironment(dir string) ([]string, error) {
+	var files []string
+
+	for _, snapshot := range snapshotCommands {
+		if _, err := exec.LookPath(snapshot.name); err != nil {
+			continue
+		}
+
+		output, err := exec.Command(snapshot.name, snapshot.args...).Output()
+		if err != nil {
+			// A broken tool installation should not prevent the other snapshots
+			continue
+		}
+
+		path := filepath.Join(dir, snapshot.fileName)
+		if err := os.WriteFile(path, output, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", snapshot.fileName, err)
+		}
+		files = append(files, path)
+	}
+
+	data, err := json.MarshalIndent(captureSystem(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode system snapshot: %w", err)
+	}
+
+	path := filepath.Join(dir, "system.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write system.json: %w", err)
+	}
+	files = append(files, path)
+
+	return files, nil
+}
+
+// captureSystem collects OS information and the Go build info of the binary
+func captureSystem() SystemSnapshot {
+	snapshot := SystemSnapshot{
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		NumCPU:    runtime.NumCPU(),
+		GoVersion: runtime.Version(),
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		snapshot.Hostname = hostname
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		snapshot.Module = info.Main.Path
+		snapshot.Version = info.Main.Version
+		snapshot.Settings = make(map[string]string)
+		for _, setting := range info.Settings {
+			snapshot.Settings[setting.Key] = setting.Value
+		}
+	}
+
+	return snapshot
+}
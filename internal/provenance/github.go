@@ -0,0 +1,129 @@
+package provenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GitHub tag keys linking a run to the pull request it was run for
+const (
+	TagGitHubRepository = "github.repository"
+	TagGitHubPRNumber   = "github.pr_number"
+	TagGitHubPRURL      = "github.pr_url"
+)
+
+// pullRequestRefPattern matches the refs GitHub Actions checks out for pull requests
+var pullRequestRefPattern = regexp.MustCompile(`^refs/pull/(\d+)/(merge|head)$`)
+
+// PullRequest identifies a GitHub pull request
+type PullRequest struct {
+	Repository string // owner/name
+	Number     int
+	URL        string
+	APIURL     string // REST API base URL, e.g. https://api.github.com
+}
+
+// DetectPullRequest returns the pull request a GitHub Actions workflow runs
+// for, or nil outside a pull request workflow
+func DetectPullRequest() *PullRequest {
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		return nil
+	}
+	repository := os.Getenv("GITHUB_REPOSITORY")
+	if repository == "" {
+		return nil
+	}
+
+	number := 0
+	if match := pullRequestRefPattern.FindStringSubmatch(os.Getenv("GITHUB_REF")); match != nil {
+		number, _ = strconv.Atoi(match[1])
+	} else {
+		number = eventPullRequestNumber(os.Getenv("GITHUB_EVENT_PATH"))
+	}
+	if number == 0 {
+		return nil
+	}
+
+	serverURL := envOrDefault("GITHUB_SERVER_URL", "https://github.com")
+	return &PullRequest{
+		Repository: repository,
+		Number:     number,
+		URL:        fmt.Sprintf("%s/%s/pull/%d", strings.TrimSuffix(serverURL, "/"), repository, number),
+		APIURL:     strings.TrimSuffix(envOrDefault("GITHUB_API_URL", "https://api.github.com"), "/"),
+	}
+}
+
+// PullRequestFromTags returns the pull request recorded in run tags, or nil
+func PullRequestFromTags(tags map[string]string) *PullRequest {
+	number, err := strconv.Atoi(tags[TagGitHubPRNumber])
+	if err != nil || tags[TagGitHubRepository] == "" {
+		return nil
+	}
+	return &PullRequest{
+		Repository: tags[TagGitHubRepository],
+		Number:     number,
+		URL:        tags[TagGitHubPRURL],
+		APIURL:     strings.TrimSuffix(envOrDefault("GITHUB_API_URL", "https://api.github.com"), "/"),
+	}
+}
+
+// GitHubTags returns tags linking the run to the pull request of a GitHub
+// Actions workflow. It returns an empty map outside a pull request workflow.
+func GitHubTags() map[string]string {
+	tags := make(map[string]string)
+	pr := DetectPullRequest()
+	if pr == nil {
+		return tags
+	}
+
+	tags[TagGitHubRepository] = pr.Repository
+	tags[TagGitHubPRNumber] = strconv.Itoa(pr.Number)
+	tags[TagGitHubPRURL] = pr.URL
+	return tags
+}
+
+// eventPullRequestNumber reads the pull request number from the workflow's
+// event payload (e.g. for pull_request_target or issue_comment events)
+func eventPullRequestNumber(path string) int {
+	if path == "" {
+		return 0
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+
+	var event struct {
+		PullRequest *struct {
+			Number int `json:"number"`
+		} `json:"pull_request"`
+		Issue *struct {
+			Number      int       `json:"number"`
+			PullRequest *struct{} `json:"pull_request"`
+		} `json:"issue"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return 0
+	}
+
+	if event.PullRequest != nil {
+		return event.PullRequest.Number
+	}
+	// Comments on pull requests are issue_comment events
+	if event.Issue != nil && event.Issue.PullRequest != nil {
+		return event.Issue.Number
+	}
+	return 0
+}
+
+// envOrDefault returns the environment variable or a default if it is unset
+func envOrDefault(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
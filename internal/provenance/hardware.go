@@ -0,0 +1,170 @@
+package provenance
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// HardwareArtifactPath is the artifact path of the hardware inventory
+const HardwareArtifactPath = "hardware.json"
+
+// Hardware tag keys summarizing the hardware inventory
+const (
+	TagHardwareCPUModel    = "hardware.cpu_model"
+	TagHardwareCPUCount    = "hardware.cpu_count"
+	TagHardwareMemoryGB    = "hardware.memory_gb"
+	TagHardwareGPUCount    = "hardware.gpu_count"
+	TagHardwareGPUModel    = "hardware.gpu_model"
+	TagHardwareGPUDriver   = "hardware.gpu_driver"
+	TagHardwareCUDAVersion = "hardware.cuda_version"
+)
+
+// Hardware describes the machine a run is executed on
+type Hardware struct {
+	CPUModel    string `json:"cpu_model,omitempty"`
+	CPUCount    int    `json:"cpu_count"`
+	MemoryBytes uint64 `json:"memory_bytes,omitempty"`
+	GPUs        []GPU  `json:"gpus,omitempty"`
+	GPUDriver   string `json:"gpu_driver,omitempty"`
+	CUDAVersion string `json:"cuda_version,omitempty"`
+}
+
+// GPU describes a single GPU reported by nvidia-smi
+type GPU struct {
+	Index         int    `json:"index"`
+	Name          string `json:"name"`
+	MemoryTotalMB int64  `json:"memory_total_mb,omitempty"`
+}
+
+var cudaVersionPattern = regexp.MustCompile(`CUDA Version:\s*([0-9.]+)`)
+
+// DetectHardware collects the hardware inventory of the current machine.
+// Components that cannot be detected are left empty.
+func DetectHardware() *Hardware {
+	hw := &Hardware{
+		CPUModel:    cpuModel(),
+		CPUCount:    runtime.NumCPU(),
+		MemoryBytes: totalMemory(),
+	}
+	detectNvidiaGPUs(hw)
+	return hw
+}
+
+// Tags returns summary tags for the hardware inventory
+func (h *Hardware) Tags() map[string]string {
+	tags := map[string]string{
+		TagHardwareCPUCount: strconv.Itoa(h.CPUCount),
+		TagHardwareGPUCount: strconv.Itoa(len(h.GPUs)),
+	}
+	if h.CPUModel != "" {
+		tags[TagHardwareCPUModel] = h.CPUModel
+	}
+	if h.MemoryBytes > 0 {
+		tags[TagHardwareMemoryGB] = fmt.Sprintf("%.1f", float64(h.MemoryBytes)/(1<<30))
+	}
+	if len(h.GPUs) > 0 {
+		tags[TagHardwareGPUModel] = h.GPUs[0].Name
+	}
+	if h.GPUDriver != "" {
+		tags[TagHardwareGPUDriver] = h.GPUDriver
+	}
+	if h.CUDAVersion != "" {
+		tags[TagHardwareCUDAVersion] = h.CUDAVersion
+	}
+	return tags
+}
+
+// cpuModel returns the CPU model name
+func cpuModel() string {
+	switch runtime.GOOS {
+	case "linux":
+		return procField("/proc/cpuinfo", "model name")
+	case "darwin":
+		return commandOutput("sysctl", "-n", "machdep.cpu.brand_string")
+	}
+	return ""
+}
+
+// totalMemory returns the total physical memory in bytes
+func totalMemory() uint64 {
+	switch runtime.GOOS {
+	case "linux":
+		// MemTotal is reported as "<n> kB"
+		fields := strings.Fields(procField("/proc/meminfo", "MemTotal"))
+		if len(fields) > 0 {
+			if kb, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+				return kb * 1024
+			}
+		}
+	case "darwin":
+		if size, err := strconv.ParseUint(commandOutput("sysctl", "-n", "hw.memsize"), 10, 64); err == nil {
+			return size
+		}
+	}
+	return 0
+}
+
+// detectNvidiaGPUs fills GPU information using nvidia-smi when available
+func detectNvidiaGPUs(hw *Hardware) {
+	if _, err := exec.LookPath("nvidia-smi"); err != nil {
+		return
+	}
+
+	output := commandOutput("nvidia-smi", "--query-gpu=index,name,driver_version,memory.total", "--format=csv,noheader,nounits")
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 4 {
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		index, _ := strconv.Atoi(fields[0])
+		memory, _ := strconv.ParseInt(fields[3], 10, 64)
+		hw.GPUs = append(hw.GPUs, GPU{
+			Index:         index,
+			Name:          fields[1],
+			MemoryTotalMB: memory,
+		})
+		hw.GPUDriver = fields[2]
+	}
+
+	// The CUDA version is only shown in the default nvidia-smi banner
+	if match := cudaVersionPattern.FindStringSubmatch(commandOutput("nvidia-smi")); match != nil {
+		hw.CUDAVersion = match[1]
+	}
+}
+
+// procField returns the value of the first "key: value" line with the given key
+func procField(path, key string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name, value, found := strings.Cut(scanner.Text(), ":")
+		if found && strings.TrimSpace(name) == key {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// commandOutput runs a command and returns its trimmed stdout, or "" on failure
+func commandOutput(name string, args ...string) string {
+	output, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,90 @@
+package provenance
+
+import (
+	"os"
+	"strings"
+)
+
+// HPC tag keys recorded on runs launched by a cluster scheduler
+const (
+	TagHPCScheduler   = "hpc.scheduler"
+	TagHPCJobID       = "hpc.job_id"
+	TagHPCJobName     = "hpc.job_name"
+	TagHPCPartition   = "hpc.partition"
+	TagHPCNodeList    = "hpc.node_list"
+	TagHPCArrayJobID  = "hpc.array_job_id"
+	TagHPCArrayTaskID = "hpc.array_task_id"
+)
+
+// slurmEnv maps Slurm environment variables to HPC tag keys
+var slurmEnv = map[string]string{
+	"SLURM_JOB_ID":        TagHPCJobID,
+	"SLURM_JOB_NAME":      TagHPCJobName,
+	"SLURM_JOB_PARTITION": TagHPCPartition,
+	"SLURM_JOB_NODELIST":  TagHPCNodeList,
+	"SLURM_ARRAY_JOB_ID":  TagHPCArrayJobID,
+	"SLURM_ARRAY_TASK_ID": TagHPCArrayTaskID,
+}
+
+// lsfEnv maps LSF environment variables to HPC tag keys
+var lsfEnv = map[string]string{
+	"LSB_JOBID":    TagHPCJobID,
+	"LSB_JOBNAME":  TagHPCJobName,
+	"LSB_QUEUE":    TagHPCPartition,
+	"LSB_HOSTS":    TagHPCNodeList,
+	"LSB_JOBINDEX": TagHPCArrayTaskID,
+}
+
+// HPCTags detects Slurm or LSF job context from the environment and returns
+// the corresponding run tags. It returns an empty map outside a scheduler job.
+func HPCTags() map[string]string {
+	tags := make(map[string]string)
+
+	switch {
+	case os.Getenv("SLURM_JOB_ID") != "":
+		tags[TagHPCScheduler] = "slurm"
+		collectEnv(tags, slurmEnv)
+		// Older Slurm versions only export SLURM_NODELIST
+		if _, ok := tags[TagHPCNodeList]; !ok {
+			if nodes := os.Getenv("SLURM_NODELIST"); nodes != "" {
+				tags[TagHPCNodeList] = nodes
+			}
+		}
+	case os.Getenv("LSB_JOBID") != "":
+		tags[TagHPCScheduler] = "lsf"
+		collectEnv(tags, lsfEnv)
+		// LSB_HOSTS repeats a host once per slot; collapse to unique hosts
+		if hosts, ok := tags[TagHPCNodeList]; ok {
+			tags[TagHPCNodeList] = uniqueFields(hosts)
+		}
+		// LSB_JOBINDEX is 0 for jobs that are not part of an array
+		if tags[TagHPCArrayTaskID] == "0" {
+			delete(tags, TagHPCArrayTaskID)
+		}
+	}
+
+	return tags
+}
+
+// collectEnv copies non-empty environment variables into tags
+func collectEnv(tags map[string]string, envMap map[string]string) {
+	for env, key := range envMap {
+		if value := os.Getenv(env); value != "" {
+			tags[key] = value
+		}
+	}
+}
+
+// uniqueFields returns the whitespace-separated fields of s without duplicates,
+// joined by commas
+func uniqueFields(s string) string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, field := range strings.Fields(s) {
+		if !seen[field] {
+			seen[field] = true
+			result = append(result, field)
+		}
+	}
+	return strings.Join(result, ",")
+}
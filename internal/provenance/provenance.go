@@ -0,0 +1,21 @@
+// Package provenance detects the execution context of the CLI (scheduler,
+// container, CI) and exposes it as run tags.
+package provenance
+
+// detectors are the context detectors whose tags are merged by Tags
+var detectors = []func() map[string]string{
+	HPCTags,
+	DockerTags,
+	GitHubTags,
+}
+
+// Tags returns all context tags detected from the current environment
+func Tags() map[string]string {
+	tags := make(map[string]string)
+	for _, detect := range detectors {
+		for key, value := range detect() {
+			tags[key] = value
+		}
+	}
+	return tags
+}
@@ -0,0 +1,55 @@
+// Package proxy routes the HTTP requests of the CLI through a proxy: the one
+// given with --proxy, or the one of the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+// environment variables.
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// explicit is the proxy set with Set; nil if requests use the environment
+var explicit func(*url.URL) (*url.URL, error)
+
+// Set routes all requests through the proxy at rawURL, except those to hosts
+// listed in NO_PROXY. It must be called before clients are created, as it
+// changes http.DefaultTransport, which they are built on.
+func Set(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid proxy: %s (expected a URL such as http://proxy.example.com:3128)", rawURL)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("invalid proxy: %s (the scheme must be http, https or socks5)", rawURL)
+	}
+
+	noProxy := os.Getenv("NO_PROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("no_proxy")
+	}
+	explicit = (&httpproxy.Config{HTTPProxy: u.String(), HTTPSProxy: u.String(), NoProxy: noProxy}).ProxyFunc()
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport.Proxy = Func
+	}
+	return nil
+}
+
+// Explicit reports whether a proxy was set with Set. Clients of SDKs that
+// build their own transports need to be given one then.
+func Explicit() bool {
+	return explicit != nil
+}
+
+// Func returns the proxy of a request, for the Proxy field of transports
+func Func(req *http.Request) (*url.URL, error) {
+	if explicit != nil {
+		return explicit(req.URL)
+	}
+	return http.ProxyFromEnvironment(req)
+}
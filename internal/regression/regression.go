@@ -0,0 +1,146 @@
+// Package regression compares metrics of a candidate run against a baseline
+// run within absolute or relative tolerance bands.
+package regression
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Directions in which a metric improves
+const (
+	HigherIsBetter = "max"
+	LowerIsBetter  = "min"
+)
+
+// Comparison statuses
+const (
+	StatusOK        = "ok"
+	StatusRegressed = "regressed"
+	StatusMissing   = "missing"
+)
+
+// Tolerance is an allowed regression, either absolute or a percentage of the
+// baseline value
+type Tolerance struct {
+	Value    float64
+	Relative bool
+}
+
+// ParseTolerance parses an absolute tolerance such as 0.01 or a relative one
+// such as 1%
+func ParseTolerance(value string) (Tolerance, error) {
+	s := strings.TrimSpace(value)
+	number, relative := strings.CutSuffix(s, "%")
+	v, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return Tolerance{}, fmt.Errorf("invalid tolerance %q (expected a non-negative number such as 0.01, or a percentage such as 1%%)", value)
+	}
+	return Tolerance{Value: v, Relative: relative}, nil
+}
+
+// String formats the tolerance as it is parsed
+func (t Tolerance) String() string {
+	s := strconv.FormatFloat(t.Value, 'g', -1, 64)
+	if t.Relative {
+		return s + "%"
+	}
+	return s
+}
+
+// Allowed returns the allowed absolute regression from baseline
+func (t Tolerance) Allowed(baseline float64) float64 {
+	if t.Relative {
+		return math.Abs(baseline) * t.Value / 100
+	}
+	return t.Value
+}
+
+// Check is a metric to compare
+type Check struct {
+	Metric    string
+	Direction string
+	Tolerance Tolerance
+}
+
+// ParseCheck parses a metric spec of the form name[:max|min[:tolerance]]. The
+// direction defaults to max (higher is better) and the tolerance to
+// defaultTolerance.
+func ParseCheck(spec string, defaultTolerance Tolerance) (Check, error) {
+	parts := strings.Split(spec, ":")
+	if parts[0] == "" || len(parts) > 3 {
+		return Check{}, fmt.Errorf("invalid metric %q (expected name[:max|min[:tolerance]])", spec)
+	}
+
+	check := Check{Metric: parts[0], Direction: HigherIsBetter, Tolerance: defaultTolerance}
+	if len(parts) > 1 {
+		switch parts[1] {
+		case HigherIsBetter, LowerIsBetter:
+			check.Direction = parts[1]
+		default:
+			return Check{}, fmt.Errorf("invalid direction %q of metric %s (valid: max, min)", parts[1], parts[0])
+		}
+	}
+	if len(parts) > 2 {
+		tolerance, err := ParseTolerance(parts[2])
+		if err != nil {
+			return Check{}, fmt.Errorf("metric %s: %w", parts[0], err)
+		}
+		check.Tolerance = tolerance
+	}
+	return check, nil
+}
+
+// Result is the outcome of a check. Baseline, Candidate, Delta and
+// RelativeDelta are nil when they cannot be computed.
+type Result struct {
+	Check
+	Baseline      *float64
+	Candidate     *float64
+	Delta         *float64
+	RelativeDelta *float64
+	Allowed       float64
+	Status        string
+}
+
+// Compare checks the final value of a metric of the candidate against the
+// baseline. The candidate regresses when it is worse than the baseline by more
+// than the allowed tolerance.
+func Compare(check Check, baseline, candidate map[string]float64) Result {
+	result := Result{Check: check, Status: StatusMissing}
+
+	baselineValue, inBaseline := baseline[check.Metric]
+	candidateValue, inCandidate := candidate[check.Metric]
+	if inBaseline {
+		result.Baseline = &baselineValue
+		result.Allowed = check.Tolerance.Allowed(baselineValue)
+	}
+	if inCandidate {
+		result.Candidate = &candidateValue
+	}
+	if !inBaseline || !inCandidate {
+		return result
+	}
+
+	delta := candidateValue - baselineValue
+	result.Delta = &delta
+	if baselineValue != 0 {
+		relative := delta / math.Abs(baselineValue) * 100
+		result.RelativeDelta = &relative
+	}
+
+	// Positive regression means the candidate is worse
+	regression := -delta
+	if check.Direction == LowerIsBetter {
+		regression = delta
+	}
+	result.Status = StatusOK
+	// Values at the tolerance limit pass despite floating point rounding
+	limit := result.Allowed + 1e-9*math.Abs(baselineValue)
+	if regression > limit || math.IsNaN(candidateValue) {
+		result.Status = StatusRegressed
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// csvColumns are the columns of CSV sink files. Every row is a metric value or
+// a param, so that one file holds all runs.
+var csvColumns = []string{"timestamp", "run_id", "type", "key", "value", "step"}
+
+// csvTimeFormat is the format of timestamps in CSV sink files, with the
+// millisecond precision of MLflow
+const csvTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// csvFiles serializes the appends of concurrent requests to the same file
+var csvFiles sync.Map
+
+// csvSink appends rows to a local CSV file, writing the header when it
+// creates the file
+type csvSink struct {
+	cfg config.SinkConfig
+}
+
+func newCSVSink(cfg config.SinkConfig) *csvSink {
+	return &csvSink{cfg: cfg}
+}
+
+func (s *csvSink) String() string {
+	return s.cfg.String()
+}
+
+func (s *csvSink) WriteMetrics(ctx context.Context, runID string, metrics []models.Metric) error {
+	rows := make([][]string, len(metrics))
+	for i, metric := range metrics {
+		rows[i] = []string{
+			metric.Timestamp.UTC().Format(csvTimeFormat),
+			runID,
+			"metric",
+			metric.Key,
+			strconv.FormatFloat(metric.Value, 'g', -1, 64),
+			strconv.FormatInt(metric.Step, 10),
+		}
+	}
+	return s.append(rows)
+}
+
+func (s *csvSink) WriteParams(ctx context.Context, runID string, params []models.Parameter) error {
+	now := time.Now().UTC().Format(csvTimeFormat)
+	rows := make([][]string, len(params))
+	for i, param := range params {
+		rows[i] = []string{now, runID, "param", param.Key, param.Value, ""}
+	}
+	return s.append(rows)
+}
+
+// append writes rows to the file in a single write, so that processes
+// appending to the same file do not interleave their rows
+func (s *csvSink) append(rows [][]string) error {
+	mu, _ := csvFiles.LoadOrStore(s.cfg.Path, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	file, err := os.OpenFile(s.cfg.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", s.cfg.Path, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", s.cfg.Path, err)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if info.Size() == 0 {
+		w.Write(csvColumns)
+	}
+	w.WriteAll(rows)
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.cfg.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/httperr"
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// otlpMetricsPath is the path of the metrics signal of OTLP/HTTP collectors
+const otlpMetricsPath = "/v1/metrics"
+
+// otlpTimeout bounds each export request. Exports delay the logging they
+// mirror, so an unresponsive collector is given up on quickly.
+const otlpTimeout = 5 * time.Second
+
+// otlpSink exports metric values as OTLP gauges over HTTP with JSON encoding,
+// with the run ID and step as attributes of each data point. Params are not
+// exported.
+type otlpSink struct {
+	cfg    config.SinkConfig
+	client *http.Client
+}
+
+func newOTLPSink(cfg config.SinkConfig) *otlpSink {
+	return &otlpSink{cfg: cfg, client: &http.Client{Timeout: otlpTimeout}}
+}
+
+func (s *otlpSink) String() string {
+	return s.cfg.String()
+}
+
+// OTLP JSON encoding of metrics; 64-bit integers are strings
+type (
+	otlpExportRequest struct {
+		ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+	}
+	otlpResourceMetrics struct {
+		Resource     otlpResource       `json:"resource"`
+		ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeMetrics struct {
+		Scope   otlpScope    `json:"scope"`
+		Metrics []otlpMetric `json:"metrics"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpMetric struct {
+		Name  string    `json:"name"`
+		Gauge otlpGauge `json:"gauge"`
+	}
+	otlpGauge struct {
+		DataPoints []otlpDataPoint `json:"dataPoints"`
+	}
+	otlpDataPoint struct {
+		TimeUnixNano string          `json:"timeUnixNano"`
+		AsDouble     float64         `json:"asDouble"`
+		Attributes   []otlpAttribute `json:"attributes"`
+	}
+	otlpAttribute struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue string `json:"stringValue,omitempty"`
+		IntValue    string `json:"intValue,omitempty"`
+	}
+)
+
+func (s *otlpSink) WriteMetrics(ctx context.Context, runID string, metrics []models.Metric) error {
+	// Values of the same key are data points of one metric
+	var exported []otlpMetric
+	index := make(map[string]int)
+	for _, metric := range metrics {
+		i, ok := index[metric.Key]
+		if !ok {
+			i = len(exported)
+			index[metric.Key] = i
+			exported = append(exported, otlpMetric{Name: metric.Key})
+		}
+		exported[i].Gauge.DataPoints = append(exported[i].Gauge.DataPoints, otlpDataPoint{
+			TimeUnixNano: strconv.FormatInt(metric.Timestamp.UnixNano(), 10),
+			AsDouble:     metric.Value,
+			Attributes: []otlpAttribute{
+				{Key: "mlflow.run_id", Value: otlpValue{StringValue: runID}},
+				{Key: "mlflow.step", Value: otlpValue{IntValue: strconv.FormatInt(metric.Step, 10)}},
+			},
+		})
+	}
+
+	body, err := json.Marshal(otlpExportRequest{ResourceMetrics: []otlpResourceMetrics{{
+		Resource: otlpResource{Attributes: []otlpAttribute{
+			{Key: "service.name", Value: otlpValue{StringValue: "mlflow-cli"}},
+		}},
+		ScopeMetrics: []otlpScopeMetrics{{Scope: otlpScope{Name: "mlflow-cli"}, Metrics: exported}},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range s.cfg.Headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export to %s: %w", s.cfg.Endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("export to %s failed with status %d: %s", s.cfg.Endpoint, resp.StatusCode, httperr.ReadMessage(resp.Body))
+	}
+	return nil
+}
+
+func (s *otlpSink) WriteParams(ctx context.Context, runID string, params []models.Parameter) error {
+	return nil
+}
+
+// url returns the URL metrics are exported to: the endpoint if it is the
+// metrics URL already, as OTEL_EXPORTER_OTLP_METRICS_ENDPOINT is, and its
+// metrics path otherwise
+func (s *otlpSink) url() string {
+	endpoint := strings.TrimSuffix(s.cfg.Endpoint, "/")
+	if strings.HasSuffix(endpoint, otlpMetricsPath) {
+		return endpoint
+	}
+	return endpoint + otlpMetricsPath
+}
@@ -0,0 +1,34 @@
+// Package sink writes the metrics and params logged to MLflow to other
+// destinations as well: local CSV files, StatsD servers and OpenTelemetry
+// collectors.
+package sink
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// Sink is a destination of logged metrics and params. Sinks for metrics only
+// ignore params.
+type Sink interface {
+	WriteMetrics(ctx context.Context, runID string, metrics []models.Metric) error
+	WriteParams(ctx context.Context, runID string, params []models.Parameter) error
+	// String describes the sink in messages
+	String() string
+}
+
+// New creates the sink of a validated configuration
+func New(cfg config.SinkConfig) (Sink, error) {
+	switch cfg.Type {
+	case config.SinkCSV:
+		return newCSVSink(cfg), nil
+	case config.SinkStatsD:
+		return newStatsDSink(cfg), nil
+	case config.SinkOTLP:
+		return newOTLPSink(cfg), nil
+	}
+	return nil, fmt.Errorf("invalid sink type: %q (valid: csv, statsd, otlp)", cfg.Type)
+}
@@ -0,0 +1,94 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/imishinist/mlflow-cli/internal/config"
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// statsDMaxPacket is the maximum size of a StatsD packet, which must fit in
+// an Ethernet frame to arrive in one piece
+const statsDMaxPacket = 1432
+
+// statsDNameReplacer makes metric keys valid StatsD names. Slashes become
+// dots, so that train/loss is the train.loss metric of Graphite.
+var statsDNameReplacer = strings.NewReplacer("/", ".", ":", "_", "|", "_", "@", "_", " ", "_", "\n", "_")
+
+// statsDSink sends metric values as StatsD gauges over UDP. Params are not
+// numeric and are not sent, nor are NaN and infinite values.
+type statsDSink struct {
+	cfg config.SinkConfig
+}
+
+func newStatsDSink(cfg config.SinkConfig) *statsDSink {
+	return &statsDSink{cfg: cfg}
+}
+
+func (s *statsDSink) String() string {
+	return s.cfg.String()
+}
+
+func (s *statsDSink) WriteMetrics(ctx context.Context, runID string, metrics []models.Metric) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", s.cfg.Address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", s.cfg.Address, err)
+	}
+	defer conn.Close()
+
+	// Lines are packed into as few packets as fit
+	var packet []byte
+	flush := func() error {
+		if len(packet) == 0 {
+			return nil
+		}
+		_, err := conn.Write(packet)
+		packet = packet[:0]
+		if err != nil {
+			return fmt.Errorf("failed to send to %s: %w", s.cfg.Address, err)
+		}
+		return nil
+	}
+	for _, metric := range metrics {
+		// StatsD has no representation of NaN and infinity
+		if math.IsNaN(metric.Value) || math.IsInf(metric.Value, 0) {
+			continue
+		}
+		name := s.name(metric.Key)
+		line := name + ":" + strconv.FormatFloat(metric.Value, 'g', -1, 64) + "|g"
+		// A signed gauge value changes the gauge by the value instead of
+		// setting it, so negative values are set by resetting the gauge first
+		if metric.Value < 0 {
+			line = name + ":0|g\n" + line
+		}
+		if len(packet) > 0 && len(packet)+1+len(line) > statsDMaxPacket {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if len(packet) > 0 {
+			packet = append(packet, '\n')
+		}
+		packet = append(packet, line...)
+	}
+	return flush()
+}
+
+func (s *statsDSink) WriteParams(ctx context.Context, runID string, params []models.Parameter) error {
+	return nil
+}
+
+// name returns the StatsD name of a metric key
+func (s *statsDSink) name(key string) string {
+	name := statsDNameReplacer.Replace(key)
+	if s.cfg.Prefix != "" {
+		name = strings.TrimSuffix(s.cfg.Prefix, ".") + "." + name
+	}
+	return name
+}
@@ -0,0 +1,186 @@
+// Package spool buffers data in memory up to a budget and spills the rest to
+// temporary files, so that bulk operations run in bounded memory
+package spool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/imishinist/mlflow-cli/internal/models"
+)
+
+// metricOverhead approximates the memory of a buffered metric besides its key
+const metricOverhead = 64
+
+// MetricSpool holds metrics in the order they are added. Once the metrics in
+// memory exceed the limit, they are written to a temporary file; spilled
+// metrics keep their timestamps at millisecond precision, as they are logged.
+// A limit of 0 keeps every metric in memory.
+type MetricSpool struct {
+	limit   int64
+	size    int64
+	buffer  []models.Metric
+	file    *os.File
+	writer  *bufio.Writer
+	written int64
+	spilled int
+}
+
+// NewMetricSpool returns an empty spool keeping up to limit bytes of metrics
+// in memory
+func NewMetricSpool(limit int64) *MetricSpool {
+	return &MetricSpool{limit: limit}
+}
+
+// Add appends a metric, spilling the metrics in memory if it exceeds the limit
+func (s *MetricSpool) Add(metric models.Metric) error {
+	s.buffer = append(s.buffer, metric)
+	s.size += metricOverhead + int64(len(metric.Key))
+	if s.limit > 0 && s.size > s.limit {
+		return s.spill()
+	}
+	return nil
+}
+
+// Len returns the number of metrics added
+func (s *MetricSpool) Len() int {
+	return s.spilled + len(s.buffer)
+}
+
+// Spilled returns the number of metrics written to the temporary file
+func (s *MetricSpool) Spilled() int {
+	return s.spilled
+}
+
+// spill appends the metrics in memory to the temporary file
+func (s *MetricSpool) spill() error {
+	if s.file == nil {
+		file, err := os.CreateTemp("", "mlflow-cli-metrics-*.spool")
+		if err != nil {
+			return fmt.Errorf("failed to create spool file: %w", err)
+		}
+		s.file = file
+		s.writer = bufio.NewWriter(file)
+	}
+
+	var record []byte
+	for _, metric := range s.buffer {
+		record = binary.AppendUvarint(record[:0], uint64(len(metric.Key)))
+		record = append(record, metric.Key...)
+		record = binary.LittleEndian.AppendUint64(record, math.Float64bits(metric.Value))
+		record = binary.AppendVarint(record, metric.Timestamp.UnixMilli())
+		record = binary.AppendVarint(record, metric.Step)
+		localClock := byte(0)
+		if metric.LocalClock {
+			localClock = 1
+		}
+		record = append(record, localClock)
+		if _, err := s.writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write spool file: %w", err)
+		}
+		s.written += int64(len(record))
+	}
+
+	s.spilled += len(s.buffer)
+	// The buffer's array is reused; its memory is part of the budget anyway
+	clear(s.buffer)
+	s.buffer = s.buffer[:0]
+	s.size = 0
+	return nil
+}
+
+// Batches calls fn with consecutive batches of up to size metrics, in the
+// order they were added. Every batch is a new slice that fn may keep; the
+// spool can be read any number of times.
+func (s *MetricSpool) Batches(size int, fn func([]models.Metric) error) error {
+	if size < 1 {
+		size = 1
+	}
+
+	batch := make([]models.Metric, 0, size)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := fn(batch)
+		batch = make([]models.Metric, 0, size)
+		return err
+	}
+
+	if s.file != nil {
+		if err := s.writer.Flush(); err != nil {
+			return fmt.Errorf("failed to write spool file: %w", err)
+		}
+		reader := bufio.NewReader(io.NewSectionReader(s.file, 0, s.written))
+		for i := 0; i < s.spilled; i++ {
+			metric, err := readMetric(reader)
+			if err != nil {
+				return fmt.Errorf("failed to read spool file: %w", err)
+			}
+			batch = append(batch, metric)
+			if len(batch) == size {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, metric := range s.buffer {
+		batch = append(batch, metric)
+		if len(batch) == size {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// readMetric decodes a metric written by spill
+func readMetric(reader *bufio.Reader) (models.Metric, error) {
+	var metric models.Metric
+
+	keyLength, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return metric, err
+	}
+	record := make([]byte, keyLength+8)
+	if _, err := io.ReadFull(reader, record); err != nil {
+		return metric, err
+	}
+	metric.Key = string(record[:keyLength])
+	metric.Value = math.Float64frombits(binary.LittleEndian.Uint64(record[keyLength:]))
+
+	millis, err := binary.ReadVarint(reader)
+	if err != nil {
+		return metric, err
+	}
+	metric.Timestamp = time.UnixMilli(millis)
+	if metric.Step, err = binary.ReadVarint(reader); err != nil {
+		return metric, err
+	}
+	localClock, err := reader.ReadByte()
+	if err != nil {
+		return metric, err
+	}
+	metric.LocalClock = localClock == 1
+	return metric, nil
+}
+
+// Close removes the temporary file
+func (s *MetricSpool) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	name := s.file.Name()
+	err := s.file.Close()
+	s.file = nil
+	return errors.Join(err, os.Remove(name))
+}
@@ -0,0 +1,15 @@
+//go:build linux || darwin
+
+package sysmetrics
+
+import "syscall"
+
+// diskUsage returns the total and available bytes of the filesystem containing path
+func diskUsage(path string) (total, available uint64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+	blockSize := uint64(stat.Bsize)
+	return stat.Blocks * blockSize, stat.Bavail * blockSize, nil
+}
@@ -0,0 +1,279 @@
+// Package sysmetrics samples system utilization (CPU, memory, disk, network and
+// GPU) using the metric names of MLflow's system metrics feature.
+package sysmetrics
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// MetricPrefix is the namespace of all system metrics
+const MetricPrefix = "system/"
+
+// System metric keys, matching MLflow's system metrics
+const (
+	MetricCPUUtilization     = MetricPrefix + "cpu_utilization_percentage"
+	MetricMemoryUsageMB      = MetricPrefix + "system_memory_usage_megabytes"
+	MetricMemoryUsagePercent = MetricPrefix + "system_memory_usage_percentage"
+	MetricDiskUsagePercent   = MetricPrefix + "disk_usage_percentage"
+	MetricDiskUsageMB        = MetricPrefix + "disk_usage_megabytes"
+	MetricDiskAvailableMB    = MetricPrefix + "disk_available_megabytes"
+	MetricNetworkReceiveMB   = MetricPrefix + "network_receive_megabytes"
+	MetricNetworkTransmitMB  = MetricPrefix + "network_transmit_megabytes"
+)
+
+const megabyte = 1024 * 1024
+
+// cpuTimes holds cumulative CPU time counters from /proc/stat
+type cpuTimes struct {
+	idle  uint64
+	total uint64
+}
+
+// netCounters holds cumulative network byte counters from /proc/net/dev
+type netCounters struct {
+	received    uint64
+	transmitted uint64
+}
+
+// Sampler samples system metrics. CPU utilization is averaged over the time
+// between samples; network traffic is reported as the total since the sampler
+// was created.
+type Sampler struct {
+	diskPath string
+	cpu      *cpuTimes
+	net      *netCounters
+	gpu      bool
+}
+
+// NewSampler creates a sampler reporting disk usage of the filesystem
+// containing diskPath
+func NewSampler(diskPath string) *Sampler {
+	s := &Sampler{diskPath: diskPath}
+	s.cpu = readCPUTimes()
+	s.net = readNetCounters()
+	_, err := exec.LookPath("nvidia-smi")
+	s.gpu = err == nil
+	return s
+}
+
+// Sample returns the current system metrics. Metrics that cannot be read on
+// this platform are omitted.
+func (s *Sampler) Sample() map[string]float64 {
+	values := make(map[string]float64)
+	s.sampleCPU(values)
+	sampleMemory(values)
+	s.sampleDisk(values)
+	s.sampleNetwork(values)
+	if s.gpu {
+		sampleGPUs(values)
+	}
+	return values
+}
+
+// sampleCPU records CPU utilization since the previous sample
+func (s *Sampler) sampleCPU(values map[string]float64) {
+	current := readCPUTimes()
+	if current == nil {
+		return
+	}
+	previous := s.cpu
+	s.cpu = current
+	if previous == nil || current.total <= previous.total {
+		return
+	}
+
+	total := float64(current.total - previous.total)
+	idle := float64(current.idle - previous.idle)
+	values[MetricCPUUtilization] = 100 * (total - idle) / total
+}
+
+// sampleMemory records used system memory
+func sampleMemory(values map[string]float64) {
+	if runtime.GOOS != "linux" {
+		return
+	}
+
+	meminfo := readMeminfo()
+	total, ok := meminfo["MemTotal"]
+	if !ok || total == 0 {
+		return
+	}
+	available, ok := meminfo["MemAvailable"]
+	if !ok {
+		available = meminfo["MemFree"] + meminfo["Buffers"] + meminfo["Cached"]
+	}
+
+	used := total - available
+	values[MetricMemoryUsageMB] = float64(used) / megabyte
+	values[MetricMemoryUsagePercent] = 100 * float64(used) / float64(total)
+}
+
+// sampleDisk records usage of the filesystem containing the disk path
+func (s *Sampler) sampleDisk(values map[string]float64) {
+	total, available, err := diskUsage(s.diskPath)
+	if err != nil || total == 0 {
+		return
+	}
+
+	used := total - available
+	values[MetricDiskUsageMB] = float64(used) / megabyte
+	values[MetricDiskAvailableMB] = float64(available) / megabyte
+	values[MetricDiskUsagePercent] = 100 * float64(used) / float64(total)
+}
+
+// sampleNetwork records network traffic since the sampler was created
+func (s *Sampler) sampleNetwork(values map[string]float64) {
+	current := readNetCounters()
+	if current == nil || s.net == nil {
+		return
+	}
+
+	// Counters reset when interfaces go away; never report negative traffic
+	if current.received >= s.net.received {
+		values[MetricNetworkReceiveMB] = float64(current.received-s.net.received) / megabyte
+	}
+	if current.transmitted >= s.net.transmitted {
+		values[MetricNetworkTransmitMB] = float64(current.transmitted-s.net.transmitted) / megabyte
+	}
+}
+
+// sampleGPUs records utilization, memory and power of NVIDIA GPUs via nvidia-smi
+func sampleGPUs(values map[string]float64) {
+	output, err := exec.Command("nvidia-smi",
+		"--query-gpu=index,utilization.gpu,memory.used,memory.total,power.draw",
+		"--format=csv,noheader,nounits").Output()
+	if err != nil {
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 5 {
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		prefix := fmt.Sprintf("%sgpu_%s_", MetricPrefix, fields[0])
+		// Unsupported queries are reported as "[N/A]" and skipped
+		if utilization, err := strconv.ParseFloat(fields[1], 64); err == nil {
+			values[prefix+"utilization_percentage"] = utilization
+		}
+		used, usedErr := strconv.ParseFloat(fields[2], 64)
+		total, totalErr := strconv.ParseFloat(fields[3], 64)
+		if usedErr == nil {
+			values[prefix+"memory_usage_megabytes"] = used
+			if totalErr == nil && total > 0 {
+				values[prefix+"memory_usage_percentage"] = 100 * used / total
+			}
+		}
+		if power, err := strconv.ParseFloat(fields[4], 64); err == nil {
+			values[prefix+"power_usage_watts"] = power
+		}
+	}
+}
+
+// readCPUTimes reads the aggregate CPU counters from /proc/stat
+func readCPUTimes() *cpuTimes {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+
+		times := &cpuTimes{}
+		for i, field := range fields[1:] {
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return nil
+			}
+			times.total += value
+			// idle and iowait
+			if i == 3 || i == 4 {
+				times.idle += value
+			}
+		}
+		return times
+	}
+	return nil
+}
+
+// readMeminfo reads /proc/meminfo values in bytes
+func readMeminfo() map[string]uint64 {
+	meminfo := make(map[string]uint64)
+	data, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return meminfo
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		// Values are reported as "<n> kB"
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		if kb, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			meminfo[name] = kb * 1024
+		}
+	}
+	return meminfo
+}
+
+// readNetCounters sums the byte counters of all non-loopback interfaces from
+// /proc/net/dev
+func readNetCounters() *netCounters {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	data, err := os.ReadFile("/proc/net/dev")
+	if err != nil {
+		return nil
+	}
+
+	counters := &netCounters{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name, stats, found := strings.Cut(scanner.Text(), ":")
+		if !found || strings.TrimSpace(name) == "lo" {
+			continue
+		}
+		// Receive bytes is the 1st column, transmit bytes the 9th
+		fields := strings.Fields(stats)
+		if len(fields) < 9 {
+			continue
+		}
+		received, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		transmitted, err := strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			continue
+		}
+		counters.received += received
+		counters.transmitted += transmitted
+	}
+	return counters
+}
@@ -2,28 +2,142 @@ package timeutils
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"time"
+	// Embedded time zone database for systems without zoneinfo (e.g. slim containers)
+	_ "time/tzdata"
 
 	"github.com/imishinist/mlflow-cli/internal/models"
+	"github.com/imishinist/mlflow-cli/internal/units"
 )
 
-// AlignTimestamp aligns timestamp to the specified resolution and alignment
+// ISO8601 variants accepted by ParseTime, most specific first
+var (
+	zonedTimeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04Z07:00",
+	}
+	naiveTimeLayouts = []string{
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02T15:04",
+		"2006-01-02",
+	}
+)
+
+// epochMillisThreshold separates epoch seconds from epoch milliseconds: 1e11
+// seconds is in the year 5138, while 1e11 milliseconds is in 1973
+const epochMillisThreshold = 1e11
+
+// ParseTime parses an ISO8601 timestamp or Unix epoch seconds/milliseconds.
+// Seconds and the time of day may be omitted from ISO8601 timestamps;
+// timestamps without a zone are taken as UTC.
+func ParseTime(value string) (time.Time, error) {
+	return ParseTimeIn(value, time.UTC)
+}
+
+// ParseTimeIn is like ParseTime but takes timestamps without a zone as local
+// time in loc
+func ParseTimeIn(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range zonedTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range naiveTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	if epoch, err := strconv.ParseFloat(value, 64); err == nil {
+		return FromEpoch(epoch), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp: %s (expected ISO8601, e.g. 2024-05-01T00:00Z, or Unix epoch)", value)
+}
+
+// IsNaiveTime reports whether value is an ISO8601 timestamp without a zone
+func IsNaiveTime(value string) bool {
+	for _, layout := range naiveTimeLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadLocation returns the time zone with the given IANA name; an empty name
+// is UTC and "Local" is the system time zone
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone: %s", name)
+	}
+	return loc, nil
+}
+
+// PointTime returns the absolute timestamp of a point, taking naive timestamps
+// as local time in loc (UTC if nil). Relative timestamps are not resolved.
+func PointTime(point models.MetricPoint, loc *time.Location) *time.Time {
+	if point.Timestamp == nil || !point.Naive || loc == nil {
+		return point.Timestamp
+	}
+	t := *point.Timestamp
+	local := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	return &local
+}
+
+// FromEpoch converts Unix epoch seconds, or milliseconds for values of 1e11 and
+// above, to a time
+func FromEpoch(epoch float64) time.Time {
+	if math.Abs(epoch) >= epochMillisThreshold {
+		return time.UnixMilli(int64(epoch)).UTC()
+	}
+	sec, frac := math.Modf(epoch)
+	return time.Unix(int64(sec), int64(frac*1e9)).Round(time.Millisecond).UTC()
+}
+
+// ResolutionNone disables timestamp alignment
+const ResolutionNone = "none"
+
+// ParseResolution parses a time resolution: a duration such as 10s, 1h30m, 1d
+// or 1w (see units.ParseDuration), or "none" which returns 0
+func ParseResolution(resolution string) (time.Duration, error) {
+	if resolution == ResolutionNone {
+		return 0, nil
+	}
+
+	duration, err := units.ParseDuration(resolution)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported resolution: %w", err)
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("resolution must be positive: %s", resolution)
+	}
+	return duration, nil
+}
+
+// AlignTimestamp aligns timestamp to the specified resolution and alignment.
+// Buckets start at boundaries in the timestamp's location, e.g. at local
+// midnight for 1d. Timestamps are returned untouched for the "none" resolution.
 func AlignTimestamp(t time.Time, resolution string, alignment string) (time.Time, error) {
-	var duration time.Duration
-
-	switch resolution {
-	case "1m":
-		duration = time.Minute
-	case "5m":
-		duration = 5 * time.Minute
-	case "1h":
-		duration = time.Hour
-	default:
-		return t, fmt.Errorf("unsupported resolution: %s", resolution)
+	duration, err := ParseResolution(resolution)
+	if err != nil {
+		return t, err
+	}
+	if duration == 0 {
+		return t, nil
 	}
 
-	// Truncate to the resolution
-	aligned := t.Truncate(duration)
+	// Truncate to the resolution. Truncate works on absolute time, so the zone
+	// offset is added first to truncate wall-clock time.
+	_, offset := t.Zone()
+	shift := time.Duration(offset) * time.Second
+	aligned := t.Add(shift).Truncate(duration).Add(-shift)
 
 	switch alignment {
 	case "floor":
@@ -44,80 +158,246 @@ func AlignTimestamp(t time.Time, resolution string, alignment string) (time.Time
 	}
 }
 
-// ProcessMetrics processes metrics according to time configuration
+// ProcessMetrics processes metrics according to time configuration. Relative
+// timestamps (offsets) and timestamp-derived steps are based on baseTime, or on
+// the first point's timestamp or the current time if baseTime is nil.
 func ProcessMetrics(metrics []models.MetricPoint, config models.TimeConfig, baseTime *time.Time) ([]models.Metric, error) {
-	var result []models.Metric
-	var base time.Time
+	processor, err := NewMetricProcessor(config, baseTime)
+	if err != nil {
+		return nil, err
+	}
+	return processor.ProcessAll(metrics)
+}
 
-	if baseTime != nil {
-		base = *baseTime
-	} else if len(metrics) > 0 && metrics[0].Timestamp != nil {
-		base = *metrics[0].Timestamp
-	} else {
-		base = time.Now()
+// MetricProcessor processes metric points one at a time like ProcessMetrics,
+// so that callers need not hold every point in memory. Without aggregation,
+// the metrics of a point are emitted as it is processed; aggregated metrics
+// are kept per bucket and emitted by Finish.
+type MetricProcessor struct {
+	config     models.TimeConfig
+	loc        *time.Location
+	stride     int64
+	base       *time.Time
+	count      int64
+	aggregator *metricAggregator
+	// localBase is set if the base was read from the local clock
+	localBase bool
+}
+
+// NewMetricProcessor returns a processor basing relative timestamps and timestamp-derived steps on baseTime, or on
+// the first point's timestamp or the current time if baseTime is nil
+func NewMetricProcessor(config models.TimeConfig, baseTime *time.Time) (*MetricProcessor, error) {
+	aggregator, err := newMetricAggregator(config.Aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &MetricProcessor{
+		config:     config,
+		loc:        config.Location,
+		stride:     config.StepStride,
+		base:       baseTime,
+		aggregator: aggregator,
+	}
+	if p.loc == nil {
+		p.loc = time.UTC
 	}
+	if p.stride == 0 {
+		p.stride = 1
+	}
+	return p, nil
+}
+
+// SetLocalBase marks the base time given to NewMetricProcessor as read from
+// the local clock, so that timestamps relative to it are corrected for clock
+// skew like the current time
+func (p *MetricProcessor) SetLocalBase() {
+	p.localBase = true
+}
 
-	for _, point := range metrics {
-		var timestamp time.Time
-		var step int64
+// ProcessAll processes points and finishes, returning all metrics
+func (p *MetricProcessor) ProcessAll(points []models.MetricPoint) ([]models.Metric, error) {
+	var result []models.Metric
+	collect := func(metric models.Metric) error {
+		result = append(result, metric)
+		return nil
+	}
+	for _, point := range points {
+		if err := p.Process(point, collect); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.Finish(collect); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
 
-		// Determine timestamp
+// Process converts a point into one metric per field, in key order for
+// determinism, and passes them to emit unless they are aggregated
+func (p *MetricProcessor) Process(point models.MetricPoint, emit func(models.Metric) error) error {
+	if p.base == nil {
+		base := time.Now()
+		p.localBase = true
 		if point.Timestamp != nil {
-			var err error
-			timestamp, err = AlignTimestamp(*point.Timestamp, config.Resolution, config.Alignment)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			timestamp = time.Now()
+			base = *PointTime(point, p.loc)
+			p.localBase = false
 		}
+		p.base = &base
+	}
+	base := *p.base
+
+	var timestamp time.Time
+	var step int64
+	localClock := false
+
+	// Resolve relative and naive timestamps
+	if point.Offset != nil {
+		t := base.Add(*point.Offset)
+		point.Timestamp = &t
+		localClock = p.localBase
+	}
+	point.Timestamp = PointTime(point, p.loc)
+
+	// Determine timestamp, aligned to boundaries in the configured time zone
+	if point.Timestamp != nil {
+		var err error
+		timestamp, err = AlignTimestamp(point.Timestamp.In(p.loc), p.config.Resolution, p.config.Alignment)
+		if err != nil {
+			return err
+		}
+	} else {
+		timestamp = time.Now()
+		localClock = true
+	}
 
-		// Determine step
-		if point.Step != nil {
-			step = *point.Step
-		} else {
-			switch config.StepMode {
-			case "timestamp":
-				// Convert timestamp to minutes from base time
+	// Determine step
+	if point.Step != nil {
+		step = *point.Step
+	} else {
+		switch p.config.StepMode {
+		case "timestamp":
+			// Convert timestamp to minutes from base time
+			step = int64(timestamp.Sub(base).Minutes())
+		case "sequence":
+			step = p.count
+		case "auto":
+			if point.Timestamp != nil {
 				step = int64(timestamp.Sub(base).Minutes())
-			case "sequence":
-				step = int64(len(result))
-			case "auto":
-				if point.Timestamp != nil {
-					step = int64(timestamp.Sub(base).Minutes())
-				} else {
-					step = int64(len(result))
-				}
+			} else {
+				step = p.count
 			}
 		}
+	}
+	step = p.config.StepOffset + step*p.stride
+
+	keys := make([]string, 0, len(point.Values))
+	for key := range point.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-		// Convert each field to a separate metric
-		if point.ExecutionTime != 0 {
-			result = append(result, models.Metric{
-				Key:       "execution_time",
-				Value:     point.ExecutionTime,
-				Timestamp: timestamp,
-				Step:      step,
-			})
+	for _, key := range keys {
+		metric := models.Metric{
+			Key:        key,
+			Value:      point.Values[key],
+			Timestamp:  timestamp,
+			Step:       step,
+			LocalClock: localClock,
 		}
+		p.count++
+		if p.aggregator != nil {
+			p.aggregator.add(metric)
+			continue
+		}
+		if err := emit(metric); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		if point.SuccessRate != 0 {
-			result = append(result, models.Metric{
-				Key:       "success_rate",
-				Value:     point.SuccessRate,
-				Timestamp: timestamp,
-				Step:      step,
-			})
+// Finish emits the aggregated metrics, in order of their first data point
+func (p *MetricProcessor) Finish(emit func(models.Metric) error) error {
+	if p.aggregator == nil {
+		return nil
+	}
+	for _, metric := range p.aggregator.result {
+		if err := emit(metric); err != nil {
+			return err
 		}
+	}
+	p.aggregator = nil
+	return nil
+}
 
-		// ErrorCount can be 0, so we always include it
-		result = append(result, models.Metric{
-			Key:       "error_count",
-			Value:     point.ErrorCount,
-			Timestamp: timestamp,
-			Step:      step,
-		})
+// AggregateMetrics collapses metrics with the same key and (aligned) timestamp
+// into a single metric using the given method: mean, max, min, last, or none.
+// Aggregated metrics take the step of the last data point in their bucket.
+func AggregateMetrics(metrics []models.Metric, method string) ([]models.Metric, error) {
+	aggregator, err := newMetricAggregator(method)
+	if err != nil {
+		return nil, err
+	}
+	if aggregator == nil {
+		return metrics, nil
 	}
 
-	return result, nil
+	for _, metric := range metrics {
+		aggregator.add(metric)
+	}
+	return aggregator.result, nil
+}
+
+// aggregateBucket identifies the metrics collapsed by aggregation
+type aggregateBucket struct {
+	key       string
+	timestamp int64
+}
+
+// metricAggregator collapses metrics of the same bucket as they are added
+type metricAggregator struct {
+	method string
+	result []models.Metric
+	counts []int
+	index  map[aggregateBucket]int
+}
+
+// newMetricAggregator returns an aggregator for method, or nil for none
+func newMetricAggregator(method string) (*metricAggregator, error) {
+	switch method {
+	case "", "none":
+		return nil, nil
+	case "mean", "max", "min", "last":
+	default:
+		return nil, fmt.Errorf("unsupported aggregate: %s", method)
+	}
+	return &metricAggregator{method: method, index: make(map[aggregateBucket]int)}, nil
+}
+
+// add adds a metric to its bucket
+func (a *metricAggregator) add(metric models.Metric) {
+	bucket := aggregateBucket{key: metric.Key, timestamp: metric.Timestamp.UnixMilli()}
+	i, exists := a.index[bucket]
+	if !exists {
+		a.index[bucket] = len(a.result)
+		a.result = append(a.result, metric)
+		a.counts = append(a.counts, 1)
+		return
+	}
+
+	aggregated := &a.result[i]
+	a.counts[i]++
+	switch a.method {
+	case "mean":
+		// Running mean avoids keeping every value of the bucket
+		aggregated.Value += (metric.Value - aggregated.Value) / float64(a.counts[i])
+	case "max":
+		aggregated.Value = math.Max(aggregated.Value, metric.Value)
+	case "min":
+		aggregated.Value = math.Min(aggregated.Value, metric.Value)
+	case "last":
+		aggregated.Value = metric.Value
+	}
+	aggregated.Step = metric.Step
 }
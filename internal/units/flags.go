@@ -0,0 +1,105 @@
+package units
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// durationValue is a pflag.Value for durations parsed with ParseDuration. Its
+// type is "duration", so the flag is read with FlagSet.GetDuration.
+type durationValue time.Duration
+
+func (d *durationValue) Set(value string) error {
+	parsed, err := ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = durationValue(parsed)
+	return nil
+}
+
+func (d *durationValue) Type() string { return "duration" }
+
+// String must stay parsable by time.ParseDuration for FlagSet.GetDuration
+func (d *durationValue) String() string { return time.Duration(*d).String() }
+
+// sizeValue is a pflag.Value for sizes parsed with ParseSize
+type sizeValue int64
+
+func (s *sizeValue) Set(value string) error {
+	parsed, err := ParseSize(value)
+	if err != nil {
+		return err
+	}
+	*s = sizeValue(parsed)
+	return nil
+}
+
+func (s *sizeValue) Type() string { return "size" }
+
+func (s *sizeValue) String() string { return FormatSize(int64(*s)) }
+
+// rateValue is a pflag.Value for transfer rates parsed with ParseRate
+type rateValue int64
+
+func (r *rateValue) Set(value string) error {
+	parsed, err := ParseRate(value)
+	if err != nil {
+		return err
+	}
+	*r = rateValue(parsed)
+	return nil
+}
+
+func (r *rateValue) Type() string { return "rate" }
+
+func (r *rateValue) String() string { return FormatSize(int64(*r)) + "/s" }
+
+// DurationFlag defines a duration flag that also accepts days and weeks
+// (2d, 1w). Read it with FlagSet.GetDuration.
+func DurationFlag(flags *pflag.FlagSet, name string, value time.Duration, usage string) {
+	d := durationValue(value)
+	flags.Var(&d, name, usage)
+}
+
+// SizeFlag defines a size flag in bytes accepting units (5GB, 512MiB). Read
+// it with GetSize.
+func SizeFlag(flags *pflag.FlagSet, name string, value int64, usage string) {
+	s := sizeValue(value)
+	flags.Var(&s, name, usage)
+}
+
+// RateFlag defines a transfer rate flag in bytes per second (10MiB/s). Read
+// it with GetRate.
+func RateFlag(flags *pflag.FlagSet, name string, value int64, usage string) {
+	r := rateValue(value)
+	flags.Var(&r, name, usage)
+}
+
+// GetSize returns the value of a flag defined with SizeFlag
+func GetSize(flags *pflag.FlagSet, name string) (int64, error) {
+	flag := flags.Lookup(name)
+	if flag == nil {
+		return 0, fmt.Errorf("flag accessed but not defined: %s", name)
+	}
+	s, ok := flag.Value.(*sizeValue)
+	if !ok {
+		return 0, fmt.Errorf("flag %s is not a size flag", name)
+	}
+	return int64(*s), nil
+}
+
+// GetRate returns the value of a flag defined with RateFlag
+func GetRate(flags *pflag.FlagSet, name string) (int64, error) {
+	flag := flags.Lookup(name)
+	if flag == nil {
+		return 0, fmt.Errorf("flag accessed but not defined: %s", name)
+	}
+	r, ok := flag.Value.(*rateValue)
+	if !ok {
+		return 0, fmt.Errorf("flag %s is not a rate flag", name)
+	}
+	return int64(*r), nil
+}
@@ -0,0 +1,214 @@
+// Package units parses human-friendly durations, sizes and transfer rates
+// given on the command line, such as 1h30m, 2w, 5GB or 10MiB/s, and formats
+// durations and times for people to read.
+package units
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Day and Week extend the units of time.ParseDuration
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// Decimal (SI) and binary (IEC) byte units
+const (
+	KB int64 = 1000
+	MB       = 1000 * KB
+	GB       = 1000 * MB
+	TB       = 1000 * GB
+
+	KiB int64 = 1 << 10
+	MiB       = 1 << 20
+	GiB       = 1 << 30
+	TiB       = 1 << 40
+)
+
+// sizeUnits maps lowercase unit suffixes to bytes
+var sizeUnits = map[string]int64{
+	"": 1, "b": 1,
+	"k": KB, "kb": KB, "m": MB, "mb": MB, "g": GB, "gb": GB, "t": TB, "tb": TB,
+	"ki": KiB, "kib": KiB, "mi": MiB, "mib": MiB, "gi": GiB, "gib": GiB, "ti": TiB, "tib": TiB,
+}
+
+var (
+	// durationComponent matches a number followed by a unit, e.g. 1.5h
+	durationComponent = regexp.MustCompile(`^([0-9]*\.?[0-9]+)([a-zµ]+)`)
+	sizePattern       = regexp.MustCompile(`^([0-9]*\.?[0-9]+)\s*([a-zA-Z]*)$`)
+)
+
+// ParseDuration parses a duration such as 90s, 1h30m, 2d or 1w. It accepts
+// everything time.ParseDuration does plus the units d (24h) and w (7d),
+// which may be combined with the others (1w2d, 1d12h).
+func ParseDuration(value string) (time.Duration, error) {
+	invalid := fmt.Errorf("invalid duration %q (expected e.g. 90s, 1h30m, 2d or 1w)", value)
+
+	s := strings.TrimSpace(value)
+	if s == "" {
+		return 0, invalid
+	}
+	if s == "0" {
+		return 0, nil
+	}
+
+	sign := time.Duration(1)
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = -1, rest
+	}
+
+	var total float64
+	for s != "" {
+		match := durationComponent.FindStringSubmatch(s)
+		if match == nil {
+			return 0, invalid
+		}
+		n, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, invalid
+		}
+
+		var unit time.Duration
+		switch match[2] {
+		case "w":
+			unit = Week
+		case "d":
+			unit = Day
+		default:
+			// Leave the other units to time.ParseDuration
+			d, err := time.ParseDuration(match[0])
+			if err != nil {
+				return 0, invalid
+			}
+			total += float64(d)
+			s = s[len(match[0]):]
+			continue
+		}
+		total += n * float64(unit)
+		s = s[len(match[0]):]
+	}
+
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("duration %q is too large", value)
+	}
+	return sign * time.Duration(total), nil
+}
+
+// FormatDuration formats a duration with its two largest units, rounded down,
+// e.g. 45s, 12m5s, 1h23m or 2d4h. The result is accepted by ParseDuration.
+func FormatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+
+	units := []struct {
+		suffix string
+		length time.Duration
+	}{
+		{"d", Day}, {"h", time.Hour}, {"m", time.Minute}, {"s", time.Second},
+	}
+	for i, unit := range units {
+		if d < unit.length && unit.length != time.Second {
+			continue
+		}
+		s := sign + strconv.FormatInt(int64(d/unit.length), 10) + unit.suffix
+		if i+1 < len(units) {
+			next := units[i+1]
+			if rest := (d % unit.length) / next.length; rest > 0 {
+				s += strconv.FormatInt(int64(rest), 10) + next.suffix
+			}
+		}
+		return s
+	}
+	return "0s"
+}
+
+// FormatRelativeTime formats a time relative to now in its largest unit, e.g.
+// "just now", "5m ago", "2h ago", "3d ago" or "in 10m". Times a month or more
+// away are formatted as their date in the time zone of t.
+func FormatRelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var s string
+	switch {
+	case d >= 30*Day:
+		return t.Format("2006-01-02")
+	case d >= Day:
+		s = strconv.FormatInt(int64(d/Day), 10) + "d"
+	case d >= time.Hour:
+		s = strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	case d >= time.Minute:
+		s = strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+	default:
+		return "just now"
+	}
+
+	if future {
+		return "in " + s
+	}
+	return s + " ago"
+}
+
+// ParseSize parses a size in bytes such as 512, 512KiB, 10MB or 1.5GB. SI
+// units (KB, MB, GB, TB) are powers of 1000 and IEC units (KiB, MiB, GiB, TiB)
+// powers of 1024; units are case-insensitive and the B is optional.
+func ParseSize(value string) (int64, error) {
+	invalid := fmt.Errorf("invalid size %q (expected e.g. 512KiB, 10MB or 5GB)", value)
+
+	match := sizePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, invalid
+	}
+	unit, ok := sizeUnits[strings.ToLower(match[2])]
+	if !ok {
+		return 0, invalid
+	}
+	n, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, invalid
+	}
+
+	size := n * float64(unit)
+	if size > math.MaxInt64 {
+		return 0, fmt.Errorf("size %q is too large", value)
+	}
+	return int64(size), nil
+}
+
+// FormatSize formats a size in bytes with the largest unit dividing it,
+// preferring IEC units
+func FormatSize(size int64) string {
+	for _, unit := range []struct {
+		suffix string
+		bytes  int64
+	}{
+		{"TiB", TiB}, {"GiB", GiB}, {"MiB", MiB}, {"KiB", KiB},
+		{"TB", TB}, {"GB", GB}, {"MB", MB}, {"KB", KB},
+	} {
+		if size != 0 && size%unit.bytes == 0 {
+			return strconv.FormatInt(size/unit.bytes, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(size, 10) + "B"
+}
+
+// ParseRate parses a transfer rate in bytes per second such as 10MiB/s or
+// 500KB. The /s suffix is optional.
+func ParseRate(value string) (int64, error) {
+	size, err := ParseSize(strings.TrimSuffix(strings.TrimSpace(value), "/s"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid rate %q (expected e.g. 10MiB/s or 500KB/s)", value)
+	}
+	return size, nil
+}
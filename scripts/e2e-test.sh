@@ -350,6 +350,9 @@ main() {
     run_test "Log metrics from YAML file" \
         "$BINARY_PATH log metrics --run-id $RUN_ID --from-file test/fixtures/test_metrics.yaml"
 
+    run_test "Log metrics from CSV file" \
+        "$BINARY_PATH log metrics --run-id $RUN_ID --from-file test/fixtures/test_metrics.csv"
+
     run_test "Log metrics with time processing" \
         "$BINARY_PATH log metrics --run-id $RUN_ID --from-file test/fixtures/test_metrics.json --time-resolution 1m --time-alignment floor --step-mode timestamp"
 
@@ -360,7 +363,16 @@ main() {
         "$BINARY_PATH log artifact --run-id $RUN_ID --file test/fixtures/config.yaml --artifact-path models/config.yaml"
 
     run_test "Upload multiple artifacts" \
-        "$BINARY_PATH log artifact --run-id $RUN_ID --file test/fixtures/sample_model.txt --file test/fixtures/config.yaml"
+        "$BINARY_PATH log artifact --run-id $RUN_ID --file test/fixtures/sample_model.txt --file test/fixtures/config.yaml --overwrite"
+
+    run_test "Set tags on run" \
+        "$BINARY_PATH run set-tag --run-id $RUN_ID --tag e2e_tag=value"
+
+    run_test "Set tags from YAML file" \
+        "$BINARY_PATH run set-tag --run-id $RUN_ID --from-file test/fixtures/test_tags.yaml"
+
+    run_test "Delete tag from run" \
+        "$BINARY_PATH run delete-tag --run-id $RUN_ID --key e2e_tag --yes"
 
     run_test "End run with FINISHED status" \
         "$BINARY_PATH run end --run-id $RUN_ID --status FINISHED"
@@ -384,6 +396,15 @@ main() {
     fi
     ((TESTS_TOTAL++))
 
+    run_test "Run exec with successful command" \
+        "$BINARY_PATH run exec --run-name e2e-exec-$(date +%s) -- sh -c 'test -n \"\$MLFLOW_RUN_ID\"'"
+
+    run_test "Run exec propagates failure" \
+        "$BINARY_PATH run exec --run-name e2e-exec-fail-$(date +%s) -- false" true
+
+    run_test "Track run in one invocation" \
+        "$BINARY_PATH track --run-name e2e-track-$(date +%s) --params test/fixtures/test_params.yaml --metrics test/fixtures/test_metrics.json --artifacts 'test/fixtures/*.txt' --tag stage=e2e"
+
     # Test 10-11: Error handling
     run_test "Error handling - Invalid run ID" \
         "$BINARY_PATH log params --run-id invalid-run-id --param test=value" true
@@ -466,13 +487,13 @@ main() {
     ((TESTS_TOTAL++))
 
     printf "%-50s " "Verify JSON metrics"
-    ERROR_COUNT_HISTORY=$(curl -s "$MLFLOW_TRACKING_URI/api/2.0/mlflow/metrics/get-history?run_id=$RUN_ID&metric_key=error_count")
+    ERROR_COUNT_HISTORY=$(curl -s "$MLFLOW_TRACKING_URI/api/2.0/mlflow/metrics/get-history?run_id=$RUN_ID&metric_key=val_loss")
     if echo "$ERROR_COUNT_HISTORY" | grep -q "\"value\""; then
         echo "${GREEN}[ OK ]${NC}"
         ((TESTS_PASSED++))
     else
         echo "${RED}[FAIL]${NC}"
-        echo "  Expected: error_count metric from JSON file"
+        echo "  Expected: val_loss metric from JSON file"
         if [ "$DEBUG_MODE" = true ]; then
             echo "  Response: $ERROR_COUNT_HISTORY"
         fi
@@ -481,13 +502,13 @@ main() {
     ((TESTS_TOTAL++))
 
     printf "%-50s " "Verify YAML metrics"
-    ERROR_COUNT_YAML=$(curl -s "$MLFLOW_TRACKING_URI/api/2.0/mlflow/metrics/get-history?run_id=$RUN_ID&metric_key=error_count")
+    ERROR_COUNT_YAML=$(curl -s "$MLFLOW_TRACKING_URI/api/2.0/mlflow/metrics/get-history?run_id=$RUN_ID&metric_key=f1_score")
     if echo "$ERROR_COUNT_YAML" | grep -q "\"value\""; then
         echo "${GREEN}[ OK ]${NC}"
         ((TESTS_PASSED++))
     else
         echo "${RED}[FAIL]${NC}"
-        echo "  Expected: error_count metric from YAML file"
+        echo "  Expected: f1_score metric from YAML file"
         if [ "$DEBUG_MODE" = true ]; then
             echo "  Response: $ERROR_COUNT_YAML"
         fi
@@ -497,7 +518,7 @@ main() {
 
     # Test 17-18: Time series and status verification
     printf "%-50s " "Verify time series processing (JSON)"
-    JSON_METRIC_COUNT=$(count_metric_points "$RUN_ID" "error_count")
+    JSON_METRIC_COUNT=$(count_metric_points "$RUN_ID" "val_loss")
     if [ "$JSON_METRIC_COUNT" -ge 4 ]; then
         echo "${GREEN}[ OK ]${NC} ($JSON_METRIC_COUNT points)"
         ((TESTS_PASSED++))
@@ -509,7 +530,7 @@ main() {
     ((TESTS_TOTAL++))
 
     printf "%-50s " "Verify time series processing (YAML)"
-    YAML_METRIC_COUNT=$(count_metric_points "$RUN_ID" "error_count")
+    YAML_METRIC_COUNT=$(count_metric_points "$RUN_ID" "f1_score")
     if [ "$YAML_METRIC_COUNT" -ge 3 ]; then
         echo "${GREEN}[ OK ]${NC} ($YAML_METRIC_COUNT points)"
         ((TESTS_PASSED++))